@@ -43,7 +43,7 @@ func ContainsDuplicateCurrency(currencies []*types.Currency) *types.Currency {
 // struct (including currency.Metadata).
 func ContainsCurrency(currencies []*types.Currency, currency *types.Currency) bool {
 	for _, curr := range currencies {
-		if types.Hash(curr) == types.Hash(currency) {
+		if types.CurrenciesEqual(curr, currency) {
 			return true
 		}
 	}
@@ -74,14 +74,18 @@ func AssertUniqueAmounts(amounts []*types.Amount) error {
 	return nil
 }
 
-// AccountBalanceResponse returns an error if the provided
-// types.BlockIdentifier is invalid, if the requestBlock
-// is not nil and not equal to the response block, or
-// if the same currency is present in multiple amounts.
+// AccountBalanceResponse returns an error if the response is
+// nil, if the provided types.BlockIdentifier is invalid, if
+// the requestBlock is not nil and not equal to the response
+// block, or if the same currency is present in multiple amounts.
 func AccountBalanceResponse(
 	requestBlock *types.PartialBlockIdentifier,
 	response *types.AccountBalanceResponse,
 ) error {
+	if response == nil {
+		return ErrAccountBalanceResponseIsNil
+	}
+
 	if err := BlockIdentifier(response.BlockIdentifier); err != nil {
 		return fmt.Errorf("%w: block identifier is invalid", err)
 	}
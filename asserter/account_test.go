@@ -362,4 +362,9 @@ func TestAccountBalance(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("nil response", func(t *testing.T) {
+		err := AccountBalanceResponse(nil, nil)
+		assert.True(t, errors.Is(err, ErrAccountBalanceResponseIsNil))
+	})
 }
@@ -51,6 +51,20 @@ type Validations struct {
 	ChainType        ChainType            `json:"chain_type"`
 	Payment          *ValidationOperation `json:"payment"`
 	Fee              *ValidationOperation `json:"fee"`
+
+	// AmountSigns maps operation types to the sign
+	// their amounts must have (ex: "FEE": "negative").
+	AmountSigns map[string]AmountSign `json:"amount_signs,omitempty"`
+
+	// AllowParseStatus permits operations returned in a
+	// *types.ConstructionParseResponse to have a populated
+	// status. This is applied regardless of Enabled.
+	AllowParseStatus bool `json:"allow_parse_status,omitempty"`
+
+	// MaxOperationMetadataBytes is the maximum size of the
+	// JSON-encoded metadata of any operation (0 means no limit).
+	// This is applied regardless of Enabled.
+	MaxOperationMetadataBytes int `json:"max_operation_metadata_bytes,omitempty"`
 }
 
 type ValidationOperation struct {
@@ -65,6 +79,18 @@ type Operation struct {
 
 type ChainType string
 
+// AmountSign is the sign an operation amount
+// is required to have.
+type AmountSign string
+
+const (
+	// PositiveAmountSign requires an amount to be > 0.
+	PositiveAmountSign AmountSign = "positive"
+
+	// NegativeAmountSign requires an amount to be < 0.
+	NegativeAmountSign AmountSign = "negative"
+)
+
 const (
 	Account ChainType = "account"
 	UTXO    ChainType = "utxo"
@@ -324,5 +350,17 @@ func getValidationConfig(validationFilePath string) (*Validations, error) {
 			return nil, err
 		}
 	}
+
+	for opType, sign := range validationConfig.AmountSigns {
+		if sign != PositiveAmountSign && sign != NegativeAmountSign {
+			return nil, fmt.Errorf(
+				"%w: %s for operation type %s",
+				ErrAmountSignUnsupported,
+				sign,
+				opType,
+			)
+		}
+	}
+
 	return validationConfig, nil
 }
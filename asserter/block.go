@@ -15,6 +15,7 @@
 package asserter
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/big"
 
@@ -208,6 +209,10 @@ func (a *Asserter) Operation(
 		return fmt.Errorf("%w: operation status is invalid in operation %d", err, index)
 	}
 
+	if err := a.operationMetadata(operation.Metadata); err != nil {
+		return fmt.Errorf("%w: metadata is invalid in operation %d", err, index)
+	}
+
 	if operation.Amount == nil {
 		return nil
 	}
@@ -231,6 +236,31 @@ func (a *Asserter) Operation(
 	return nil
 }
 
+// operationMetadata returns an error if the JSON-encoded
+// size of operation metadata exceeds
+// Validations.MaxOperationMetadataBytes.
+func (a *Asserter) operationMetadata(metadata map[string]interface{}) error {
+	if a.validations == nil || a.validations.MaxOperationMetadataBytes <= 0 || len(metadata) == 0 {
+		return nil
+	}
+
+	encoded, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrOperationMetadataTooLarge, err)
+	}
+
+	if len(encoded) > a.validations.MaxOperationMetadataBytes {
+		return fmt.Errorf(
+			"%w: %d bytes > %d bytes",
+			ErrOperationMetadataTooLarge,
+			len(encoded),
+			a.validations.MaxOperationMetadataBytes,
+		)
+	}
+
+	return nil
+}
+
 // BlockIdentifier ensures a types.BlockIdentifier
 // is well-formatted.
 func BlockIdentifier(blockIdentifier *types.BlockIdentifier) error {
@@ -283,8 +313,39 @@ func TransactionIdentifier(
 	return nil
 }
 
+// validateAmountSign returns an error if the amount of
+// an operation does not have the required sign (an operation
+// without an amount has neither sign).
+func validateAmountSign(op *types.Operation, sign AmountSign) error {
+	value := big.NewInt(0)
+	if op.Amount != nil {
+		parsed, ok := new(big.Int).SetString(op.Amount.Value, 10)
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrAmountIsNotInt, op.Amount.Value)
+		}
+		value = parsed
+	}
+
+	if (sign == PositiveAmountSign && value.Sign() <= 0) ||
+		(sign == NegativeAmountSign && value.Sign() >= 0) {
+		return fmt.Errorf(
+			"%w: %s operation %d must have a %s amount",
+			ErrAmountSignInvalid,
+			op.Type,
+			op.OperationIdentifier.Index,
+			sign,
+		)
+	}
+
+	return nil
+}
+
 // Operations returns an error if any *types.Operation
 // in a []*types.Operation is invalid.
+//
+// RelatedOperations do not identify a transaction, so each
+// related operation index must refer to an operation in the
+// same []*types.Operation (with a lower index).
 func (a *Asserter) Operations( // nolint:gocognit
 	operations []*types.Operation,
 	construction bool,
@@ -324,6 +385,12 @@ func (a *Asserter) Operations( // nolint:gocognit
 				feeTotal.Add(feeTotal, val)
 				feeCount++
 			}
+
+			if sign, ok := a.validations.AmountSigns[op.Type]; ok {
+				if err := validateAmountSign(op, sign); err != nil {
+					return err
+				}
+			}
 		}
 
 		// Ensure an operation's related_operations are only
@@ -332,6 +399,15 @@ func (a *Asserter) Operations( // nolint:gocognit
 		relatedIndexes := []int64{}
 		for _, relatedOp := range op.RelatedOperations {
 			relatedOpsExists = true
+			if relatedOp == nil || relatedOp.Index < 0 {
+				return fmt.Errorf(
+					"%w: related operation %s of operation index %d",
+					ErrRelatedOperationIndexOutOfRange,
+					types.PrintStruct(relatedOp),
+					op.OperationIdentifier.Index,
+				)
+			}
+
 			if relatedOp.Index >= op.OperationIdentifier.Index {
 				return fmt.Errorf(
 					"%w: related operation index %d >= operation index %d",
@@ -516,6 +592,21 @@ func Timestamp(timestamp int64) error {
 	}
 }
 
+// genesisTimestamp returns an error if the timestamp
+// on a genesis block is negative or after MaxUnixEpoch.
+// Many chains set the genesis timestamp to 0 (or some
+// other small value), so the MinUnixEpoch check is skipped.
+func genesisTimestamp(timestamp int64) error {
+	switch {
+	case timestamp < 0:
+		return fmt.Errorf("%w: %d", ErrTimestampBeforeMin, timestamp)
+	case timestamp > MaxUnixEpoch:
+		return fmt.Errorf("%w: %d", ErrTimestampAfterMax, timestamp)
+	default:
+		return nil
+	}
+}
+
 // Block runs a basic set of assertions for each returned block.
 func (a *Asserter) Block(
 	block *types.Block,
@@ -549,9 +640,15 @@ func (a *Asserter) Block(
 	}
 
 	// Only check for timestamp validity if timestamp start index is <=
-	// the current block index.
+	// the current block index. The genesis block is exempt from the
+	// MinUnixEpoch check.
 	if a.timestampStartIndex <= block.BlockIdentifier.Index {
-		if err := Timestamp(block.Timestamp); err != nil {
+		timestampCheck := Timestamp
+		if a.genesisBlock.Index == block.BlockIdentifier.Index {
+			timestampCheck = genesisTimestamp
+		}
+
+		if err := timestampCheck(block.Timestamp); err != nil {
 			return err
 		}
 	}
@@ -17,6 +17,7 @@ package asserter
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -304,6 +305,90 @@ func TestOperationsValidations(t *testing.T) {
 		construction       bool
 		err                error
 	}{
+		"valid simple transfer": {
+			operations: types.SimpleTransferOperations(
+				validAccount,
+				&types.AccountIdentifier{Address: "recipient"},
+				validDepositAmount.Value,
+				validDepositAmount.Currency,
+				"PAYMENT",
+			),
+			construction: true,
+		},
+		"related operation in another transaction": {
+			operations: []*types.Operation{
+				{
+					OperationIdentifier: &types.OperationIdentifier{
+						Index: int64(0),
+					},
+					RelatedOperations: []*types.OperationIdentifier{
+						{
+							Index: int64(-1),
+						},
+					},
+					Type:    "PAYMENT",
+					Status:  types.String("SUCCESS"),
+					Account: validAccount,
+					Amount:  validDepositAmount,
+				},
+			},
+			err: ErrRelatedOperationIndexOutOfRange,
+		},
+		"nil related operation": {
+			operations: []*types.Operation{
+				{
+					OperationIdentifier: &types.OperationIdentifier{
+						Index: int64(0),
+					},
+					Type:    "PAYMENT",
+					Status:  types.String("SUCCESS"),
+					Account: validAccount,
+					Amount:  validDepositAmount,
+				},
+				{
+					OperationIdentifier: &types.OperationIdentifier{
+						Index: int64(1),
+					},
+					RelatedOperations: []*types.OperationIdentifier{
+						nil,
+					},
+					Type:    "PAYMENT",
+					Status:  types.String("SUCCESS"),
+					Account: validAccount,
+					Amount:  validWithdrawAmount,
+				},
+			},
+			err: ErrRelatedOperationIndexOutOfRange,
+		},
+		"negative fee with amount signs": {
+			operations: []*types.Operation{
+				{
+					OperationIdentifier: &types.OperationIdentifier{
+						Index: int64(0),
+					},
+					Type:    "FEE",
+					Status:  types.String("SUCCESS"),
+					Account: validAccount,
+					Amount:  validFeeAmount,
+				},
+			},
+			validationFilePath: "data/validation_amount_signs.json",
+		},
+		"positive fee with amount signs": {
+			operations: []*types.Operation{
+				{
+					OperationIdentifier: &types.OperationIdentifier{
+						Index: int64(0),
+					},
+					Type:    "FEE",
+					Status:  types.String("SUCCESS"),
+					Account: validAccount,
+					Amount:  validDepositAmount,
+				},
+			},
+			validationFilePath: "data/validation_amount_signs.json",
+			err:                ErrAmountSignInvalid,
+		},
 		"valid operations based on validation file": {
 			operations: []*types.Operation{
 				{
@@ -575,6 +660,12 @@ func TestOperationsValidations(t *testing.T) {
 	}
 }
 
+func TestAmountSignsUnsupported(t *testing.T) {
+	validations, err := getValidationConfig("data/validation_amount_signs_unsupported.json")
+	assert.Nil(t, validations)
+	assert.True(t, errors.Is(err, ErrAmountSignUnsupported))
+}
+
 func TestOperation(t *testing.T) {
 	var (
 		validAmount = &types.Amount{
@@ -595,6 +686,7 @@ func TestOperation(t *testing.T) {
 		index        int64
 		successful   bool
 		construction bool
+		validation   string
 		err          error
 	}{
 		"valid operation": {
@@ -742,6 +834,58 @@ func TestOperation(t *testing.T) {
 			construction: true,
 			err:          ErrOperationStatusNotEmptyForConstruction,
 		},
+		"valid operation metadata size": {
+			operation: &types.Operation{
+				OperationIdentifier: &types.OperationIdentifier{
+					Index: int64(1),
+				},
+				Type:    "PAYMENT",
+				Status:  types.String("SUCCESS"),
+				Account: validAccount,
+				Amount:  validAmount,
+				Metadata: map[string]interface{}{
+					"memo": "hello",
+				},
+			},
+			index:      int64(1),
+			successful: true,
+			validation: "data/validation_max_operation_metadata_bytes.json",
+			err:        nil,
+		},
+		"invalid operation metadata size": {
+			operation: &types.Operation{
+				OperationIdentifier: &types.OperationIdentifier{
+					Index: int64(1),
+				},
+				Type:    "PAYMENT",
+				Status:  types.String("SUCCESS"),
+				Account: validAccount,
+				Amount:  validAmount,
+				Metadata: map[string]interface{}{
+					"memo": strings.Repeat("a", 1024),
+				},
+			},
+			index:      int64(1),
+			validation: "data/validation_max_operation_metadata_bytes.json",
+			err:        ErrOperationMetadataTooLarge,
+		},
+		"invalid construction operation metadata size": {
+			operation: &types.Operation{
+				OperationIdentifier: &types.OperationIdentifier{
+					Index: int64(1),
+				},
+				Type:    "PAYMENT",
+				Account: validAccount,
+				Amount:  validAmount,
+				Metadata: map[string]interface{}{
+					"memo": strings.Repeat("a", 1024),
+				},
+			},
+			index:        int64(1),
+			construction: true,
+			validation:   "data/validation_max_operation_metadata_bytes.json",
+			err:          ErrOperationMetadataTooLarge,
+		},
 	}
 
 	for name, test := range tests {
@@ -787,7 +931,7 @@ func TestOperation(t *testing.T) {
 					},
 				},
 			},
-			"",
+			test.validation,
 		)
 		assert.NotNil(t, asserter)
 		assert.NoError(t, err)
@@ -1168,16 +1312,37 @@ func TestBlock(t *testing.T) {
 			startIndex:   types.Int64(genesisIdentifier.Index + 1),
 			err:          nil,
 		},
-		"invalid genesis block (with start index)": {
+		"valid genesis block at timestamp 0 (with start index)": {
+			block: &types.Block{
+				BlockIdentifier:       genesisIdentifier,
+				ParentBlockIdentifier: genesisIdentifier,
+				Transactions:          []*types.Transaction{validTransaction},
+			},
+			genesisIndex: genesisIdentifier.Index,
+			startIndex:   types.Int64(genesisIdentifier.Index),
+		},
+		"invalid genesis block with negative timestamp (with start index)": {
 			block: &types.Block{
 				BlockIdentifier:       genesisIdentifier,
 				ParentBlockIdentifier: genesisIdentifier,
+				Timestamp:             -1,
 				Transactions:          []*types.Transaction{validTransaction},
 			},
 			genesisIndex: genesisIdentifier.Index,
 			startIndex:   types.Int64(genesisIdentifier.Index),
 			err:          ErrTimestampBeforeMin,
 		},
+		"invalid genesis block after max timestamp (with start index)": {
+			block: &types.Block{
+				BlockIdentifier:       genesisIdentifier,
+				ParentBlockIdentifier: genesisIdentifier,
+				Timestamp:             MaxUnixEpoch + 1,
+				Transactions:          []*types.Transaction{validTransaction},
+			},
+			genesisIndex: genesisIdentifier.Index,
+			startIndex:   types.Int64(genesisIdentifier.Index),
+			err:          ErrTimestampAfterMax,
+		},
 		"out of order transaction operations": {
 			block: &types.Block{
 				BlockIdentifier:       validBlockIdentifier,
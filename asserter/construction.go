@@ -22,6 +22,8 @@ import (
 
 // ConstructionPreprocessResponse returns an error if
 // the request public keys are not valid AccountIdentifiers.
+// RequiredPublicKeys is optional, so a response without
+// any RequiredPublicKeys is valid.
 func ConstructionPreprocessResponse(
 	response *types.ConstructionPreprocessResponse,
 ) error {
@@ -29,9 +31,9 @@ func ConstructionPreprocessResponse(
 		return ErrConstructionPreprocessResponseIsNil
 	}
 
-	for _, accountIdentifier := range response.RequiredPublicKeys {
+	for i, accountIdentifier := range response.RequiredPublicKeys {
 		if err := AccountIdentifier(accountIdentifier); err != nil {
-			return err
+			return fmt.Errorf("%w: required public key %d is invalid", err, i)
 		}
 	}
 
@@ -112,7 +114,9 @@ func ConstructionDeriveResponse(
 // ConstructionParseResponse returns an error if
 // a *types.ConstructionParseResponse does
 // not have a valid set of operations or
-// if the signers is empty.
+// if the signers is empty. Operations must
+// not have a populated status unless
+// Validations.AllowParseStatus is set.
 func (a *Asserter) ConstructionParseResponse(
 	response *types.ConstructionParseResponse,
 	signed bool,
@@ -129,7 +133,16 @@ func (a *Asserter) ConstructionParseResponse(
 		return ErrConstructionParseResponseOperationsEmpty
 	}
 
-	if err := a.Operations(response.Operations, true); err != nil {
+	operations := response.Operations
+	if a.validations != nil && a.validations.AllowParseStatus {
+		var err error
+		operations, err = a.parseOperationStatuses(operations)
+		if err != nil {
+			return fmt.Errorf("%w unable to parse operations", err)
+		}
+	}
+
+	if err := a.Operations(operations, true); err != nil {
 		return fmt.Errorf("%w unable to parse operations", err)
 	}
 
@@ -156,6 +169,39 @@ func (a *Asserter) ConstructionParseResponse(
 	return nil
 }
 
+// parseOperationStatuses validates any populated status in
+// operations returned by /construction/parse and returns
+// copies of the operations without a status (so they can
+// be validated as construction operations).
+//
+// The Rosetta Specification deprecates populating Operation.Status
+// for construction, however, parsing a signed transaction may
+// reflect what the node already knows about the transaction (ex: if
+// it was already broadcast). When Validations.AllowParseStatus is
+// set, such statuses are allowed as long as they are supported by
+// the network.
+func (a *Asserter) parseOperationStatuses(
+	operations []*types.Operation,
+) ([]*types.Operation, error) {
+	parsed := make([]*types.Operation, len(operations))
+	for i, op := range operations {
+		if op == nil || op.Status == nil || len(*op.Status) == 0 {
+			parsed[i] = op
+			continue
+		}
+
+		if err := a.OperationStatus(op.Status, false); err != nil {
+			return nil, fmt.Errorf("%w: operation status is invalid in operation %d", err, i)
+		}
+
+		opCopy := *op
+		opCopy.Status = nil
+		parsed[i] = &opCopy
+	}
+
+	return parsed, nil
+}
+
 // ConstructionPayloadsResponse returns an error if
 // a *types.ConstructionPayloadsResponse does
 // not have an UnsignedTransaction or has no
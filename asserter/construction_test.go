@@ -57,6 +57,17 @@ func TestConstructionPreprocessResponse(t *testing.T) {
 			},
 			err: ErrAccountAddrMissing,
 		},
+		"invalid response with nil account": {
+			response: &types.ConstructionPreprocessResponse{
+				RequiredPublicKeys: []*types.AccountIdentifier{
+					{
+						Address: "hello",
+					},
+					nil,
+				},
+			},
+			err: ErrAccountIsNil,
+		},
 		"nil response": {
 			err: ErrConstructionPreprocessResponseIsNil,
 		},
@@ -514,6 +525,120 @@ func TestConstructionParseResponse(t *testing.T) {
 	}
 }
 
+func TestConstructionParseResponseStatus(t *testing.T) {
+	operations := func(status *string) []*types.Operation {
+		return []*types.Operation{
+			{
+				OperationIdentifier: &types.OperationIdentifier{
+					Index: int64(0),
+				},
+				Type:    "PAYMENT",
+				Status:  status,
+				Account: validAccount,
+				Amount:  validAmount,
+			},
+		}
+	}
+
+	var tests = map[string]struct {
+		validationFilePath string
+		status             *string
+		err                error
+	}{
+		"no status": {
+			status: nil,
+		},
+		"empty status": {
+			status: types.String(""),
+		},
+		"status": {
+			status: types.String("SUCCESS"),
+			err:    ErrOperationStatusNotEmptyForConstruction,
+		},
+		"no status (allow parse status)": {
+			validationFilePath: "data/validation_allow_parse_status.json",
+			status:             nil,
+		},
+		"status (allow parse status)": {
+			validationFilePath: "data/validation_allow_parse_status.json",
+			status:             types.String("SUCCESS"),
+		},
+		"invalid status (allow parse status)": {
+			validationFilePath: "data/validation_allow_parse_status.json",
+			status:             types.String("PENDING"),
+			err:                ErrOperationStatusInvalid,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			asserter, err := NewClientWithResponses(
+				&types.NetworkIdentifier{
+					Blockchain: "hello",
+					Network:    "world",
+				},
+				&types.NetworkStatusResponse{
+					GenesisBlockIdentifier: &types.BlockIdentifier{
+						Index: 0,
+						Hash:  "block 0",
+					},
+					CurrentBlockIdentifier: &types.BlockIdentifier{
+						Index: 100,
+						Hash:  "block 100",
+					},
+					CurrentBlockTimestamp: MinUnixEpoch + 1,
+					Peers: []*types.Peer{
+						{
+							PeerID: "peer 1",
+						},
+					},
+				},
+				&types.NetworkOptionsResponse{
+					Version: &types.Version{
+						RosettaVersion: "1.4.0",
+						NodeVersion:    "1.0",
+					},
+					Allow: &types.Allow{
+						OperationStatuses: []*types.OperationStatus{
+							{
+								Status:     "SUCCESS",
+								Successful: true,
+							},
+						},
+						OperationTypes: []string{
+							"PAYMENT",
+						},
+					},
+				},
+				test.validationFilePath,
+			)
+			assert.NotNil(t, asserter)
+			assert.NoError(t, err)
+
+			for _, signed := range []bool{true, false} {
+				response := &types.ConstructionParseResponse{
+					Operations: operations(test.status),
+				}
+				if signed {
+					response.AccountIdentifierSigners = []*types.AccountIdentifier{
+						validAccount,
+					}
+				}
+
+				err := asserter.ConstructionParseResponse(response, signed)
+				if test.err != nil {
+					assert.True(t, errors.Is(err, test.err))
+				} else {
+					assert.NoError(t, err)
+				}
+
+				// The response is never modified
+				assert.Equal(t, test.status, response.Operations[0].Status)
+			}
+		})
+	}
+}
+
 func TestConstructionPayloadsResponse(t *testing.T) {
 	var tests = map[string]struct {
 		response *types.ConstructionPayloadsResponse
@@ -34,10 +34,12 @@ var (
 	ErrReturnedBlockIndexMismatch = errors.New(
 		"request block index does not match response block index",
 	)
+	ErrAccountBalanceResponseIsNil = errors.New("AccountBalanceResponse is nil")
 
 	AccountBalanceErrs = []error{
 		ErrReturnedBlockHashMismatch,
 		ErrReturnedBlockIndexMismatch,
+		ErrAccountBalanceResponseIsNil,
 	}
 )
 
@@ -70,6 +72,9 @@ var (
 	ErrRelatedOperationIndexOutOfOrder = errors.New(
 		"related operation has index greater than operation",
 	)
+	ErrRelatedOperationIndexOutOfRange = errors.New(
+		"related operation index is not in the transaction",
+	)
 	ErrRelatedOperationIndexDuplicate  = errors.New("found duplicate related operation index")
 	ErrRelatedOperationMissing         = errors.New("related operations key is missing")
 	ErrRelatedOperationInFeeNotAllowed = errors.New(
@@ -103,6 +108,9 @@ var (
 	ErrFeeAmountNotBalancing       = errors.New("fee amount doesn't balance")
 	ErrPaymentCountMismatch        = errors.New("payment count doesn't match")
 	ErrFeeCountMismatch            = errors.New("fee count doesn't match")
+	ErrAmountSignInvalid           = errors.New("operation amount has invalid sign")
+	ErrAmountSignUnsupported       = errors.New("amount sign is not supported")
+	ErrOperationMetadataTooLarge   = errors.New("operation metadata is too large")
 
 	BlockErrs = []error{
 		ErrAmountValueMissing,
@@ -122,6 +130,7 @@ var (
 		ErrOperationIsNil,
 		ErrOperationStatusNotEmptyForConstruction,
 		ErrRelatedOperationIndexOutOfOrder,
+		ErrRelatedOperationIndexOutOfRange,
 		ErrRelatedOperationIndexDuplicate,
 		ErrRelatedOperationMissing,
 		ErrBlockIdentifierIsNil,
@@ -142,6 +151,9 @@ var (
 		ErrDuplicateRelatedTransaction,
 		ErrPaymentAmountNotBalancing,
 		ErrFeeAmountNotBalancing,
+		ErrAmountSignInvalid,
+		ErrAmountSignUnsupported,
+		ErrOperationMetadataTooLarge,
 	}
 )
 
@@ -73,6 +73,11 @@ const (
 	// GenerateKey creates a new *keys.KeyPair.
 	GenerateKey ActionType = "generate_key"
 
+	// GenerateKeys creates an array of *keys.KeyPair (of
+	// some count up to MaxGenerateKeys) in a single step.
+	// This is useful when provisioning a pool of accounts.
+	GenerateKeys ActionType = "generate_keys"
+
 	// SaveAccount saves a generated *keys.KeyPair
 	// and *types.AccountIdentifier to key storage.
 	SaveAccount ActionType = "save_account"
@@ -80,6 +85,11 @@ const (
 	// Derive calls `/construction/derive` with a *keys.PublicKey.
 	Derive ActionType = "derive"
 
+	// DeriveBatch calls `/construction/derive` with the
+	// public key of each *keys.KeyPair in an array (usually
+	// created with GenerateKeys).
+	DeriveBatch ActionType = "derive_batch"
+
 	// SetVariable allows for setting the value of any
 	// variable (as opposed to calculating it using
 	// other actions).
@@ -106,6 +116,11 @@ const (
 	// on UTXO blockchains.
 	Math ActionType = "math"
 
+	// CalculateFee multiplies a per-unit fee rate by some estimated
+	// size (usually bytes) to determine the fee to pay for a transaction.
+	// This is most commonly used on blockchains with size-based fees.
+	CalculateFee ActionType = "calculate_fee"
+
 	// RandomString generates a string according to some provided regex.
 	// It is used to generate account names for blockchains that require
 	// on-chain origination.
@@ -149,6 +164,26 @@ const (
 	// GetBlob attempts to retrieve some previously saved blob.
 	// If the blob is not accessible, it will return an error.
 	GetBlob ActionType = "get_blob"
+
+	// SaveState checkpoints the entire job state at some key. If
+	// a checkpoint at a key already exists, it will be overwritten.
+	//
+	// Checkpoints are stored with the Helper's SetBlob in the same
+	// database.Transaction used to process the rest of the scenario,
+	// so a checkpoint is only durable once that transaction is
+	// committed (and is discarded with it otherwise).
+	SaveState ActionType = "save_state"
+
+	// LoadState retrieves the job state previously checkpointed
+	// at some key with SaveState and stores it at the OutputPath.
+	// If no checkpoint exists, it will return an error.
+	LoadState ActionType = "load_state"
+
+	// Transform converts a value from one Codec to
+	// another (ex: hex to base64). This is useful when a
+	// transaction blob or public key must be provided in
+	// a different encoding than it was returned in.
+	Transform ActionType = "transform"
 )
 
 // Action is a step of computation that
@@ -164,6 +199,23 @@ type GenerateKeyInput struct {
 	CurveType types.CurveType `json:"curve_type"`
 }
 
+// MaxGenerateKeys is the maximum number of keys
+// that can be created in a single GenerateKeys action.
+const MaxGenerateKeys = 1000
+
+// GenerateKeysInput is the input for GenerateKeys.
+type GenerateKeysInput struct {
+	Count     int             `json:"count"`
+	CurveType types.CurveType `json:"curve_type"`
+}
+
+// DeriveBatchInput is the input for DeriveBatch.
+type DeriveBatchInput struct {
+	NetworkIdentifier *types.NetworkIdentifier `json:"network_identifier"`
+	KeyPairs          []*keys.KeyPair          `json:"keypairs"`
+	Metadata          map[string]interface{}   `json:"metadata,omitempty"`
+}
+
 // SaveAccountInput is the input for SaveAccount.
 type SaveAccountInput struct {
 	AccountIdentifier *types.AccountIdentifier `json:"account_identifier"`
@@ -202,6 +254,20 @@ type MathInput struct {
 	RightValue string        `json:"right_value"`
 }
 
+// MaxFeeBitLength is the maximum bit length of a fee
+// computed by CalculateFee. Any larger result is considered
+// an overflow.
+const MaxFeeBitLength = 256
+
+// CalculateFeeInput is the input to CalculateFee.
+type CalculateFeeInput struct {
+	// FeeRate is the fee to pay per unit of size (ex: satoshis per byte).
+	FeeRate string `json:"fee_rate"`
+
+	// Size is the estimated size of the transaction (ex: bytes).
+	Size string `json:"size"`
+}
+
 // FindBalanceInput is the input to FindBalance.
 type FindBalanceInput struct {
 	// AccountIdentifier can be optionally provided to ensure the balance returned
@@ -312,6 +378,37 @@ type GetBlobInput struct {
 	Key interface{} `json:"key"`
 }
 
+// SaveStateInput is the input to
+// SaveState.
+type SaveStateInput struct {
+	Key string `json:"key"`
+}
+
+// LoadStateInput is the input to
+// LoadState.
+type LoadStateInput struct {
+	Key string `json:"key"`
+}
+
+// Codec is an encoding supported by Transform.
+type Codec string
+
+const (
+	// HexCodec is hex encoding (without a 0x prefix).
+	HexCodec Codec = "hex"
+
+	// Base64Codec is standard base64 encoding (with padding).
+	Base64Codec Codec = "base64"
+)
+
+// TransformInput is the input to
+// Transform.
+type TransformInput struct {
+	Value string `json:"value"`
+	From  Codec  `json:"from"`
+	To    Codec  `json:"to"`
+}
+
 // Scenario is a collection of Actions with a specific
 // confirmation depth.
 //
@@ -0,0 +1,246 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/coinbase/rosetta-sdk-go/constructor/job"
+	"github.com/coinbase/rosetta-sdk-go/keys"
+	"github.com/coinbase/rosetta-sdk-go/storage/database"
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+const (
+	// dryRunTransactionHash is the hash of the transaction
+	// injected into state for each broadcast in a dry run.
+	dryRunTransactionHash = "dry_run"
+)
+
+var _ Helper = (*DryRunHelper)(nil)
+
+// DryRunHelper is a Helper that returns canned responses
+// instead of calling a Rosetta implementation or persisting
+// anything to storage. It is intended to be used with DryRun.
+type DryRunHelper struct {
+	// DeriveFunc is invoked on Derive. If not populated,
+	// the hex-encoded public key is used as the address.
+	DeriveFunc func(
+		context.Context,
+		*types.NetworkIdentifier,
+		*types.PublicKey,
+		map[string]interface{},
+	) (*types.AccountIdentifier, map[string]interface{}, error)
+
+	accounts []*types.AccountIdentifier
+	keys     map[string]*keys.KeyPair
+	balances map[string]*types.Amount
+	coins    map[string][]*types.Coin
+	blobs    map[string][]byte
+}
+
+// NewDryRunHelper returns a new *DryRunHelper.
+func NewDryRunHelper() *DryRunHelper {
+	return &DryRunHelper{
+		keys:     map[string]*keys.KeyPair{},
+		balances: map[string]*types.Amount{},
+		coins:    map[string][]*types.Coin{},
+		blobs:    map[string][]byte{},
+	}
+}
+
+func accountCurrencyKey(account *types.AccountIdentifier, currency *types.Currency) string {
+	return types.Hash(&types.AccountCurrency{Account: account, Currency: currency})
+}
+
+// AddAccount adds an account to the accounts returned
+// by AllAccounts.
+func (h *DryRunHelper) AddAccount(account *types.AccountIdentifier) {
+	h.accounts = append(h.accounts, account)
+}
+
+// SetBalance sets the balance returned for an account
+// in amount.Currency.
+func (h *DryRunHelper) SetBalance(account *types.AccountIdentifier, amount *types.Amount) {
+	h.balances[accountCurrencyKey(account, amount.Currency)] = amount
+}
+
+// SetCoins sets the coins returned for an account
+// in a currency.
+func (h *DryRunHelper) SetCoins(
+	account *types.AccountIdentifier,
+	currency *types.Currency,
+	coins []*types.Coin,
+) {
+	h.coins[accountCurrencyKey(account, currency)] = coins
+}
+
+// Key returns the *keys.KeyPair stored for an account
+// (if it exists).
+func (h *DryRunHelper) Key(account *types.AccountIdentifier) (*keys.KeyPair, bool) {
+	keyPair, ok := h.keys[types.Hash(account)]
+	return keyPair, ok
+}
+
+// StoreKey records the provided account and KeyPair
+// in memory.
+func (h *DryRunHelper) StoreKey(
+	ctx context.Context,
+	dbTx database.Transaction,
+	account *types.AccountIdentifier,
+	keyPair *keys.KeyPair,
+) error {
+	if _, ok := h.keys[types.Hash(account)]; !ok {
+		h.accounts = append(h.accounts, account)
+	}
+
+	h.keys[types.Hash(account)] = keyPair
+	return nil
+}
+
+// AllAccounts returns all stored or added accounts.
+func (h *DryRunHelper) AllAccounts(
+	ctx context.Context,
+	dbTx database.Transaction,
+) ([]*types.AccountIdentifier, error) {
+	return h.accounts, nil
+}
+
+// LockedAccounts always returns no accounts because
+// nothing is broadcast in a dry run.
+func (h *DryRunHelper) LockedAccounts(
+	ctx context.Context,
+	dbTx database.Transaction,
+) ([]*types.AccountIdentifier, error) {
+	return []*types.AccountIdentifier{}, nil
+}
+
+// Balance returns the balance set with SetBalance (or
+// a zero balance if none was set).
+func (h *DryRunHelper) Balance(
+	ctx context.Context,
+	dbTx database.Transaction,
+	account *types.AccountIdentifier,
+	currency *types.Currency,
+) (*types.Amount, error) {
+	if amount, ok := h.balances[accountCurrencyKey(account, currency)]; ok {
+		return amount, nil
+	}
+
+	return &types.Amount{Value: "0", Currency: currency}, nil
+}
+
+// Coins returns the coins set with SetCoins (or no
+// coins if none were set).
+func (h *DryRunHelper) Coins(
+	ctx context.Context,
+	dbTx database.Transaction,
+	account *types.AccountIdentifier,
+	currency *types.Currency,
+) ([]*types.Coin, error) {
+	if coins, ok := h.coins[accountCurrencyKey(account, currency)]; ok {
+		return coins, nil
+	}
+
+	return []*types.Coin{}, nil
+}
+
+// Derive invokes DeriveFunc (if populated) or returns
+// the hex-encoded public key as the address.
+func (h *DryRunHelper) Derive(
+	ctx context.Context,
+	network *types.NetworkIdentifier,
+	publicKey *types.PublicKey,
+	metadata map[string]interface{},
+) (*types.AccountIdentifier, map[string]interface{}, error) {
+	if h.DeriveFunc != nil {
+		return h.DeriveFunc(ctx, network, publicKey, metadata)
+	}
+
+	return &types.AccountIdentifier{Address: hex.EncodeToString(publicKey.Bytes)}, nil, nil
+}
+
+// SetBlob stores a key and value in memory.
+func (h *DryRunHelper) SetBlob(
+	ctx context.Context,
+	dbTx database.Transaction,
+	key string,
+	value []byte,
+) error {
+	h.blobs[key] = value
+	return nil
+}
+
+// GetBlob retrieves a key and value from memory.
+func (h *DryRunHelper) GetBlob(
+	ctx context.Context,
+	dbTx database.Transaction,
+	key string,
+) (bool, []byte, error) {
+	value, ok := h.blobs[key]
+	return ok, value, nil
+}
+
+// DryRun executes all remaining scenarios in a copy of a
+// *job.Job with the provided Helper (usually a *DryRunHelper)
+// and returns the final state. Instead of broadcasting, a
+// transaction containing the intent is injected into state
+// (and dry run broadcasts receive no suggested fee), so
+// workflows can be validated without a Rosetta implementation.
+//
+// Note that HTTPRequest actions are still executed.
+func DryRun(ctx context.Context, j *job.Job, helper Helper) (string, *Error) {
+	w := New(helper)
+	dryRunJob := *j
+
+	for !dryRunJob.CheckComplete() {
+		broadcast, err := w.Process(ctx, nil, &dryRunJob)
+		if err != nil {
+			return dryRunJob.State, err
+		}
+
+		if broadcast == nil {
+			continue
+		}
+
+		var completeErr error
+		if broadcast.DryRun {
+			completeErr = dryRunJob.DryRunComplete(ctx, []*types.Amount{})
+		} else {
+			completeErr = dryRunJob.BroadcastComplete(ctx, &types.Transaction{
+				TransactionIdentifier: &types.TransactionIdentifier{
+					Hash: dryRunTransactionHash,
+				},
+				Operations: broadcast.Intent,
+			})
+		}
+
+		if completeErr != nil {
+			scenarioIndex := dryRunJob.Index - 1
+			return dryRunJob.State, &Error{
+				Workflow:      dryRunJob.Workflow,
+				Job:           dryRunJob.Identifier,
+				ScenarioIndex: scenarioIndex,
+				Scenario:      dryRunJob.Scenarios[scenarioIndex].Name,
+				State:         dryRunJob.State,
+				Err:           fmt.Errorf("%w: unable to complete broadcast", completeErr),
+			}
+		}
+	}
+
+	return dryRunJob.State, nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/coinbase/rosetta-sdk-go/constructor/job"
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+func TestDryRun(t *testing.T) {
+	account := &types.AccountIdentifier{Address: "sender"}
+	currency := &types.Currency{Symbol: "BTC", Decimals: 8}
+
+	workflow := &job.Workflow{
+		Name: "transfer",
+		Scenarios: []*job.Scenario{
+			{
+				Name: "create_account",
+				Actions: []*job.Action{
+					{
+						Type:       job.SetVariable,
+						Input:      `{"network":"Testnet3", "blockchain":"Bitcoin"}`,
+						OutputPath: "network",
+					},
+					{
+						Type:       job.GenerateKey,
+						Input:      `{"curve_type": "secp256k1"}`,
+						OutputPath: "key",
+					},
+					{
+						Type:       job.Derive,
+						Input:      `{"network_identifier": {{network}}, "public_key": {{key.public_key}}}`,
+						OutputPath: "account",
+					},
+					{
+						Type:  job.SaveAccount,
+						Input: `{"account_identifier": {{account.account_identifier}}, "keypair": {{key}}}`,
+					},
+				},
+			},
+			{
+				Name: "transfer",
+				Actions: []*job.Action{
+					{
+						Type:       job.FindBalance,
+						Input:      `{"minimum_balance":{"value":"10","currency":{"symbol":"BTC","decimals":8}}}`, // nolint
+						OutputPath: "sender",
+					},
+					{
+						Type:       job.SetVariable,
+						Input:      `[{"operation_identifier":{"index":0},"type":"","account":{{sender.account_identifier}},"amount":{"value":"-10","currency":{{sender.balance.currency}}}}]`, // nolint
+						OutputPath: "transfer.operations",
+					},
+					{
+						Type:       job.SetVariable,
+						Input:      `{{network}}`,
+						OutputPath: "transfer.network",
+					},
+					{
+						Type:       job.SetVariable,
+						Input:      `"1"`,
+						OutputPath: "transfer.confirmation_depth",
+					},
+				},
+			},
+			{
+				Name: "verify",
+				Actions: []*job.Action{
+					{
+						Type:       job.SetVariable,
+						Input:      `{{transfer.transaction.transaction_identifier.hash}}`,
+						OutputPath: "hash",
+					},
+				},
+			},
+		},
+	}
+
+	var tests = map[string]struct {
+		balance *types.Amount
+
+		expectedHash string
+		expectedErr  error
+	}{
+		"sufficient balance": {
+			balance:      &types.Amount{Value: "100", Currency: currency},
+			expectedHash: dryRunTransactionHash,
+		},
+		"insufficient balance": {
+			balance:     &types.Amount{Value: "5", Currency: currency},
+			expectedErr: ErrUnsatisfiable,
+		},
+		"no balance": {
+			expectedErr: ErrUnsatisfiable,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			helper := NewDryRunHelper()
+			helper.DeriveFunc = func(
+				context.Context,
+				*types.NetworkIdentifier,
+				*types.PublicKey,
+				map[string]interface{},
+			) (*types.AccountIdentifier, map[string]interface{}, error) {
+				return account, nil, nil
+			}
+			if test.balance != nil {
+				helper.SetBalance(account, test.balance)
+			}
+
+			j := job.New(workflow)
+			state, err := DryRun(ctx, j, helper)
+
+			// The provided job should not be modified.
+			assert.Equal(t, 0, j.Index)
+			assert.Equal(t, "", j.State)
+
+			keyPair, ok := helper.Key(account)
+			assert.True(t, ok)
+			assert.NotNil(t, keyPair)
+
+			if test.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.True(t, errors.Is(err.Err, test.expectedErr))
+				assert.Equal(t, "transfer", err.Scenario)
+				assert.Equal(t, state, err.State)
+				return
+			}
+
+			assert.Nil(t, err)
+			assertVariableEquality(t, state, "account.account_identifier", account)
+			assertVariableEquality(t, state, "transfer.transaction.operations.0.account", account)
+			assertVariableEquality(t, state, "hash", test.expectedHash)
+		})
+	}
+}
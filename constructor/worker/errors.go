@@ -45,6 +45,14 @@ var (
 	// type.
 	ErrInvalidActionType = errors.New("invalid action type")
 
+	// ErrActionAlreadyRegistered is returned when a custom
+	// action is registered for an ActionType more than once.
+	ErrActionAlreadyRegistered = errors.New("action already registered")
+
+	// ErrBuiltInAction is returned when a custom action
+	// is registered for a built-in ActionType.
+	ErrBuiltInAction = errors.New("cannot register built-in action")
+
 	// ErrActionFailed is returned when Action exeuction fails with a valid input.
 	ErrActionFailed = errors.New("action execution failed")
 
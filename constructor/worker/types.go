@@ -17,6 +17,7 @@ package worker
 import (
 	"context"
 
+	"github.com/coinbase/rosetta-sdk-go/constructor/job"
 	"github.com/coinbase/rosetta-sdk-go/keys"
 	"github.com/coinbase/rosetta-sdk-go/storage/database"
 	"github.com/coinbase/rosetta-sdk-go/types"
@@ -89,7 +90,14 @@ type Helper interface {
 	) (bool, []byte, error)
 }
 
+// ActionFunc processes the populated input of a custom
+// action. If the action has an output path, the returned
+// output must be valid JSON.
+type ActionFunc func(ctx context.Context, input string) (string, error)
+
 // Worker processes jobs.
 type Worker struct {
 	helper Helper
+
+	customActions map[job.ActionType]ActionFunc
 }
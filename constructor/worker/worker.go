@@ -17,10 +17,14 @@ package worker
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
+	"math/big"
 	"net/http"
 	"net/url"
 	"os"
@@ -37,18 +41,71 @@ import (
 	"github.com/coinbase/rosetta-sdk-go/utils"
 )
 
+const (
+	// stateNamespace prefixes the keys used to store
+	// checkpoints created by SaveState. Blob keys are
+	// always JSON, so these keys cannot collide with them.
+	stateNamespace = "state"
+)
+
 // New returns a new *Worker.
 func New(helper Helper) *Worker {
-	return &Worker{helper: helper}
+	return &Worker{
+		helper:        helper,
+		customActions: map[job.ActionType]ActionFunc{},
+	}
+}
+
+// RegisterAction adds support for a custom ActionType (like
+// a chain-specific address encoder). Built-in actions cannot
+// be overridden, so registering a built-in ActionType returns
+// ErrBuiltInAction. Note that the DSL parser only supports
+// built-in actions, so workflows using custom actions must be
+// defined in JSON.
+//
+// This must be called before any jobs are processed.
+func (w *Worker) RegisterAction(actionType job.ActionType, fn ActionFunc) error {
+	if builtInAction(actionType) {
+		return fmt.Errorf("%w: %s", ErrBuiltInAction, actionType)
+	}
+
+	if _, ok := w.customActions[actionType]; ok {
+		return fmt.Errorf("%w: %s", ErrActionAlreadyRegistered, actionType)
+	}
+
+	w.customActions[actionType] = fn
+	return nil
+}
+
+// builtInAction returns a boolean indicating if
+// actionType is handled by invokeWorker.
+func builtInAction(actionType job.ActionType) bool {
+	switch actionType {
+	case job.SetVariable, job.GenerateKey, job.GenerateKeys, job.Derive,
+		job.DeriveBatch, job.SaveAccount, job.PrintMessage, job.RandomString,
+		job.Math, job.CalculateFee, job.FindBalance, job.RandomNumber,
+		job.Assert, job.FindCurrencyAmount, job.LoadEnv, job.HTTPRequest,
+		job.SetBlob, job.GetBlob, job.SaveState, job.LoadState, job.Transform:
+		return true
+	default:
+		return false
+	}
 }
 
+// marshalString JSON-encodes a string so that it can be
+// safely stored in job state (quotes, backslashes, and
+// control characters are escaped).
 func marshalString(value string) string {
-	return fmt.Sprintf(`"%s"`, value)
+	// Marshaling a string never returns an error (invalid
+	// UTF-8 is coerced to the Unicode replacement character).
+	encoded, _ := json.Marshal(value)
+	return string(encoded)
 }
 
 func (w *Worker) invokeWorker(
 	ctx context.Context,
 	dbTx database.Transaction,
+	state string,
 	action job.ActionType,
 	input string,
 ) (string, error) {
@@ -57,8 +114,12 @@ func (w *Worker) invokeWorker(
 		return input, nil
 	case job.GenerateKey:
 		return GenerateKeyWorker(input)
+	case job.GenerateKeys:
+		return GenerateKeysWorker(input)
 	case job.Derive:
 		return w.DeriveWorker(ctx, input)
+	case job.DeriveBatch:
+		return w.DeriveBatchWorker(ctx, input)
 	case job.SaveAccount:
 		return "", w.SaveAccountWorker(ctx, dbTx, input)
 	case job.PrintMessage:
@@ -68,6 +129,8 @@ func (w *Worker) invokeWorker(
 		return RandomStringWorker(input)
 	case job.Math:
 		return MathWorker(input)
+	case job.CalculateFee:
+		return CalculateFeeWorker(input)
 	case job.FindBalance:
 		return w.FindBalanceWorker(ctx, dbTx, input)
 	case job.RandomNumber:
@@ -84,7 +147,17 @@ func (w *Worker) invokeWorker(
 		return "", w.SetBlobWorker(ctx, dbTx, input)
 	case job.GetBlob:
 		return w.GetBlobWorker(ctx, dbTx, input)
+	case job.SaveState:
+		return "", w.SaveStateWorker(ctx, dbTx, state, input)
+	case job.LoadState:
+		return w.LoadStateWorker(ctx, dbTx, input)
+	case job.Transform:
+		return TransformWorker(input)
 	default:
+		if fn, ok := w.customActions[action]; ok {
+			return fn(ctx, input)
+		}
+
 		return "", fmt.Errorf("%w: %s", ErrInvalidActionType, action)
 	}
 }
@@ -106,7 +179,7 @@ func (w *Worker) actions(
 			}
 		}
 
-		output, err := w.invokeWorker(ctx, dbTx, action.Type, processedInput)
+		output, err := w.invokeWorker(ctx, dbTx, state, action.Type, processedInput)
 		if err != nil {
 			return "", &Error{
 				ActionIndex:    i,
@@ -244,6 +317,89 @@ func GenerateKeyWorker(rawInput string) (string, error) {
 	return types.PrintStruct(kp), nil
 }
 
+// GenerateKeysWorker attempts to generate an array of
+// keys given a *GenerateKeysInput input.
+func GenerateKeysWorker(rawInput string) (string, error) {
+	var input job.GenerateKeysInput
+	err := job.UnmarshalInput([]byte(rawInput), &input)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidInput, err.Error())
+	}
+
+	if input.Count <= 0 || input.Count > job.MaxGenerateKeys {
+		return "", fmt.Errorf(
+			"%w: count %d must be between 1 and %d",
+			ErrInvalidInput,
+			input.Count,
+			job.MaxGenerateKeys,
+		)
+	}
+
+	keyPairs := make([]*keys.KeyPair, input.Count)
+	for i := range keyPairs {
+		keyPairs[i], err = keys.GenerateKeypair(input.CurveType)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s", ErrActionFailed, err.Error())
+		}
+	}
+
+	return types.PrintStruct(keyPairs), nil
+}
+
+// DeriveBatchWorker attempts to derive an account for each
+// *keys.KeyPair in a *DeriveBatchInput input. The output is
+// an array of *types.ConstructionDeriveResponse (in the same
+// order as the provided KeyPairs).
+func (w *Worker) DeriveBatchWorker(
+	ctx context.Context,
+	rawInput string,
+) (string, error) {
+	var input job.DeriveBatchInput
+	err := job.UnmarshalInput([]byte(rawInput), &input)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidInput, err.Error())
+	}
+
+	if len(input.KeyPairs) == 0 || len(input.KeyPairs) > job.MaxGenerateKeys {
+		return "", fmt.Errorf(
+			"%w: %d keypairs provided (must be between 1 and %d)",
+			ErrInvalidInput,
+			len(input.KeyPairs),
+			job.MaxGenerateKeys,
+		)
+	}
+
+	for i, keyPair := range input.KeyPairs {
+		if keyPair == nil {
+			return "", fmt.Errorf("%w: keypair %d is nil", ErrInvalidInput, i)
+		}
+
+		if err := asserter.PublicKey(keyPair.PublicKey); err != nil {
+			return "", fmt.Errorf("%w: keypair %d %s", ErrInvalidInput, i, err.Error())
+		}
+	}
+
+	responses := make([]*types.ConstructionDeriveResponse, len(input.KeyPairs))
+	for i, keyPair := range input.KeyPairs {
+		accountIdentifier, metadata, err := w.helper.Derive(
+			ctx,
+			input.NetworkIdentifier,
+			keyPair.PublicKey,
+			input.Metadata,
+		)
+		if err != nil {
+			return "", fmt.Errorf("%w: keypair %d %s", ErrActionFailed, i, err.Error())
+		}
+
+		responses[i] = &types.ConstructionDeriveResponse{
+			AccountIdentifier: accountIdentifier,
+			Metadata:          metadata,
+		}
+	}
+
+	return types.PrintStruct(responses), nil
+}
+
 // SaveAccountWorker saves a *types.AccountIdentifier and associated KeyPair
 // in KeyStorage.
 func (w *Worker) SaveAccountWorker(
@@ -318,6 +474,98 @@ func MathWorker(rawInput string) (string, error) {
 	return marshalString(result), nil
 }
 
+// CalculateFeeWorker multiplies a fee rate by an estimated
+// size to determine the fee of a transaction.
+func CalculateFeeWorker(rawInput string) (string, error) {
+	var input job.CalculateFeeInput
+	err := job.UnmarshalInput([]byte(rawInput), &input)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidInput, err.Error())
+	}
+
+	feeRate, err := types.BigInt(input.FeeRate)
+	if err != nil {
+		return "", fmt.Errorf("%w: fee rate %s", ErrInvalidInput, err.Error())
+	}
+
+	if feeRate.Sign() < 0 {
+		return "", fmt.Errorf("%w: fee rate %s < 0", ErrInvalidInput, feeRate.String())
+	}
+
+	size, err := types.BigInt(input.Size)
+	if err != nil {
+		return "", fmt.Errorf("%w: size %s", ErrInvalidInput, err.Error())
+	}
+
+	if size.Sign() < 0 {
+		return "", fmt.Errorf("%w: size %s < 0", ErrInvalidInput, size.String())
+	}
+
+	fee := new(big.Int).Mul(feeRate, size)
+	if fee.BitLen() > job.MaxFeeBitLength {
+		return "", fmt.Errorf(
+			"%w: fee %s exceeds %d bits",
+			ErrActionFailed,
+			fee.String(),
+			job.MaxFeeBitLength,
+		)
+	}
+
+	return marshalString(fee.String()), nil
+}
+
+// decodeValue decodes a value encoded with codec.
+func decodeValue(value string, codec job.Codec) ([]byte, error) {
+	switch codec {
+	case job.HexCodec:
+		return hex.DecodeString(value)
+	case job.Base64Codec:
+		return base64.StdEncoding.DecodeString(value)
+	default:
+		return nil, fmt.Errorf("%s is not a supported codec", codec)
+	}
+}
+
+// encodeValue encodes a value with codec.
+func encodeValue(value []byte, codec job.Codec) (string, error) {
+	switch codec {
+	case job.HexCodec:
+		return hex.EncodeToString(value), nil
+	case job.Base64Codec:
+		return base64.StdEncoding.EncodeToString(value), nil
+	default:
+		return "", fmt.Errorf("%s is not a supported codec", codec)
+	}
+}
+
+// TransformWorker converts a value from one
+// Codec to another.
+func TransformWorker(rawInput string) (string, error) {
+	var input job.TransformInput
+	err := job.UnmarshalInput([]byte(rawInput), &input)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidInput, err.Error())
+	}
+
+	decoded, err := decodeValue(input.Value, input.From)
+	if err != nil {
+		return "", fmt.Errorf(
+			"%w: unable to decode %s from %s: %s",
+			ErrInvalidInput,
+			input.Value,
+			input.From,
+			err.Error(),
+		)
+	}
+
+	encoded, err := encodeValue(decoded, input.To)
+	if err != nil {
+		return "", fmt.Errorf("%w: unable to encode to %s: %s", ErrInvalidInput, input.To, err.Error())
+	}
+
+	return marshalString(encoded), nil
+}
+
 // RandomNumberWorker generates a random number in the range
 // [minimum,maximum).
 func RandomNumberWorker(rawInput string) (string, error) {
@@ -746,7 +994,7 @@ func FindCurrencyAmountWorker(rawInput string) (string, error) {
 	}
 
 	for _, amount := range input.Amounts {
-		if types.Hash(amount.Currency) != types.Hash(input.Currency) {
+		if !types.CurrenciesEqual(amount.Currency, input.Currency) {
 			continue
 		}
 
@@ -896,3 +1144,65 @@ func (w *Worker) GetBlobWorker(
 
 	return string(val), nil
 }
+
+func stateKey(key string) string {
+	return fmt.Sprintf("%s/%s", stateNamespace, key)
+}
+
+// SaveStateWorker transactionally checkpoints the job
+// state at a key so that it can be loaded by a later run.
+func (w *Worker) SaveStateWorker(
+	ctx context.Context,
+	dbTx database.Transaction,
+	state string,
+	rawInput string,
+) error {
+	var input job.SaveStateInput
+	err := job.UnmarshalInput([]byte(rawInput), &input)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidInput, err.Error())
+	}
+
+	if len(input.Key) == 0 {
+		return fmt.Errorf("%w: key cannot be empty", ErrInvalidInput)
+	}
+
+	if err := w.helper.SetBlob(ctx, dbTx, stateKey(input.Key), []byte(state)); err != nil {
+		return fmt.Errorf("%w: %s", ErrActionFailed, err.Error())
+	}
+
+	return nil
+}
+
+// LoadStateWorker transactionally retrieves the job state
+// checkpointed at a key, if it exists.
+func (w *Worker) LoadStateWorker(
+	ctx context.Context,
+	dbTx database.Transaction,
+	rawInput string,
+) (string, error) {
+	var input job.LoadStateInput
+	err := job.UnmarshalInput([]byte(rawInput), &input)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidInput, err.Error())
+	}
+
+	if len(input.Key) == 0 {
+		return "", fmt.Errorf("%w: key cannot be empty", ErrInvalidInput)
+	}
+
+	exists, val, err := w.helper.GetBlob(ctx, dbTx, stateKey(input.Key))
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrActionFailed, err.Error())
+	}
+
+	if !exists {
+		return "", fmt.Errorf(
+			"%w: no state saved at key %s",
+			ErrActionFailed,
+			input.Key,
+		)
+	}
+
+	return string(val), nil
+}
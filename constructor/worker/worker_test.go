@@ -30,8 +30,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
 
 	"github.com/coinbase/rosetta-sdk-go/constructor/job"
+	"github.com/coinbase/rosetta-sdk-go/keys"
 	mocks "github.com/coinbase/rosetta-sdk-go/mocks/constructor/worker"
 	"github.com/coinbase/rosetta-sdk-go/storage/database"
 	"github.com/coinbase/rosetta-sdk-go/types"
@@ -1862,6 +1864,478 @@ func TestHTTPRequestWorker(t *testing.T) {
 	}
 }
 
+func TestCalculateFeeWorker(t *testing.T) {
+	var tests = map[string]struct {
+		input *job.CalculateFeeInput
+
+		output string
+		err    error
+	}{
+		"simple fee": {
+			input: &job.CalculateFeeInput{
+				FeeRate: "10",
+				Size:    "250",
+			},
+			output: `"2500"`,
+		},
+		"zero size": {
+			input: &job.CalculateFeeInput{
+				FeeRate: "10",
+				Size:    "0",
+			},
+			output: `"0"`,
+		},
+		"negative fee rate": {
+			input: &job.CalculateFeeInput{
+				FeeRate: "-10",
+				Size:    "250",
+			},
+			err: ErrInvalidInput,
+		},
+		"negative size": {
+			input: &job.CalculateFeeInput{
+				FeeRate: "10",
+				Size:    "-250",
+			},
+			err: ErrInvalidInput,
+		},
+		"invalid fee rate": {
+			input: &job.CalculateFeeInput{
+				FeeRate: "1.5",
+				Size:    "250",
+			},
+			err: ErrInvalidInput,
+		},
+		"invalid size": {
+			input: &job.CalculateFeeInput{
+				FeeRate: "10",
+				Size:    "hello",
+			},
+			err: ErrInvalidInput,
+		},
+		"overflow": {
+			input: &job.CalculateFeeInput{
+				FeeRate: "115792089237316195423570985008687907853269984665640564039457584007913129639935",
+				Size:    "2",
+			},
+			err: ErrActionFailed,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			output, err := CalculateFeeWorker(types.PrintStruct(test.input))
+			if test.err != nil {
+				assert.Equal(t, "", output)
+				assert.True(t, errors.Is(err, test.err))
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.output, output)
+			}
+		})
+	}
+}
+
+func TestTransformWorker(t *testing.T) {
+	var tests = map[string]struct {
+		input *job.TransformInput
+
+		output string
+		err    error
+	}{
+		"hex to base64": {
+			input: &job.TransformInput{
+				Value: "68656c6c6f",
+				From:  job.HexCodec,
+				To:    job.Base64Codec,
+			},
+			output: `"aGVsbG8="`,
+		},
+		"base64 to hex": {
+			input: &job.TransformInput{
+				Value: "aGVsbG8=",
+				From:  job.Base64Codec,
+				To:    job.HexCodec,
+			},
+			output: `"68656c6c6f"`,
+		},
+		"hex to hex": {
+			input: &job.TransformInput{
+				Value: "68656c6c6f",
+				From:  job.HexCodec,
+				To:    job.HexCodec,
+			},
+			output: `"68656c6c6f"`,
+		},
+		"invalid hex": {
+			input: &job.TransformInput{
+				Value: "0x68656c6c6f",
+				From:  job.HexCodec,
+				To:    job.Base64Codec,
+			},
+			err: ErrInvalidInput,
+		},
+		"invalid base64": {
+			input: &job.TransformInput{
+				Value: "68656c6c6f!",
+				From:  job.Base64Codec,
+				To:    job.HexCodec,
+			},
+			err: ErrInvalidInput,
+		},
+		"unsupported codec": {
+			input: &job.TransformInput{
+				Value: "68656c6c6f",
+				From:  job.HexCodec,
+				To:    "base58",
+			},
+			err: ErrInvalidInput,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			output, err := TransformWorker(types.PrintStruct(test.input))
+			if test.err != nil {
+				assert.Equal(t, "", output)
+				assert.True(t, errors.Is(err, test.err))
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.output, output)
+
+			// The output can be transformed back to the input
+			var encoded string
+			assert.NoError(t, json.Unmarshal([]byte(output), &encoded))
+			roundTrip, err := TransformWorker(types.PrintStruct(&job.TransformInput{
+				Value: encoded,
+				From:  test.input.To,
+				To:    test.input.From,
+			}))
+			assert.NoError(t, err)
+			assert.Equal(t, types.PrintStruct(test.input.Value), roundTrip)
+		})
+	}
+}
+
+func TestGenerateKeysWorker(t *testing.T) {
+	var tests = map[string]struct {
+		input *job.GenerateKeysInput
+
+		err error
+	}{
+		"secp256k1": {
+			input: &job.GenerateKeysInput{
+				Count:     5,
+				CurveType: types.Secp256k1,
+			},
+		},
+		"edwards25519": {
+			input: &job.GenerateKeysInput{
+				Count:     job.MaxGenerateKeys,
+				CurveType: types.Edwards25519,
+			},
+		},
+		"zero count": {
+			input: &job.GenerateKeysInput{
+				CurveType: types.Secp256k1,
+			},
+			err: ErrInvalidInput,
+		},
+		"negative count": {
+			input: &job.GenerateKeysInput{
+				Count:     -1,
+				CurveType: types.Secp256k1,
+			},
+			err: ErrInvalidInput,
+		},
+		"count too large": {
+			input: &job.GenerateKeysInput{
+				Count:     job.MaxGenerateKeys + 1,
+				CurveType: types.Secp256k1,
+			},
+			err: ErrInvalidInput,
+		},
+		"invalid curve": {
+			input: &job.GenerateKeysInput{
+				Count:     2,
+				CurveType: "blah",
+			},
+			err: ErrActionFailed,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			output, err := GenerateKeysWorker(types.PrintStruct(test.input))
+			if test.err != nil {
+				assert.Equal(t, "", output)
+				assert.True(t, errors.Is(err, test.err))
+				return
+			}
+
+			assert.NoError(t, err)
+
+			var keyPairs []*keys.KeyPair
+			assert.NoError(t, json.Unmarshal([]byte(output), &keyPairs))
+			assert.Len(t, keyPairs, test.input.Count)
+			for _, keyPair := range keyPairs {
+				assert.NoError(t, keyPair.IsValid())
+				assert.Equal(t, test.input.CurveType, keyPair.PublicKey.CurveType)
+			}
+		})
+	}
+}
+
+func TestDeriveBatchWorker(t *testing.T) {
+	ctx := context.Background()
+	network := &types.NetworkIdentifier{
+		Blockchain: "Bitcoin",
+		Network:    "Testnet3",
+	}
+
+	output, err := GenerateKeysWorker(types.PrintStruct(&job.GenerateKeysInput{
+		Count:     3,
+		CurveType: types.Secp256k1,
+	}))
+	assert.NoError(t, err)
+
+	var keyPairs []*keys.KeyPair
+	assert.NoError(t, json.Unmarshal([]byte(output), &keyPairs))
+
+	t.Run("derives each keypair", func(t *testing.T) {
+		helper := &mocks.Helper{}
+		for i, keyPair := range keyPairs {
+			helper.On(
+				"Derive",
+				ctx,
+				network,
+				keyPair.PublicKey,
+				map[string]interface{}(nil),
+			).Return(
+				&types.AccountIdentifier{Address: fmt.Sprintf("addr%d", i)},
+				nil,
+				nil,
+			).Once()
+		}
+
+		w := New(helper)
+		output, err := w.invokeWorker(ctx, nil, "", job.DeriveBatch, types.PrintStruct(
+			&job.DeriveBatchInput{
+				NetworkIdentifier: network,
+				KeyPairs:          keyPairs,
+			},
+		))
+		assert.NoError(t, err)
+
+		var responses []*types.ConstructionDeriveResponse
+		assert.NoError(t, json.Unmarshal([]byte(output), &responses))
+		assert.Equal(t, []*types.ConstructionDeriveResponse{
+			{AccountIdentifier: &types.AccountIdentifier{Address: "addr0"}},
+			{AccountIdentifier: &types.AccountIdentifier{Address: "addr1"}},
+			{AccountIdentifier: &types.AccountIdentifier{Address: "addr2"}},
+		}, responses)
+		helper.AssertExpectations(t)
+	})
+
+	t.Run("no keypairs", func(t *testing.T) {
+		w := New(&mocks.Helper{})
+		output, err := w.DeriveBatchWorker(ctx, types.PrintStruct(&job.DeriveBatchInput{
+			NetworkIdentifier: network,
+		}))
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+		assert.Equal(t, "", output)
+	})
+
+	t.Run("invalid public key", func(t *testing.T) {
+		w := New(&mocks.Helper{})
+		output, err := w.DeriveBatchWorker(ctx, types.PrintStruct(&job.DeriveBatchInput{
+			NetworkIdentifier: network,
+			KeyPairs: []*keys.KeyPair{
+				keyPairs[0],
+				{PublicKey: &types.PublicKey{CurveType: types.Secp256k1}},
+			},
+		}))
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+		assert.Equal(t, "", output)
+	})
+
+	t.Run("derive failure", func(t *testing.T) {
+		helper := &mocks.Helper{}
+		helper.On(
+			"Derive",
+			ctx,
+			network,
+			keyPairs[0].PublicKey,
+			map[string]interface{}(nil),
+		).Return(
+			nil,
+			nil,
+			errors.New("unable to derive"),
+		).Once()
+
+		w := New(helper)
+		output, err := w.DeriveBatchWorker(ctx, types.PrintStruct(&job.DeriveBatchInput{
+			NetworkIdentifier: network,
+			KeyPairs:          keyPairs,
+		}))
+		assert.True(t, errors.Is(err, ErrActionFailed))
+		assert.Equal(t, "", output)
+		helper.AssertExpectations(t)
+	})
+}
+
+func TestRegisterAction(t *testing.T) {
+	ctx := context.Background()
+	w := New(&mocks.Helper{})
+	customAction := job.ActionType("encode_address")
+
+	encodeAddress := func(ctx context.Context, input string) (string, error) {
+		var address string
+		if err := json.Unmarshal([]byte(input), &address); err != nil {
+			return "", fmt.Errorf("%w: %s", ErrInvalidInput, err.Error())
+		}
+
+		return marshalString("0x" + address), nil
+	}
+	assert.NoError(t, w.RegisterAction(customAction, encodeAddress))
+
+	err := w.RegisterAction(customAction, func(context.Context, string) (string, error) {
+		return "", nil
+	})
+	assert.True(t, errors.Is(err, ErrActionAlreadyRegistered))
+
+	t.Run("custom action", func(t *testing.T) {
+		output, err := w.invokeWorker(ctx, nil, "", customAction, `"abcd"`)
+		assert.NoError(t, err)
+		assert.Equal(t, `"0xabcd"`, output)
+	})
+
+	t.Run("custom action error", func(t *testing.T) {
+		output, err := w.invokeWorker(ctx, nil, "", customAction, `{}`)
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+		assert.Equal(t, "", output)
+	})
+
+	t.Run("built-in action cannot be registered", func(t *testing.T) {
+		override := func(context.Context, string) (string, error) {
+			return `"overridden"`, nil
+		}
+		err := w.RegisterAction(job.SetVariable, override)
+		assert.True(t, errors.Is(err, ErrBuiltInAction))
+
+		output, err := w.invokeWorker(ctx, nil, "", job.SetVariable, `"value"`)
+		assert.NoError(t, err)
+		assert.Equal(t, `"value"`, output)
+	})
+
+	t.Run("unknown action", func(t *testing.T) {
+		output, err := w.invokeWorker(ctx, nil, "", job.ActionType("unknown"), `{}`)
+		assert.True(t, errors.Is(err, ErrInvalidActionType))
+		assert.Equal(t, "", output)
+	})
+}
+
+func TestMarshalString(t *testing.T) {
+	var tests = map[string]struct {
+		value  string
+		output string
+	}{
+		"simple": {
+			value:  "hello",
+			output: `"hello"`,
+		},
+		"quotes": {
+			value:  `say "hi"`,
+			output: `"say \"hi\""`,
+		},
+		"backslashes": {
+			value:  `C:\path\`,
+			output: `"C:\\path\\"`,
+		},
+		"newline": {
+			value:  "line1\nline2",
+			output: `"line1\nline2"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			output := marshalString(test.value)
+			assert.Equal(t, test.output, output)
+
+			var decoded string
+			assert.NoError(t, json.Unmarshal([]byte(output), &decoded))
+			assert.Equal(t, test.value, decoded)
+		})
+	}
+}
+
+func TestRandomStringWorker(t *testing.T) {
+	var tests = map[string]struct {
+		input *job.RandomStringInput
+
+		output string
+		err    error
+	}{
+		"simple regex": {
+			input: &job.RandomStringInput{
+				Regex: "hello",
+				Limit: 10,
+			},
+			output: `"hello"`,
+		},
+		"double quote": {
+			input: &job.RandomStringInput{
+				Regex: `a"b`,
+				Limit: 10,
+			},
+			output: `"a\"b"`,
+		},
+		"backslash": {
+			input: &job.RandomStringInput{
+				Regex: `a\\b`,
+				Limit: 10,
+			},
+			output: `"a\\b"`,
+		},
+		"newline": {
+			input: &job.RandomStringInput{
+				Regex: `a\nb`,
+				Limit: 10,
+			},
+			output: `"a\nb"`,
+		},
+		"invalid regex": {
+			input: &job.RandomStringInput{
+				Regex: "[a-z",
+				Limit: 10,
+			},
+			err: ErrActionFailed,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			output, err := RandomStringWorker(types.PrintStruct(test.input))
+			if test.err != nil {
+				assert.Equal(t, "", output)
+				assert.True(t, errors.Is(err, test.err))
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.output, output)
+
+			// The output must be safe to store in job state.
+			state, err := sjson.SetRaw("{}", "random", output)
+			assert.NoError(t, err)
+			assert.True(t, gjson.Valid(state))
+		})
+	}
+}
+
 func TestBlobWorkers(t *testing.T) {
 	tests := map[string]struct {
 		scenario *job.Scenario
@@ -2061,3 +2535,80 @@ func TestBlobWorkers(t *testing.T) {
 		})
 	}
 }
+
+func TestStateWorkers(t *testing.T) {
+	ctx := context.Background()
+	checkpoint := []byte(`{"network":"Testnet3"}`)
+
+	t.Run("save and load", func(t *testing.T) {
+		h := &mocks.Helper{}
+		h.On(
+			"SetBlob",
+			ctx,
+			mock.Anything,
+			"state/checkpoint",
+			checkpoint,
+		).Return(nil).Once()
+		h.On(
+			"GetBlob",
+			ctx,
+			mock.Anything,
+			"state/checkpoint",
+		).Return(true, checkpoint, nil).Once()
+		w := New(h)
+
+		state, err := w.actions(ctx, nil, "", []*job.Action{
+			{
+				Type:       job.SetVariable,
+				Input:      `"Testnet3"`,
+				OutputPath: "network",
+			},
+			{
+				Type:  job.SaveState,
+				Input: `{"key":"checkpoint"}`,
+			},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, string(checkpoint), state)
+
+		// A later run can restore the checkpoint.
+		state, err = w.actions(ctx, nil, "", []*job.Action{
+			{
+				Type:       job.LoadState,
+				Input:      `{"key":"checkpoint"}`,
+				OutputPath: "restored",
+			},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, "Testnet3", gjson.Get(state, "restored.network").String())
+
+		h.AssertExpectations(t)
+	})
+
+	t.Run("load missing", func(t *testing.T) {
+		h := &mocks.Helper{}
+		h.On(
+			"GetBlob",
+			ctx,
+			mock.Anything,
+			"state/missing",
+		).Return(false, []byte{}, nil).Once()
+		w := New(h)
+
+		output, err := w.LoadStateWorker(ctx, nil, `{"key":"missing"}`)
+		assert.Equal(t, "", output)
+		assert.True(t, errors.Is(err, ErrActionFailed))
+
+		h.AssertExpectations(t)
+	})
+
+	t.Run("empty key", func(t *testing.T) {
+		w := New(&mocks.Helper{})
+
+		err := w.SaveStateWorker(ctx, nil, string(checkpoint), `{"key":""}`)
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+
+		_, err = w.LoadStateWorker(ctx, nil, `{}`)
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+	})
+}
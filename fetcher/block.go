@@ -17,6 +17,7 @@ package fetcher
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 
@@ -255,12 +256,22 @@ func (f *Fetcher) UnsafeBlock(
 // the block method. This function will
 // automatically fetch any transactions that
 // were not returned by the call to fetch the
-// block.
+// block. If the Fetcher has a block cache (see
+// WithBlockCache), requests that specify a hash
+// may be served from the cache.
 func (f *Fetcher) Block(
 	ctx context.Context,
 	network *types.NetworkIdentifier,
 	blockIdentifier *types.PartialBlockIdentifier,
 ) (*types.Block, *Error) {
+	if f.blockCache != nil && blockIdentifier != nil && blockIdentifier.Hash != nil {
+		block, ok := f.blockCache.get(network, *blockIdentifier.Hash)
+		if ok && (blockIdentifier.Index == nil ||
+			*blockIdentifier.Index == block.BlockIdentifier.Index) {
+			return block, nil
+		}
+	}
+
 	block, err := f.UnsafeBlock(ctx, network, blockIdentifier)
 	if err != nil {
 		return nil, err
@@ -279,6 +290,10 @@ func (f *Fetcher) Block(
 		return nil, fetcherErr
 	}
 
+	if f.blockCache != nil {
+		f.blockCache.add(network, block)
+	}
+
 	return block, nil
 }
 
@@ -326,3 +341,46 @@ func (f *Fetcher) BlockRetry(
 		}
 	}
 }
+
+// WaitForBlock polls for the validated Block at index every
+// pollInterval until it is available or ctx is canceled. This
+// is useful when waiting for a node that is still syncing.
+//
+// Retriable errors (see Error.Retry) are considered to indicate
+// that the block is not yet available. Any other error is
+// returned immediately. If the block at index is omitted, nil
+// is returned.
+func (f *Fetcher) WaitForBlock(
+	ctx context.Context,
+	network *types.NetworkIdentifier,
+	index int64,
+	pollInterval time.Duration,
+) (*types.Block, *Error) {
+	blockIdentifier := &types.PartialBlockIdentifier{Index: &index}
+	if err := asserter.PartialBlockIdentifier(blockIdentifier); err != nil {
+		return nil, &Error{Err: err}
+	}
+
+	for {
+		block, err := f.Block(ctx, network, blockIdentifier)
+		if err == nil {
+			return block, nil
+		}
+
+		if ctx.Err() != nil {
+			return nil, &Error{Err: ctx.Err()}
+		}
+
+		if !err.Retry {
+			return nil, err
+		}
+
+		timer := time.NewTimer(pollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, &Error{Err: ctx.Err()}
+		case <-timer.C:
+		}
+	}
+}
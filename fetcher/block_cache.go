@@ -0,0 +1,95 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetcher
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+// blockCache is a fixed-size, least-recently-used cache
+// of validated blocks keyed by network and block hash.
+//
+// Blocks are never cached by index because the block at
+// an index can change during a reorg (while the block with
+// a particular hash cannot).
+type blockCache struct {
+	size int
+
+	lock    sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type blockCacheEntry struct {
+	key   string
+	block *types.Block
+}
+
+func newBlockCache(size int) *blockCache {
+	return &blockCache{
+		size:    size,
+		order:   list.New(),
+		entries: map[string]*list.Element{},
+	}
+}
+
+func blockCacheKey(network *types.NetworkIdentifier, hash string) string {
+	return fmt.Sprintf("%s/%s", types.Hash(network), hash)
+}
+
+// get returns the cached block with hash on network,
+// if it exists.
+func (c *blockCache) get(
+	network *types.NetworkIdentifier,
+	hash string,
+) (*types.Block, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	element, ok := c.entries[blockCacheKey(network, hash)]
+	if !ok {
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	return element.Value.(*blockCacheEntry).block, true
+}
+
+// add stores block in the cache, evicting the least
+// recently used block if the cache is full.
+func (c *blockCache) add(network *types.NetworkIdentifier, block *types.Block) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	key := blockCacheKey(network, block.BlockIdentifier.Hash)
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*blockCacheEntry).block = block
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&blockCacheEntry{key: key, block: block})
+	if c.order.Len() <= c.size {
+		return
+	}
+
+	oldest := c.order.Back()
+	c.order.Remove(oldest)
+	delete(c.entries, oldest.Value.(*blockCacheEntry).key)
+}
@@ -261,3 +261,207 @@ func TestBlockRetry(t *testing.T) {
 		})
 	}
 }
+
+func TestBlockCache(t *testing.T) {
+	var (
+		assert   = assert.New(t)
+		ctx      = context.Background()
+		requests = 0
+	)
+
+	blocks := map[int64]*types.Block{}
+	for i := int64(1); i <= 3; i++ {
+		blocks[i] = &types.Block{
+			BlockIdentifier: &types.BlockIdentifier{
+				Index: i,
+				Hash:  fmt.Sprintf("block %d", i),
+			},
+			ParentBlockIdentifier: &types.BlockIdentifier{
+				Index: i - 1,
+				Hash:  fmt.Sprintf("block %d", i-1),
+			},
+			Timestamp: 1582833600000,
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("/block", r.URL.RequestURI())
+		requests++
+
+		var blockRequest *types.BlockRequest
+		assert.NoError(json.NewDecoder(r.Body).Decode(&blockRequest))
+
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, types.PrettyPrintStruct(&types.BlockResponse{
+			Block: blocks[*blockRequest.BlockIdentifier.Index],
+		}))
+	}))
+	defer ts.Close()
+
+	a, err := asserter.NewClientWithOptions(
+		basicNetwork,
+		&types.BlockIdentifier{
+			Index: 0,
+			Hash:  "block 0",
+		},
+		basicNetworkOptions.Allow.OperationTypes,
+		basicNetworkOptions.Allow.OperationStatuses,
+		nil,
+		nil,
+		&asserter.Validations{
+			Enabled: false,
+		},
+	)
+	assert.NoError(err)
+
+	f := New(ts.URL, WithAsserter(a), WithBlockCache(2))
+	fetch := func(block *types.Block, byHash bool) {
+		identifier := &types.PartialBlockIdentifier{Index: &block.BlockIdentifier.Index}
+		if byHash {
+			identifier.Hash = &block.BlockIdentifier.Hash
+		}
+
+		fetched, fetchErr := f.Block(ctx, basicNetwork, identifier)
+		assert.Nil(fetchErr)
+		assert.Equal(block, fetched)
+	}
+
+	// Requests by index are always sent to the server
+	fetch(blocks[1], false)
+	fetch(blocks[1], false)
+	assert.Equal(2, requests)
+
+	// Requests by hash are served from the cache
+	fetch(blocks[1], true)
+	assert.Equal(2, requests)
+
+	// A hash with a mismatched index is not served from the cache
+	otherIndex := int64(2)
+	_, fetchErr := f.Block(ctx, basicNetwork, &types.PartialBlockIdentifier{
+		Index: &otherIndex,
+		Hash:  &blocks[1].BlockIdentifier.Hash,
+	})
+	assert.Nil(fetchErr)
+	assert.Equal(3, requests)
+
+	// Block 1 is evicted when block 3 is added
+	fetch(blocks[3], true)
+	assert.Equal(4, requests)
+	fetch(blocks[2], true)
+	fetch(blocks[3], true)
+	assert.Equal(4, requests)
+	fetch(blocks[1], true)
+	assert.Equal(5, requests)
+
+	// Blocks on other networks are not served from the cache
+	_, fetchErr = f.Block(ctx, otherNetwork, types.ConstructPartialBlockIdentifier(
+		blocks[1].BlockIdentifier,
+	))
+	assert.Nil(fetchErr)
+	assert.Equal(6, requests)
+}
+
+func TestWaitForBlock(t *testing.T) {
+	var tests = map[string]struct {
+		unavailablePolls int
+		retriableError   bool
+		timeout          time.Duration
+
+		expectedBlock *types.Block
+		expectedTries int
+		expectedError error
+	}{
+		"available immediately": {
+			expectedBlock: basicFullBlock,
+			expectedTries: 1,
+		},
+		"available after polls": {
+			unavailablePolls: 3,
+			retriableError:   true,
+			expectedBlock:    basicFullBlock,
+			expectedTries:    4,
+		},
+		"hard error": {
+			unavailablePolls: 3,
+			expectedTries:    1,
+			expectedError:    ErrRequestFailed,
+		},
+		"context canceled": {
+			unavailablePolls: 1000,
+			retriableError:   true,
+			timeout:          100 * time.Millisecond,
+			expectedError:    context.DeadlineExceeded,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var (
+				tries  = 0
+				assert = assert.New(t)
+				ctx    = context.Background()
+			)
+			if test.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, test.timeout)
+				defer cancel()
+			}
+
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal("POST", r.Method)
+				assert.Equal("/block", r.URL.RequestURI())
+
+				var blockRequest *types.BlockRequest
+				assert.NoError(json.NewDecoder(r.Body).Decode(&blockRequest))
+				assert.Equal(basicBlock.Index, *blockRequest.BlockIdentifier.Index)
+
+				tries++
+				w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+				if tries <= test.unavailablePolls {
+					w.WriteHeader(http.StatusInternalServerError)
+					fmt.Fprintln(w, types.PrettyPrintStruct(&types.Error{
+						Message:   "block not found",
+						Retriable: test.retriableError,
+					}))
+					return
+				}
+
+				w.WriteHeader(http.StatusOK)
+				fmt.Fprintln(w, types.PrettyPrintStruct(&types.BlockResponse{
+					Block: basicFullBlock,
+				}))
+			}))
+			defer ts.Close()
+
+			a, err := asserter.NewClientWithOptions(
+				basicNetwork,
+				&types.BlockIdentifier{
+					Index: 0,
+					Hash:  "block 0",
+				},
+				basicNetworkOptions.Allow.OperationTypes,
+				basicNetworkOptions.Allow.OperationStatuses,
+				nil,
+				nil,
+				&asserter.Validations{
+					Enabled: false,
+				},
+			)
+			assert.NoError(err)
+
+			f := New(ts.URL, WithAsserter(a))
+			block, blockErr := f.WaitForBlock(
+				ctx,
+				basicNetwork,
+				basicBlock.Index,
+				10*time.Millisecond,
+			)
+			assert.Equal(test.expectedBlock, block)
+			assert.True(checkError(blockErr, test.expectedError))
+			if test.expectedTries > 0 {
+				assert.Equal(test.expectedTries, tries)
+			}
+		})
+	}
+}
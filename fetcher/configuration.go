@@ -15,6 +15,7 @@
 package fetcher
 
 import (
+	"crypto/tls"
 	"time"
 
 	"github.com/coinbase/rosetta-sdk-go/asserter"
@@ -59,7 +60,9 @@ func WithAsserter(asserter *asserter.Asserter) Option {
 
 // WithInsecureTLS overrides the default TLS
 // security settings to allow insecure certificates
-// on an HTTPS connection.
+// on an HTTPS connection. This is a shortcut for
+// setting InsecureSkipVerify (and is applied on top of
+// any config provided with WithTLSConfig).
 //
 // This should ONLY be used when debugging a Rosetta API
 // implementation. Using this option can lead to a man-in-the-middle
@@ -70,6 +73,20 @@ func WithInsecureTLS() Option {
 	}
 }
 
+// WithTLSConfig overrides the default TLS settings
+// used on an HTTPS connection (ex: to trust a custom CA pool
+// or to enforce a minimum TLS version). The config is
+// copied, so it is safe to modify after construction.
+//
+// This is not intended to be used with WithClient (the
+// config is only applied if the provided client uses an
+// *http.Transport). Configure TLS on the provided client instead.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(f *Fetcher) {
+		f.tlsConfig = config
+	}
+}
+
 // WithTimeout overrides the default HTTP timeout.
 func WithTimeout(timeout time.Duration) Option {
 	return func(f *Fetcher) {
@@ -99,3 +116,98 @@ func WithForceRetry() Option {
 		f.forceRetry = true
 	}
 }
+
+// WithRetryClassifier overrides the default
+// retry handling logic with a custom RetryClassifier.
+//
+// This is useful when a Rosetta implementation returns
+// chain-specific errors that should (or should not) be retried
+// (ex: a node that is still syncing). Requests that fail
+// because the context was canceled are never retried.
+func WithRetryClassifier(classifier RetryClassifier) Option {
+	return func(f *Fetcher) {
+		f.retryClassifier = classifier
+	}
+}
+
+// WithHonorRetriable causes the Retriable field of any
+// *types.Error returned by the Rosetta server to determine
+// if a request is retried, regardless of the HTTP status
+// code of the response. If Retriable is false, the request
+// is aborted immediately (even if the error would otherwise
+// be considered transient). Requests that fail without
+// returning a *types.Error use the default retry handling.
+func WithHonorRetriable() Option {
+	return func(f *Fetcher) {
+		f.honorRetriable = true
+	}
+}
+
+// WithStrictErrors causes requests to fail (without retry)
+// if the Rosetta server returns a *types.Error that does not
+// match any error declared in /network/options (ex: an
+// undeclared error code). By default, such errors are only
+// logged. This has no effect until the Asserter is initialized.
+func WithStrictErrors() Option {
+	return func(f *Fetcher) {
+		f.strictErrors = true
+	}
+}
+
+// WithCachedNetworkOptions persists the NetworkStatus and
+// NetworkOptions fetched in InitializeAsserter to the file at
+// path. If a cache exists that was written by the same version of
+// the SDK for the same network within ttl, it is used to
+// construct the Asserter instead of querying the Rosetta server.
+// If the cache is stale or missing, the responses are fetched
+// from the server and the cache is overwritten.
+//
+// The cached responses could be out-of-date if the
+// Rosetta implementation is upgraded or reconfigured
+// within ttl (ex: a new operation type is supported). This
+// can cause valid responses to fail assertion, so this should
+// ONLY be used when the implementation is not expected to change.
+func WithCachedNetworkOptions(path string, ttl time.Duration) Option {
+	return func(f *Fetcher) {
+		f.networkCachePath = path
+		f.networkCacheTTL = ttl
+	}
+}
+
+// WithBlockCache caches up to size recently fetched blocks
+// (by hash) so that calls to Block and BlockRetry that specify
+// a block hash can be served without querying the Rosetta
+// server. Requests that only specify an index are always sent
+// to the server (the block at an index can change during a
+// reorg), but the blocks returned are added to the cache.
+//
+// Cached blocks are shared between callers and must not be
+// modified. This is intended for read-heavy API frontends and
+// should NOT be used with the syncer, which must always
+// observe fresh data from the node.
+func WithBlockCache(size int) Option {
+	return func(f *Fetcher) {
+		f.blockCache = newBlockCache(size)
+	}
+}
+
+// WithZstdResponses advertises support for zstd-compressed
+// responses (in addition to gzip) and decompresses any
+// response with a zstd Content-Encoding. Without this option,
+// the default transport only negotiates gzip compression.
+func WithZstdResponses() Option {
+	return func(f *Fetcher) {
+		f.zstdResponses = true
+	}
+}
+
+// WithRecorder writes each request made by the Fetcher and
+// the (decompressed) response to a file in dir (keyed by a
+// hash of the request). dir must already exist. The recorded
+// responses can be served by a Fetcher created with
+// NewReplayFetcher to run tests without access to a node.
+func WithRecorder(dir string) Option {
+	return func(f *Fetcher) {
+		f.recordDir = dir
+	}
+}
@@ -16,9 +16,13 @@ package fetcher
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"sort"
 
 	"github.com/coinbase/rosetta-sdk-go/asserter"
+	"github.com/coinbase/rosetta-sdk-go/parser"
 	"github.com/coinbase/rosetta-sdk-go/types"
 )
 
@@ -333,3 +337,160 @@ func (f *Fetcher) ConstructionSubmit(
 
 	return submitResponse.TransactionIdentifier, submitResponse.Metadata, nil
 }
+
+// VerifyTransactionIntent parses a signed transaction with
+// `/construction/parse` and returns an error if the parsed
+// operations or signers differ from what was intended. This
+// should be called before broadcasting a transaction to ensure
+// the implementation constructed what was requested.
+//
+// Operations are compared without regard to order (using
+// parser.ExpectedOperation) and every intended operation must
+// match exactly one parsed operation (with no parsed operations
+// left over). Signers are compared by address.
+func (f *Fetcher) VerifyTransactionIntent(
+	ctx context.Context,
+	network *types.NetworkIdentifier,
+	signedTx string,
+	intendedOps []*types.Operation,
+	expectedSigners []string,
+) error {
+	parsedOps, signers, _, fetcherErr := f.ConstructionParse(
+		ctx,
+		network,
+		true,
+		signedTx,
+	)
+	if fetcherErr != nil {
+		return fetcherErr.Err
+	}
+
+	if err := operationsDiff(intendedOps, parsedOps); err != nil {
+		return err
+	}
+
+	return signersDiff(expectedSigners, signers)
+}
+
+// operationsDiff returns an error describing all intended
+// operations that were not observed and all observed operations
+// that were not intended.
+func operationsDiff(intent []*types.Operation, observed []*types.Operation) error {
+	matched := make([]bool, len(intent))
+	extra := []*types.Operation{}
+	for _, obs := range observed {
+		foundMatch := false
+		for i, in := range intent {
+			if matched[i] {
+				continue
+			}
+
+			if err := parser.ExpectedOperation(in, obs); err != nil {
+				continue
+			}
+
+			matched[i] = true
+			foundMatch = true
+			break
+		}
+
+		if !foundMatch {
+			extra = append(extra, obs)
+		}
+	}
+
+	missing := []*types.Operation{}
+	for i, in := range intent {
+		if !matched[i] {
+			missing = append(missing, in)
+		}
+	}
+
+	if len(missing) == 0 && len(extra) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"%w: missing operations %s, unexpected operations %s",
+		ErrIntentMismatch,
+		types.PrettyPrintStruct(missing),
+		types.PrettyPrintStruct(extra),
+	)
+}
+
+// signersDiff returns an error describing all expected signers
+// that were not observed and all observed signers that were not
+// expected. Duplicate expected signers are ignored (ex: multiple
+// UTXOs from the same address).
+func signersDiff(expected []string, observed []*types.AccountIdentifier) error {
+	expectedSet := make(map[string]struct{})
+	for _, address := range expected {
+		expectedSet[address] = struct{}{}
+	}
+
+	observedSet := make(map[string]struct{})
+	unexpected := []string{}
+	for _, signer := range observed {
+		observedSet[signer.Address] = struct{}{}
+		if _, ok := expectedSet[signer.Address]; !ok {
+			unexpected = append(unexpected, signer.Address)
+		}
+	}
+
+	missing := []string{}
+	for address := range expectedSet {
+		if _, ok := observedSet[address]; !ok {
+			missing = append(missing, address)
+		}
+	}
+
+	if len(missing) == 0 && len(unexpected) == 0 {
+		return nil
+	}
+
+	sort.Strings(missing)
+	sort.Strings(unexpected)
+	return fmt.Errorf(
+		"%w: missing signers %v, unexpected signers %v",
+		ErrSignersMismatch,
+		missing,
+		unexpected,
+	)
+}
+
+// SupportsConstruction returns a boolean indicating if the node
+// implements the Construction API. It probes /construction/preprocess
+// with no operations: a 404 or 501 response means construction is not
+// supported, while any other response from the node (including a
+// *types.Error) means it is. If the probe fails for any other reason
+// (i.e. a transient error), the error is returned.
+func (f *Fetcher) SupportsConstruction(
+	ctx context.Context,
+	network *types.NetworkIdentifier,
+) (bool, error) {
+	_, _, fetchErr := f.ConstructionPreprocess(ctx, network, []*types.Operation{}, nil)
+	if fetchErr == nil {
+		return true, nil
+	}
+
+	switch statusCode(nil, fetchErr.Err) {
+	case http.StatusNotFound, http.StatusNotImplemented:
+		return false, nil
+	}
+
+	if fetchErr.ClientErr != nil {
+		return true, nil
+	}
+
+	if errors.Is(fetchErr.Err, ErrRequestFailed) ||
+		errors.Is(fetchErr.Err, ErrCouldNotAcquireSemaphore) {
+		return false, fmt.Errorf(
+			"%w: unable to determine construction support",
+			fetchErr.Err,
+		)
+	}
+
+	// The node responded to the request but the response
+	// was invalid, so the endpoint is still implemented.
+	return true, nil
+}
@@ -0,0 +1,209 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+// Construction flow steps, in the order they are
+// invoked by ConstructionFlowWithDeadline.
+const (
+	StepPreprocess = "preprocess"
+	StepMetadata   = "metadata"
+	StepPayloads   = "payloads"
+	StepSign       = "sign"
+	StepCombine    = "combine"
+	StepSubmit     = "submit"
+)
+
+var constructionFlowSteps = []string{
+	StepPreprocess,
+	StepMetadata,
+	StepPayloads,
+	StepSign,
+	StepCombine,
+	StepSubmit,
+}
+
+// ConstructionFlow contains the inputs needed to
+// construct, sign, and submit a transaction.
+type ConstructionFlow struct {
+	Network    *types.NetworkIdentifier
+	Operations []*types.Operation
+
+	// Metadata is provided to /construction/preprocess.
+	Metadata map[string]interface{}
+
+	// PublicKeys are provided to /construction/metadata
+	// and /construction/payloads.
+	PublicKeys []*types.PublicKey
+
+	// Sign returns a *types.Signature for each
+	// *types.SigningPayload.
+	Sign func(context.Context, []*types.SigningPayload) ([]*types.Signature, error)
+}
+
+// ConstructionFlowWithDeadline constructs, signs, and submits
+// a transaction, completing all steps (/construction/preprocess,
+// /construction/metadata, /construction/payloads, Sign,
+// /construction/combine, and /construction/submit) within
+// budget.
+//
+// Each step may use all of the budget remaining when it
+// starts (so a slow step, like Sign, is not limited to a
+// fixed share of the budget). If the budget is exceeded,
+// ConstructionFlowWithDeadline returns immediately with
+// ErrConstructionBudgetExceeded and the name of the step.
+//
+// If flow (or flow.Sign) is nil, ErrConstructionFlowInvalid
+// is returned before any step is invoked.
+//
+// The budget is applied in addition to the HTTP timeout of
+// the Fetcher (see WithTimeout), so a request is canceled by
+// whichever expires first. If the HTTP timeout is shorter
+// than the remaining budget, the request fails with
+// ErrRequestFailed instead of ErrConstructionBudgetExceeded.
+// ConstructionFlowWithDeadline does not retry any step.
+func (f *Fetcher) ConstructionFlowWithDeadline(
+	ctx context.Context,
+	budget time.Duration,
+	flow *ConstructionFlow,
+) (*types.TransactionIdentifier, map[string]interface{}, *Error) {
+	if flow == nil {
+		return nil, nil, &Error{
+			Err: fmt.Errorf("%w: flow is nil", ErrConstructionFlowInvalid),
+		}
+	}
+
+	if flow.Sign == nil {
+		return nil, nil, &Error{
+			Err: fmt.Errorf("%w: sign function is nil", ErrConstructionFlowInvalid),
+		}
+	}
+
+	deadline := time.Now().Add(budget)
+	var (
+		options           map[string]interface{}
+		metadata          map[string]interface{}
+		unsignedTx        string
+		payloads          []*types.SigningPayload
+		signatures        []*types.Signature
+		signedTx          string
+		txIdentifier      *types.TransactionIdentifier
+		submitMetadata    map[string]interface{}
+		constructionSteps = map[string]func(context.Context) *Error{
+			StepPreprocess: func(stepCtx context.Context) (fetcherErr *Error) {
+				options, _, fetcherErr = f.ConstructionPreprocess(
+					stepCtx,
+					flow.Network,
+					flow.Operations,
+					flow.Metadata,
+				)
+				return fetcherErr
+			},
+			StepMetadata: func(stepCtx context.Context) (fetcherErr *Error) {
+				metadata, _, fetcherErr = f.ConstructionMetadata(
+					stepCtx,
+					flow.Network,
+					options,
+					flow.PublicKeys,
+				)
+				return fetcherErr
+			},
+			StepPayloads: func(stepCtx context.Context) (fetcherErr *Error) {
+				unsignedTx, payloads, fetcherErr = f.ConstructionPayloads(
+					stepCtx,
+					flow.Network,
+					flow.Operations,
+					metadata,
+					flow.PublicKeys,
+				)
+				return fetcherErr
+			},
+			StepSign: func(stepCtx context.Context) *Error {
+				var err error
+				signatures, err = flow.Sign(stepCtx, payloads)
+				if err != nil {
+					return &Error{
+						Err: fmt.Errorf("%w: %v", ErrSignFailed, err),
+					}
+				}
+
+				return nil
+			},
+			StepCombine: func(stepCtx context.Context) (fetcherErr *Error) {
+				signedTx, fetcherErr = f.ConstructionCombine(
+					stepCtx,
+					flow.Network,
+					unsignedTx,
+					signatures,
+				)
+				return fetcherErr
+			},
+			StepSubmit: func(stepCtx context.Context) (fetcherErr *Error) {
+				txIdentifier, submitMetadata, fetcherErr = f.ConstructionSubmit(
+					stepCtx,
+					flow.Network,
+					signedTx,
+				)
+				return fetcherErr
+			},
+		}
+	)
+
+	for _, step := range constructionFlowSteps {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, &Error{Err: err}
+		}
+
+		if !time.Now().Before(deadline) {
+			return nil, nil, &Error{
+				Err: fmt.Errorf("%w: %s", ErrConstructionBudgetExceeded, step),
+			}
+		}
+
+		stepCtx, cancel := context.WithDeadline(ctx, deadline)
+		fetcherErr := constructionSteps[step](stepCtx)
+		stepErr := stepCtx.Err()
+		cancel()
+
+		if fetcherErr == nil {
+			continue
+		}
+
+		// Only attribute the failure to the budget if
+		// the parent context is still valid.
+		if errors.Is(stepErr, context.DeadlineExceeded) && ctx.Err() == nil {
+			return nil, nil, &Error{
+				Err: fmt.Errorf(
+					"%w: %s %v",
+					ErrConstructionBudgetExceeded,
+					step,
+					fetcherErr.Err,
+				),
+			}
+		}
+
+		return nil, nil, fetcherErr
+	}
+
+	return txIdentifier, submitMetadata, nil
+}
@@ -0,0 +1,183 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+func TestConstructionFlowWithDeadline(t *testing.T) {
+	responses := map[string]interface{}{
+		"/construction/preprocess": &types.ConstructionPreprocessResponse{
+			Options: map[string]interface{}{"from": "addr1"},
+		},
+		"/construction/metadata": &types.ConstructionMetadataResponse{
+			Metadata: map[string]interface{}{"nonce": float64(1)},
+		},
+		"/construction/payloads": &types.ConstructionPayloadsResponse{
+			UnsignedTransaction: "unsigned",
+			Payloads: []*types.SigningPayload{
+				{
+					AccountIdentifier: &types.AccountIdentifier{Address: "addr1"},
+					Bytes:             []byte("payload"),
+				},
+			},
+		},
+		"/construction/combine": &types.ConstructionCombineResponse{
+			SignedTransaction: "signed",
+		},
+		"/construction/submit": &types.TransactionIdentifierResponse{
+			TransactionIdentifier: &types.TransactionIdentifier{Hash: "tx"},
+		},
+	}
+
+	var tests = map[string]struct {
+		budget    time.Duration
+		slowStep  string
+		signDelay time.Duration
+		signErr   error
+		nilSign   bool
+
+		expectedError error
+		expectedStep  string
+	}{
+		"completes within budget": {
+			budget: 5 * time.Second,
+		},
+		"slow request": {
+			budget:        600 * time.Millisecond,
+			slowStep:      "/construction/payloads",
+			expectedError: ErrConstructionBudgetExceeded,
+			expectedStep:  StepPayloads,
+		},
+		"slow signer": {
+			budget:        600 * time.Millisecond,
+			signDelay:     time.Second,
+			expectedError: ErrConstructionBudgetExceeded,
+			expectedStep:  StepSign,
+		},
+		"slow signer within budget": {
+			budget:    time.Second,
+			signDelay: 400 * time.Millisecond,
+		},
+		"nil signer": {
+			budget:        5 * time.Second,
+			nilSign:       true,
+			expectedError: ErrConstructionFlowInvalid,
+		},
+		"signer error": {
+			budget:        5 * time.Second,
+			signErr:       errors.New("bad key"),
+			expectedError: ErrSignFailed,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var (
+				assert = assert.New(t)
+				ctx    = context.Background()
+			)
+
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal("POST", r.Method)
+
+				if r.URL.RequestURI() == test.slowStep {
+					select {
+					case <-r.Context().Done():
+					case <-time.After(time.Second):
+					}
+				}
+
+				w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+				w.WriteHeader(http.StatusOK)
+				fmt.Fprintln(w, types.PrettyPrintStruct(responses[r.URL.RequestURI()]))
+			}))
+			defer ts.Close()
+
+			flow := &ConstructionFlow{
+				Network: basicNetwork,
+				Operations: []*types.Operation{
+					transferOperation(0, "addr1", "-100"),
+					transferOperation(1, "addr2", "100"),
+				},
+				Sign: func(
+					ctx context.Context,
+					payloads []*types.SigningPayload,
+				) ([]*types.Signature, error) {
+					assert.Len(payloads, 1)
+					if test.signDelay > 0 {
+						select {
+						case <-ctx.Done():
+							return nil, ctx.Err()
+						case <-time.After(test.signDelay):
+						}
+					}
+
+					return []*types.Signature{
+						{
+							SigningPayload: payloads[0],
+							SignatureType:  types.Ecdsa,
+							Bytes:          []byte("signature"),
+						},
+					}, test.signErr
+				},
+			}
+			if test.nilSign {
+				flow.Sign = nil
+			}
+
+			f := New(ts.URL)
+			txIdentifier, _, fetcherErr := f.ConstructionFlowWithDeadline(
+				ctx,
+				test.budget,
+				flow,
+			)
+
+			if test.expectedError == nil {
+				assert.Nil(fetcherErr)
+				assert.Equal(&types.TransactionIdentifier{Hash: "tx"}, txIdentifier)
+				return
+			}
+
+			assert.Nil(txIdentifier)
+			assert.NotNil(fetcherErr)
+			assert.True(errors.Is(fetcherErr.Err, test.expectedError))
+			assert.True(strings.Contains(fetcherErr.Err.Error(), test.expectedStep))
+		})
+	}
+
+	t.Run("nil flow", func(t *testing.T) {
+		f := New("http://localhost")
+		txIdentifier, _, fetcherErr := f.ConstructionFlowWithDeadline(
+			context.Background(),
+			time.Second,
+			nil,
+		)
+		assert.Nil(t, txIdentifier)
+		assert.True(t, errors.Is(fetcherErr.Err, ErrConstructionFlowInvalid))
+	})
+}
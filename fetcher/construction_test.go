@@ -0,0 +1,241 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/coinbase/rosetta-sdk-go/asserter"
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+func transferOperation(index int64, address string, value string) *types.Operation {
+	return &types.Operation{
+		OperationIdentifier: &types.OperationIdentifier{Index: index},
+		Type:                "transfer",
+		Account:             &types.AccountIdentifier{Address: address},
+		Amount: &types.Amount{
+			Value: value,
+			Currency: &types.Currency{
+				Symbol:   "BTC",
+				Decimals: 8,
+			},
+		},
+	}
+}
+
+func TestVerifyTransactionIntent(t *testing.T) {
+	intent := []*types.Operation{
+		transferOperation(0, "addr1", "-100"),
+		transferOperation(1, "addr2", "100"),
+	}
+
+	var tests = map[string]struct {
+		parsedOps       []*types.Operation
+		parsedSigners   []*types.AccountIdentifier
+		expectedSigners []string
+
+		expectedError error
+	}{
+		"matching intent": {
+			parsedOps:       intent,
+			parsedSigners:   []*types.AccountIdentifier{{Address: "addr1"}},
+			expectedSigners: []string{"addr1"},
+		},
+		"reordered operations and duplicate signers": {
+			parsedOps: []*types.Operation{
+				transferOperation(0, "addr2", "100"),
+				transferOperation(1, "addr1", "-100"),
+			},
+			parsedSigners:   []*types.AccountIdentifier{{Address: "addr1"}},
+			expectedSigners: []string{"addr1", "addr1"},
+		},
+		"missing operation": {
+			parsedOps: []*types.Operation{
+				transferOperation(0, "addr1", "-100"),
+			},
+			parsedSigners:   []*types.AccountIdentifier{{Address: "addr1"}},
+			expectedSigners: []string{"addr1"},
+			expectedError:   ErrIntentMismatch,
+		},
+		"extra operation": {
+			parsedOps: []*types.Operation{
+				transferOperation(0, "addr1", "-100"),
+				transferOperation(1, "addr2", "100"),
+				transferOperation(2, "addr3", "100"),
+			},
+			parsedSigners:   []*types.AccountIdentifier{{Address: "addr1"}},
+			expectedSigners: []string{"addr1"},
+			expectedError:   ErrIntentMismatch,
+		},
+		"changed amount": {
+			parsedOps: []*types.Operation{
+				transferOperation(0, "addr1", "-100"),
+				transferOperation(1, "addr2", "99"),
+			},
+			parsedSigners:   []*types.AccountIdentifier{{Address: "addr1"}},
+			expectedSigners: []string{"addr1"},
+			expectedError:   ErrIntentMismatch,
+		},
+		"unexpected signer": {
+			parsedOps:       intent,
+			parsedSigners:   []*types.AccountIdentifier{{Address: "addr2"}},
+			expectedSigners: []string{"addr1"},
+			expectedError:   ErrSignersMismatch,
+		},
+		"missing signer": {
+			parsedOps:       intent,
+			parsedSigners:   []*types.AccountIdentifier{{Address: "addr1"}},
+			expectedSigners: []string{"addr1", "addr2"},
+			expectedError:   ErrSignersMismatch,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var (
+				assert   = assert.New(t)
+				ctx      = context.Background()
+				endpoint = "/construction/parse"
+			)
+
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal("POST", r.Method)
+				assert.Equal(endpoint, r.URL.RequestURI())
+
+				w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+				w.WriteHeader(http.StatusOK)
+				fmt.Fprintln(w, types.PrettyPrintStruct(
+					&types.ConstructionParseResponse{
+						Operations:               test.parsedOps,
+						AccountIdentifierSigners: test.parsedSigners,
+					},
+				))
+			}))
+			defer ts.Close()
+
+			a, err := asserter.NewClientWithOptions(
+				basicNetwork,
+				&types.BlockIdentifier{Index: 0, Hash: "block 0"},
+				[]string{"transfer"},
+				[]*types.OperationStatus{{Status: "success", Successful: true}},
+				[]*types.Error{},
+				nil,
+				&asserter.Validations{Enabled: false},
+			)
+			assert.NoError(err)
+
+			f := New(ts.URL, WithAsserter(a))
+			err = f.VerifyTransactionIntent(
+				ctx,
+				basicNetwork,
+				"signed tx",
+				intent,
+				test.expectedSigners,
+			)
+			if test.expectedError == nil {
+				assert.NoError(err)
+			} else {
+				assert.True(errors.Is(err, test.expectedError))
+			}
+		})
+	}
+}
+
+func TestSupportsConstruction(t *testing.T) {
+	var tests = map[string]struct {
+		statusCode int
+		response   interface{}
+
+		expectedSupported bool
+		expectedError     error
+	}{
+		"supported": {
+			statusCode: http.StatusOK,
+			response: &types.ConstructionPreprocessResponse{
+				Options: map[string]interface{}{},
+			},
+			expectedSupported: true,
+		},
+		"supported with rosetta error": {
+			statusCode: http.StatusInternalServerError,
+			response: &types.Error{
+				Code:    1,
+				Message: "no operations",
+			},
+			expectedSupported: true,
+		},
+		"not found": {
+			statusCode: http.StatusNotFound,
+			response:   map[string]interface{}{},
+		},
+		"not implemented": {
+			statusCode: http.StatusNotImplemented,
+			response:   map[string]interface{}{},
+		},
+		"transient error": {
+			statusCode:    http.StatusServiceUnavailable,
+			response:      map[string]interface{}{},
+			expectedError: ErrRequestFailed,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var (
+				assert   = assert.New(t)
+				ctx      = context.Background()
+				endpoint = "/construction/preprocess"
+			)
+
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal("POST", r.Method)
+				assert.Equal(endpoint, r.URL.RequestURI())
+
+				w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+				w.WriteHeader(test.statusCode)
+				fmt.Fprintln(w, types.PrettyPrintStruct(test.response))
+			}))
+			defer ts.Close()
+
+			a, err := asserter.NewClientWithOptions(
+				basicNetwork,
+				&types.BlockIdentifier{Index: 0, Hash: "block 0"},
+				[]string{"transfer"},
+				[]*types.OperationStatus{{Status: "success", Successful: true}},
+				[]*types.Error{{Code: 1, Message: "no operations"}},
+				nil,
+				&asserter.Validations{Enabled: false},
+			)
+			assert.NoError(err)
+
+			f := New(ts.URL, WithAsserter(a))
+			supported, err := f.SupportsConstruction(ctx, basicNetwork)
+			assert.Equal(test.expectedSupported, supported)
+			if test.expectedError == nil {
+				assert.NoError(err)
+			} else {
+				assert.True(errors.Is(err, test.expectedError))
+			}
+		})
+	}
+}
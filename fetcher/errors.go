@@ -43,22 +43,53 @@ func (f *Fetcher) RequestFailedError(
 	err error,
 	message string,
 ) *Error {
-	// Only check for error correctness if err is not context.Canceled
-	// and it is not transient (usually caused by the client failing
-	// the request).
-	if !errors.Is(err, context.Canceled) && !transientError(err) {
+	// Only check for error correctness if the implementation returned
+	// a *types.Error, err is not context.Canceled, and it is not transient
+	// (usually caused by the client failing the request). Plain HTTP
+	// failures (with no *types.Error) have nothing to assert.
+	if rosettaErr != nil && f.Asserter != nil &&
+		!errors.Is(err, context.Canceled) && !transientError(err) {
 		// If there is a *types.Error assertion error, we log it instead
-		// of exiting. Exiting abruptly here may cause unintended consequences.
+		// of exiting (unless WithStrictErrors is provided). Exiting abruptly
+		// here may cause unintended consequences.
 		if assertionErr := f.Asserter.Error(rosettaErr); assertionErr != nil {
+			if f.strictErrors {
+				return &Error{
+					Err: fmt.Errorf(
+						"%w: %s %s: %s",
+						ErrErrorAssertionFailed,
+						message,
+						err.Error(),
+						assertionErr.Error(),
+					),
+					ClientErr: rosettaErr,
+				}
+			}
+
 			log.Printf("error %s assertion failed: %s", types.PrintStruct(rosettaErr), assertionErr)
 		}
 	}
 
+	retry := (rosettaErr != nil && rosettaErr.Retriable) || transientError(err) || f.forceRetry
+	code := statusCode(rosettaErr, err)
+	if f.honorRetriable {
+		if rosettaErr == nil {
+			rosettaErr = errorBody(err)
+		}
+
+		if rosettaErr != nil {
+			retry = rosettaErr.Retriable
+		}
+	}
+
+	if f.retryClassifier != nil {
+		retry = f.retryClassifier(err, code, rosettaErr)
+	}
+
 	return &Error{
 		Err:       fmt.Errorf("%w: %s %s", ErrRequestFailed, message, err.Error()),
 		ClientErr: rosettaErr,
-		Retry: ((rosettaErr != nil && rosettaErr.Retriable) || transientError(err) || f.forceRetry) &&
-			!errors.Is(err, context.Canceled),
+		Retry:     retry && !errors.Is(err, context.Canceled),
 	}
 }
 
@@ -82,6 +113,50 @@ var (
 	// ErrCouldNotAcquireSemaphore is returned when acquiring
 	// the connection semaphore returns an error.
 	ErrCouldNotAcquireSemaphore = errors.New("could not acquire semaphore")
+
+	// ErrIntentMismatch is returned when the operations parsed
+	// from a signed transaction do not match the intended operations.
+	ErrIntentMismatch = errors.New("parsed operations do not match intent")
+
+	// ErrSignersMismatch is returned when the signers parsed
+	// from a signed transaction do not match the expected signers.
+	ErrSignersMismatch = errors.New("parsed signers do not match expected signers")
+
+	// ErrRecordingNotFound is returned by a Fetcher created
+	// with NewReplayFetcher when a request was not recorded.
+	ErrRecordingNotFound = errors.New("recording not found")
+
+	// ErrNonceMissing is returned by a NonceManager when
+	// the construction metadata does not contain a nonce.
+	ErrNonceMissing = errors.New("nonce missing from construction metadata")
+
+	// ErrNonceInvalid is returned by a NonceManager when
+	// the nonce in the construction metadata cannot be parsed.
+	ErrNonceInvalid = errors.New("nonce in construction metadata is invalid")
+
+	// ErrInitializeAssertersFailed is returned by InitializeAsserters
+	// when an *asserter.Asserter could not be initialized for
+	// at least one network.
+	ErrInitializeAssertersFailed = errors.New("unable to initialize asserters")
+
+	// ErrErrorAssertionFailed is returned when WithStrictErrors
+	// is provided and the Rosetta server returns a *types.Error
+	// that does not match any error in /network/options.
+	ErrErrorAssertionFailed = errors.New("error does not match /network/options")
+
+	// ErrConstructionBudgetExceeded is returned by
+	// ConstructionFlowWithDeadline when a step does not
+	// complete within the budget.
+	ErrConstructionBudgetExceeded = errors.New("construction flow budget exceeded")
+
+	// ErrSignFailed is returned by ConstructionFlowWithDeadline
+	// when the signing payloads cannot be signed.
+	ErrSignFailed = errors.New("unable to sign payloads")
+
+	// ErrConstructionFlowInvalid is returned by
+	// ConstructionFlowWithDeadline when the provided
+	// *ConstructionFlow is nil or has no Sign function.
+	ErrConstructionFlowInvalid = errors.New("construction flow is invalid")
 )
 
 // Err takes an error as an argument and returns
@@ -93,6 +168,16 @@ func Err(err error) bool {
 		ErrRequestFailed,
 		ErrExhaustedRetries,
 		ErrCouldNotAcquireSemaphore,
+		ErrIntentMismatch,
+		ErrSignersMismatch,
+		ErrRecordingNotFound,
+		ErrNonceMissing,
+		ErrNonceInvalid,
+		ErrInitializeAssertersFailed,
+		ErrErrorAssertionFailed,
+		ErrConstructionBudgetExceeded,
+		ErrSignFailed,
+		ErrConstructionFlowInvalid,
 	}
 
 	return utils.FindError(fetcherErrors, err)
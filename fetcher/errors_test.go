@@ -15,10 +15,19 @@
 package fetcher
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/coinbase/rosetta-sdk-go/asserter"
+	"github.com/coinbase/rosetta-sdk-go/client"
+	"github.com/coinbase/rosetta-sdk-go/types"
 )
 
 func TestErr(t *testing.T) {
@@ -43,3 +52,303 @@ func TestErr(t *testing.T) {
 		})
 	}
 }
+
+func TestRequestFailedErrorWithRetryClassifier(t *testing.T) {
+	nodeSyncing := &types.Error{
+		Code:    1,
+		Message: "node syncing",
+	}
+	invalidSignature := &types.Error{
+		Code:      2,
+		Message:   "invalid signature",
+		Retriable: true,
+	}
+
+	classifier := func(err error, statusCode int, rosettaError *types.Error) bool {
+		if rosettaError != nil {
+			return rosettaError.Code == nodeSyncing.Code
+		}
+
+		return statusCode == http.StatusTooManyRequests
+	}
+
+	var tests = map[string]struct {
+		classifier RetryClassifier
+		rosettaErr *types.Error
+		err        error
+
+		retry bool
+	}{
+		"default non-retriable rosetta error": {
+			rosettaErr: nodeSyncing,
+			err:        errors.New("node syncing"),
+			retry:      false,
+		},
+		"default retriable rosetta error": {
+			rosettaErr: invalidSignature,
+			err:        errors.New("invalid signature"),
+			retry:      true,
+		},
+		"classifier retries rosetta error": {
+			classifier: classifier,
+			rosettaErr: nodeSyncing,
+			err:        errors.New("node syncing"),
+			retry:      true,
+		},
+		"classifier does not retry rosetta error": {
+			classifier: classifier,
+			rosettaErr: invalidSignature,
+			err:        errors.New("invalid signature"),
+			retry:      false,
+		},
+		"classifier retries status code": {
+			classifier: classifier,
+			err:        errors.New("invalid status code: 429 body: slow down"),
+			retry:      true,
+		},
+		"classifier does not retry transient error": {
+			classifier: classifier,
+			err:        fmt.Errorf("%w: code: 503 body: unavailable", client.ErrRetriable),
+			retry:      false,
+		},
+		"classifier cannot retry canceled context": {
+			classifier: func(error, int, *types.Error) bool { return true },
+			err:        context.Canceled,
+			retry:      false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var f *Fetcher
+			if test.classifier != nil {
+				f = New("https://serveraddress", WithRetryClassifier(test.classifier))
+			} else {
+				f = New("https://serveraddress")
+			}
+
+			fetcherErr := f.RequestFailedError(test.rosettaErr, test.err, "/network/status")
+			assert.True(t, errors.Is(fetcherErr.Err, ErrRequestFailed))
+			assert.Equal(t, test.rosettaErr, fetcherErr.ClientErr)
+			assert.Equal(t, test.retry, fetcherErr.Retry)
+		})
+	}
+}
+
+func TestRequestFailedErrorStrictErrors(t *testing.T) {
+	declared := &types.Error{
+		Code:      1,
+		Message:   "node syncing",
+		Retriable: true,
+	}
+	a, err := asserter.NewClientWithOptions(
+		basicNetwork,
+		&types.BlockIdentifier{
+			Index: 0,
+			Hash:  "block 0",
+		},
+		basicNetworkOptions.Allow.OperationTypes,
+		basicNetworkOptions.Allow.OperationStatuses,
+		[]*types.Error{declared},
+		nil,
+		&asserter.Validations{
+			Enabled: false,
+		},
+	)
+	assert.NoError(t, err)
+
+	var tests = map[string]struct {
+		strict     bool
+		initialize bool
+		rosettaErr *types.Error
+
+		expectedErr error
+		retry       bool
+	}{
+		"declared error": {
+			initialize:  true,
+			rosettaErr:  declared,
+			expectedErr: ErrRequestFailed,
+			retry:       true,
+		},
+		"declared error (strict)": {
+			strict:      true,
+			initialize:  true,
+			rosettaErr:  declared,
+			expectedErr: ErrRequestFailed,
+			retry:       true,
+		},
+		"undeclared error": {
+			initialize:  true,
+			rosettaErr:  &types.Error{Code: 2, Message: "undeclared", Retriable: true},
+			expectedErr: ErrRequestFailed,
+			retry:       true,
+		},
+		"undeclared error (strict)": {
+			strict:      true,
+			initialize:  true,
+			rosettaErr:  &types.Error{Code: 2, Message: "undeclared", Retriable: true},
+			expectedErr: ErrErrorAssertionFailed,
+			retry:       false,
+		},
+		"transport error (strict)": {
+			strict:      true,
+			initialize:  true,
+			expectedErr: ErrRequestFailed,
+		},
+		"transport error (strict, asserter not initialized)": {
+			strict:      true,
+			expectedErr: ErrRequestFailed,
+		},
+		"undeclared error (strict, asserter not initialized)": {
+			strict:      true,
+			rosettaErr:  &types.Error{Code: 2, Message: "undeclared", Retriable: true},
+			expectedErr: ErrRequestFailed,
+			retry:       true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			opts := []Option{}
+			if test.strict {
+				opts = append(opts, WithStrictErrors())
+			}
+			if test.initialize {
+				opts = append(opts, WithAsserter(a))
+			}
+			f := New("https://serveraddress", opts...)
+
+			fetcherErr := f.RequestFailedError(
+				test.rosettaErr,
+				errors.New("request failed"),
+				"/network/status",
+			)
+			assert.True(t, errors.Is(fetcherErr.Err, test.expectedErr))
+			assert.Equal(t, test.rosettaErr, fetcherErr.ClientErr)
+			assert.Equal(t, test.retry, fetcherErr.Retry)
+		})
+	}
+}
+
+func TestStatusCode(t *testing.T) {
+	var tests = map[string]struct {
+		rosettaErr *types.Error
+		err        error
+
+		statusCode int
+	}{
+		"rosetta error": {
+			rosettaErr: &types.Error{Code: 1},
+			err:        errors.New("blah"),
+			statusCode: http.StatusInternalServerError,
+		},
+		"retriable status code": {
+			err:        fmt.Errorf("%w: code: 502 body: bad gateway", client.ErrRetriable),
+			statusCode: http.StatusBadGateway,
+		},
+		"invalid status code": {
+			err:        errors.New("invalid status code: 404 body: not found"),
+			statusCode: http.StatusNotFound,
+		},
+		"no status code": {
+			err:        errors.New("connection reset by peer"),
+			statusCode: 0,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.statusCode, statusCode(test.rosettaErr, test.err))
+		})
+	}
+}
+
+func TestHonorRetriable(t *testing.T) {
+	var tests = map[string]struct {
+		statusCode int
+		body       string
+
+		expectedTries int
+		expectedError error
+	}{
+		"retriable on internal server error": {
+			statusCode: http.StatusInternalServerError,
+			body: types.PrettyPrintStruct(&types.Error{
+				Code:      1,
+				Message:   "node busy",
+				Retriable: true,
+			}),
+			expectedTries: 3,
+		},
+		"non-retriable on internal server error": {
+			statusCode: http.StatusInternalServerError,
+			body: types.PrettyPrintStruct(&types.Error{
+				Code:    1,
+				Message: "unexpected EOF",
+			}),
+			expectedTries: 1,
+			expectedError: ErrRequestFailed,
+		},
+		"non-retriable on service unavailable": {
+			statusCode: http.StatusServiceUnavailable,
+			body: types.PrettyPrintStruct(&types.Error{
+				Code:    2,
+				Message: "invalid request",
+			}),
+			expectedTries: 1,
+			expectedError: ErrRequestFailed,
+		},
+		"retriable on too many requests": {
+			statusCode: http.StatusTooManyRequests,
+			body: types.PrettyPrintStruct(&types.Error{
+				Code:      3,
+				Message:   "slow down",
+				Retriable: true,
+			}),
+			expectedTries: 3,
+		},
+		"no rosetta error on service unavailable": {
+			statusCode:    http.StatusServiceUnavailable,
+			body:          "unavailable",
+			expectedTries: 3,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var (
+				tries  = 0
+				assert = assert.New(t)
+				ctx    = context.Background()
+			)
+
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				tries++
+				w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+				if tries < 3 {
+					w.WriteHeader(test.statusCode)
+					fmt.Fprintln(w, test.body)
+					return
+				}
+
+				w.WriteHeader(http.StatusOK)
+				fmt.Fprintln(w, types.PrettyPrintStruct(basicNetworkStatus))
+			}))
+			defer ts.Close()
+
+			f := New(
+				ts.URL,
+				WithRetryElapsedTime(5*time.Second),
+				WithMaxRetries(5),
+				WithHonorRetriable(),
+			)
+			status, err := f.NetworkStatusRetry(ctx, basicNetwork, nil)
+			assert.Equal(test.expectedTries, tries)
+			assert.True(checkError(err, test.expectedError))
+			if test.expectedError == nil {
+				assert.Equal(basicNetworkStatus, status)
+			}
+		})
+	}
+}
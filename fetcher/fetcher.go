@@ -19,7 +19,11 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"log"
 	"net/http"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"golang.org/x/sync/semaphore"
@@ -73,8 +77,27 @@ type Fetcher struct {
 	maxRetries       uint64
 	retryElapsedTime time.Duration
 	insecureTLS      bool
+	tlsConfig        *tls.Config
 	forceRetry       bool
+	retryClassifier  RetryClassifier
+	honorRetriable   bool
+	strictErrors     bool
 	httpTimeout      time.Duration
+	zstdResponses    bool
+
+	// recordDir is the directory each response is
+	// written to. If empty, no recording is performed.
+	recordDir string
+
+	// networkCachePath is the file used to persist
+	// the responses used to initialize the Asserter. If
+	// empty, no caching is performed.
+	networkCachePath string
+	networkCacheTTL  time.Duration
+
+	// blockCache stores recently fetched blocks by
+	// hash. If nil, no caching is performed.
+	blockCache *blockCache
 
 	// connectionSemaphore is used to limit the
 	// number of concurrent requests we make.
@@ -121,18 +144,52 @@ func New(
 		f.rosettaClient = client.NewAPIClient(clientCfg)
 	}
 
-	if f.insecureTLS {
+	if f.tlsConfig != nil || f.insecureTLS {
 		if transport, ok := f.rosettaClient.GetConfig().HTTPClient.Transport.(*http.Transport); ok {
-			transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} // #nosec G402
+			tlsConfig := &tls.Config{} // #nosec G402
+			if f.tlsConfig != nil {
+				tlsConfig = f.tlsConfig.Clone()
+			}
+
+			if f.insecureTLS {
+				tlsConfig.InsecureSkipVerify = true // #nosec G402
+			}
+
+			transport.TLSClientConfig = tlsConfig
 		}
 	}
 
+	if f.zstdResponses {
+		f.wrapTransport(func(transport http.RoundTripper) http.RoundTripper {
+			return newCompressionTransport(transport)
+		})
+	}
+
+	if len(f.recordDir) > 0 {
+		f.wrapTransport(func(transport http.RoundTripper) http.RoundTripper {
+			return newRecordingTransport(transport, f.recordDir)
+		})
+	}
+
 	// Initialize the connection semaphore
 	f.connectionSemaphore = semaphore.NewWeighted(int64(f.maxConnections))
 
 	return f
 }
 
+// wrapTransport replaces the transport of the *http.Client
+// used by the Fetcher with wrap(transport). The *http.Client
+// and *client.Configuration are copied first, so a client
+// provided with WithClient (which may be shared with other
+// Fetchers) is not modified.
+func (f *Fetcher) wrapTransport(wrap func(http.RoundTripper) http.RoundTripper) {
+	cfg := *f.rosettaClient.GetConfig()
+	httpClient := *cfg.HTTPClient
+	httpClient.Transport = wrap(httpClient.Transport)
+	cfg.HTTPClient = &httpClient
+	f.rosettaClient = client.NewAPIClient(&cfg)
+}
+
 // InitializeAsserter creates an Asserter for
 // validating responses. The Asserter is created
 // by fetching the NetworkStatus and NetworkOptions
@@ -145,6 +202,12 @@ func New(
 //
 // This method should be called before making any
 // validated client requests.
+//
+// If WithCachedNetworkOptions is provided, a cached
+// NetworkStatus and NetworkOptions written within the TTL
+// will be used instead of querying the Rosetta server. In this
+// case, the returned *types.NetworkStatusResponse is the cached
+// response (and may not reflect the current tip).
 func (f *Fetcher) InitializeAsserter(
 	ctx context.Context,
 	networkIdentifier *types.NetworkIdentifier,
@@ -158,6 +221,34 @@ func (f *Fetcher) InitializeAsserter(
 		return nil, nil, &Error{Err: errors.New("asserter already initialized")}
 	}
 
+	if networkIdentifier != nil {
+		if err := asserter.NetworkIdentifier(networkIdentifier); err != nil {
+			return nil, nil, &Error{
+				Err: fmt.Errorf("%w: invalid network identifier", err),
+			}
+		}
+	}
+
+	if len(f.networkCachePath) > 0 {
+		cache, err := f.loadNetworkCache(networkIdentifier)
+		if err == nil {
+			newAsserter, assertErr := asserter.NewClientWithResponses(
+				cache.NetworkIdentifier,
+				cache.NetworkStatus,
+				cache.NetworkOptions,
+				validationFilePath,
+			)
+			if assertErr == nil {
+				f.Asserter = newAsserter
+				return cache.NetworkIdentifier, cache.NetworkStatus, nil
+			}
+
+			err = assertErr
+		}
+
+		log.Printf("%s: fetching network options from server\n", err.Error())
+	}
+
 	// Attempt to fetch network list
 	networkList, err := f.NetworkListRetry(ctx, nil)
 	if err != nil {
@@ -185,36 +276,155 @@ func (f *Fetcher) InitializeAsserter(
 		primaryNetwork = networkIdentifier
 	}
 
+	newAsserter, networkStatus, networkOptions, err := f.fetchAsserter(
+		ctx,
+		primaryNetwork,
+		validationFilePath,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	f.Asserter = newAsserter
+
+	if len(f.networkCachePath) > 0 {
+		if err := f.storeNetworkCache(primaryNetwork, networkStatus, networkOptions); err != nil {
+			log.Printf("%s: unable to cache network options\n", err.Error())
+		}
+	}
+
+	return primaryNetwork, networkStatus, nil
+}
+
+// fetchAsserter fetches the NetworkStatus and NetworkOptions
+// of a network and uses them to construct an *asserter.Asserter.
+func (f *Fetcher) fetchAsserter(
+	ctx context.Context,
+	network *types.NetworkIdentifier,
+	validationFilePath string,
+) (
+	*asserter.Asserter,
+	*types.NetworkStatusResponse,
+	*types.NetworkOptionsResponse,
+	*Error,
+) {
 	// Attempt to fetch network status
 	networkStatus, err := f.NetworkStatusRetry(
 		ctx,
-		primaryNetwork,
+		network,
 		nil,
 	)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	// Attempt to fetch network options
 	networkOptions, err := f.NetworkOptionsRetry(
 		ctx,
-		primaryNetwork,
+		network,
 		nil,
 	)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	newAsserter, assertErr := asserter.NewClientWithResponses(
-		primaryNetwork,
+		network,
 		networkStatus,
 		networkOptions,
 		validationFilePath,
 	)
 	if assertErr != nil {
-		return nil, nil, &Error{Err: assertErr}
+		return nil, nil, nil, &Error{Err: assertErr}
 	}
-	f.Asserter = newAsserter
 
-	return primaryNetwork, networkStatus, nil
+	return newAsserter, networkStatus, networkOptions, nil
+}
+
+// InitializeAsserters concurrently initializes an *asserter.Asserter
+// for each provided network (which must all be returned by
+// /network/list). The returned map is keyed by types.Hash(network).
+//
+// If any network cannot be initialized, the *asserter.Asserter of
+// all networks that succeeded are still returned alongside an
+// error describing each network that failed.
+//
+// Unlike InitializeAsserter, this does not populate the
+// Asserter used by the Fetcher (a Fetcher can only assert
+// responses of a single network).
+func (f *Fetcher) InitializeAsserters(
+	ctx context.Context,
+	networks []*types.NetworkIdentifier,
+	validationFilePath string,
+) (map[string]*asserter.Asserter, *Error) {
+	asserters := map[string]*asserter.Asserter{}
+	if len(networks) == 0 {
+		return asserters, nil
+	}
+
+	for _, network := range networks {
+		if err := asserter.NetworkIdentifier(network); err != nil {
+			return nil, &Error{
+				Err: fmt.Errorf("%w: invalid network identifier", err),
+			}
+		}
+	}
+
+	// Attempt to fetch network list
+	networkList, err := f.NetworkListRetry(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		failures []string
+		lock     sync.Mutex
+		wg       sync.WaitGroup
+	)
+	fail := func(network *types.NetworkIdentifier, err error) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		failures = append(
+			failures,
+			fmt.Sprintf("%s: %s", types.PrintStruct(network), err.Error()),
+		)
+	}
+
+	for _, network := range networks {
+		if exists, _ := CheckNetworkListForNetwork(networkList, network); !exists {
+			fail(network, ErrNetworkMissing)
+			continue
+		}
+
+		wg.Add(1)
+		go func(network *types.NetworkIdentifier) {
+			defer wg.Done()
+
+			newAsserter, _, _, err := f.fetchAsserter(ctx, network, validationFilePath)
+			if err != nil {
+				fail(network, err.Err)
+				return
+			}
+
+			lock.Lock()
+			asserters[types.Hash(network)] = newAsserter
+			lock.Unlock()
+		}(network)
+	}
+	wg.Wait()
+
+	if len(failures) > 0 {
+		sort.Strings(failures)
+		return asserters, &Error{
+			Err: fmt.Errorf(
+				"%w: %d of %d networks failed: %s",
+				ErrInitializeAssertersFailed,
+				len(failures),
+				len(networks),
+				strings.Join(failures, "; "),
+			),
+		}
+	}
+
+	return asserters, nil
 }
@@ -15,14 +15,22 @@
 package fetcher
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path"
 	"testing"
 	"time"
 
+	"github.com/DataDog/zstd"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/coinbase/rosetta-sdk-go/asserter"
@@ -130,6 +138,26 @@ func TestInitializeAsserter(t *testing.T) {
 			networkOptions: basicNetworkOptions,
 			expectedError:  ErrNetworkMissing,
 		},
+		"network missing blockchain": {
+			network: &types.NetworkIdentifier{
+				Network: "mainnet",
+			},
+			expectedError: asserter.ErrNetworkIdentifierBlockchainMissing,
+		},
+		"network missing network": {
+			network: &types.NetworkIdentifier{
+				Blockchain: "bitcoin",
+			},
+			expectedError: asserter.ErrNetworkIdentifierNetworkMissing,
+		},
+		"sub network missing network": {
+			network: &types.NetworkIdentifier{
+				Blockchain:           "bitcoin",
+				Network:              "mainnet",
+				SubNetworkIdentifier: &types.SubNetworkIdentifier{},
+			},
+			expectedError: asserter.ErrSubNetworkIdentifierInvalid,
+		},
 		"invalid options": {
 			networkRequest: &types.NetworkRequest{
 				NetworkIdentifier: basicNetwork,
@@ -215,6 +243,102 @@ func TestNewWithHTTPCLient(t *testing.T) {
 	assert.Same(httpClient, fetcher.rosettaClient.GetConfig().HTTPClient)
 }
 
+func TestNewWithZstdResponsesSharedClient(t *testing.T) {
+	// Fetchers created from the same client
+	// must not wrap the transport of that client.
+	var assert = assert.New(t)
+	transport := &http.Transport{}
+	httpClient := &http.Client{Transport: transport}
+	apiClient := client.NewAPIClient(
+		client.NewConfiguration(
+			"https://serveraddress",
+			DefaultUserAgent,
+			httpClient,
+		),
+	)
+
+	fetcher := New("https://serveraddress", WithClient(apiClient), WithZstdResponses())
+	fetcher2 := New("https://serveraddress", WithClient(apiClient), WithZstdResponses())
+	assert.Same(httpClient, apiClient.GetConfig().HTTPClient)
+	assert.Same(transport, httpClient.Transport)
+
+	for _, f := range []*Fetcher{fetcher, fetcher2} {
+		wrapped, ok := f.rosettaClient.GetConfig().HTTPClient.Transport.(*compressionTransport)
+		assert.True(ok)
+		assert.Same(transport, wrapped.transport)
+	}
+}
+
+func TestResponseCompression(t *testing.T) {
+	var tests = map[string]struct {
+		options []Option
+
+		expectedAcceptEncoding string
+		contentEncoding        string
+	}{
+		"gzip by default": {
+			expectedAcceptEncoding: "gzip",
+			contentEncoding:        "gzip",
+		},
+		"uncompressed": {
+			expectedAcceptEncoding: "gzip",
+		},
+		"zstd": {
+			options:                []Option{WithZstdResponses()},
+			expectedAcceptEncoding: "zstd, gzip",
+			contentEncoding:        "zstd",
+		},
+		"gzip with zstd support": {
+			options:                []Option{WithZstdResponses()},
+			expectedAcceptEncoding: "zstd, gzip",
+			contentEncoding:        "gzip",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var (
+				assert = assert.New(t)
+				ctx    = context.Background()
+			)
+
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal("/network/list", r.URL.RequestURI())
+				assert.Equal(test.expectedAcceptEncoding, r.Header.Get("Accept-Encoding"))
+
+				body := []byte(types.PrettyPrintStruct(basicNetworkList))
+				switch test.contentEncoding {
+				case "gzip":
+					var buf bytes.Buffer
+					writer := gzip.NewWriter(&buf)
+					_, err := writer.Write(body)
+					assert.NoError(err)
+					assert.NoError(writer.Close())
+					body = buf.Bytes()
+				case "zstd":
+					var err error
+					body, err = zstd.Compress(nil, body)
+					assert.NoError(err)
+				}
+
+				w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+				if len(test.contentEncoding) > 0 {
+					w.Header().Set("Content-Encoding", test.contentEncoding)
+				}
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write(body)
+				assert.NoError(err)
+			}))
+			defer ts.Close()
+
+			f := New(ts.URL, test.options...)
+			networkList, err := f.NetworkList(ctx, nil)
+			assert.Nil(err)
+			assert.Equal(basicNetworkList, networkList)
+		})
+	}
+}
+
 func TestNewWithTimeout(t *testing.T) {
 	var assert = assert.New(t)
 
@@ -242,3 +366,234 @@ func TestNewWithTimeout(t *testing.T) {
 	fetcher3 := New("https://serveraddress", WithClient(apiClient), WithTimeout(6*time.Minute))
 	assert.Equal(existingClientTimeout, fetcher3.rosettaClient.GetConfig().HTTPClient.Timeout)
 }
+
+func TestTLSConfig(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, types.PrettyPrintStruct(basicNetworkList))
+	}))
+	defer ts.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ts.Certificate())
+
+	var tests = map[string]struct {
+		options []Option
+
+		expectedError bool
+	}{
+		"default config": {
+			expectedError: true,
+		},
+		"custom ca pool": {
+			options: []Option{
+				WithTLSConfig(&tls.Config{
+					RootCAs:    pool,
+					MinVersion: tls.VersionTLS12,
+				}),
+			},
+		},
+		"insecure tls": {
+			options: []Option{WithInsecureTLS()},
+		},
+		"insecure tls with custom config": {
+			options: []Option{
+				WithTLSConfig(&tls.Config{MinVersion: tls.VersionTLS12}),
+				WithInsecureTLS(),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var (
+				assert = assert.New(t)
+				ctx    = context.Background()
+			)
+
+			options := append([]Option{WithMaxRetries(0)}, test.options...)
+			f := New(ts.URL, options...)
+			networkList, err := f.NetworkList(ctx, nil)
+			if test.expectedError {
+				assert.NotNil(err)
+				assert.Nil(networkList)
+				return
+			}
+
+			assert.Nil(err)
+			assert.Equal(basicNetworkList, networkList)
+		})
+	}
+}
+
+func TestInitializeAsserters(t *testing.T) {
+	missingNetwork := &types.NetworkIdentifier{
+		Blockchain: "missing",
+		Network:    "missing",
+	}
+
+	var tests = map[string]struct {
+		networks []*types.NetworkIdentifier
+
+		expectedNetworks []*types.NetworkIdentifier
+		expectedError    error
+		expectedMessage  string
+	}{
+		"no networks": {
+			networks:         []*types.NetworkIdentifier{},
+			expectedNetworks: []*types.NetworkIdentifier{},
+		},
+		"multiple networks": {
+			networks:         []*types.NetworkIdentifier{basicNetwork, otherNetwork},
+			expectedNetworks: []*types.NetworkIdentifier{basicNetwork, otherNetwork},
+		},
+		"partial failure": {
+			networks: []*types.NetworkIdentifier{
+				basicNetwork,
+				missingNetwork,
+				otherNetwork,
+			},
+			expectedNetworks: []*types.NetworkIdentifier{basicNetwork, otherNetwork},
+			expectedError:    ErrInitializeAssertersFailed,
+			expectedMessage:  types.PrintStruct(missingNetwork),
+		},
+		"invalid network": {
+			networks: []*types.NetworkIdentifier{
+				basicNetwork,
+				{Blockchain: "bitcoin"},
+			},
+			expectedError: asserter.ErrNetworkIdentifierNetworkMissing,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var (
+				assert = assert.New(t)
+				ctx    = context.Background()
+			)
+
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+				w.WriteHeader(http.StatusOK)
+
+				if r.URL.RequestURI() == "/network/list" {
+					fmt.Fprintln(w, types.PrettyPrintStruct(complexNetworkList))
+					return
+				}
+
+				var networkRequest *types.NetworkRequest
+				assert.NoError(json.NewDecoder(r.Body).Decode(&networkRequest))
+				basic := types.Hash(networkRequest.NetworkIdentifier) == types.Hash(basicNetwork)
+
+				switch r.URL.RequestURI() {
+				case "/network/status":
+					if basic {
+						fmt.Fprintln(w, types.PrettyPrintStruct(basicNetworkStatus))
+					} else {
+						fmt.Fprintln(w, types.PrettyPrintStruct(otherNetworkStatus))
+					}
+				case "/network/options":
+					if basic {
+						fmt.Fprintln(w, types.PrettyPrintStruct(basicNetworkOptions))
+					} else {
+						fmt.Fprintln(w, types.PrettyPrintStruct(otherNetworkOptions))
+					}
+				}
+			}))
+			defer ts.Close()
+
+			f := New(ts.URL)
+			asserters, err := f.InitializeAsserters(ctx, test.networks, "")
+			if test.expectedError != nil {
+				assert.True(checkError(err, test.expectedError))
+				if len(test.expectedMessage) > 0 {
+					assert.Contains(err.Err.Error(), test.expectedMessage)
+				}
+			} else {
+				assert.Nil(err)
+			}
+
+			// The Fetcher's Asserter is never populated
+			assert.Nil(f.Asserter)
+
+			if test.expectedNetworks == nil {
+				assert.Nil(asserters)
+				return
+			}
+
+			assert.Len(asserters, len(test.expectedNetworks))
+			for _, network := range test.expectedNetworks {
+				networkAsserter, ok := asserters[types.Hash(network)]
+				assert.True(ok)
+
+				configuration, configErr := networkAsserter.ClientConfiguration()
+				assert.NoError(configErr)
+				assert.Equal(network, configuration.NetworkIdentifier)
+			}
+		})
+	}
+}
+
+func TestInitializeAsserterWithCache(t *testing.T) {
+	var (
+		assert   = assert.New(t)
+		ctx      = context.Background()
+		requests = 0
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+
+		switch r.URL.RequestURI() {
+		case "/network/list":
+			fmt.Fprintln(w, types.PrettyPrintStruct(basicNetworkList))
+		case "/network/status":
+			fmt.Fprintln(w, types.PrettyPrintStruct(basicNetworkStatus))
+		case "/network/options":
+			fmt.Fprintln(w, types.PrettyPrintStruct(basicNetworkOptions))
+		}
+	}))
+	defer ts.Close()
+
+	dir, err := ioutil.TempDir("", "")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+	cachePath := path.Join(dir, "network_cache.json")
+
+	// No cache exists, so we fetch from the server.
+	f := New(ts.URL, WithCachedNetworkOptions(cachePath, time.Hour))
+	networkIdentifier, networkStatus, fetchErr := f.InitializeAsserter(ctx, nil, "")
+	assert.Nil(fetchErr)
+	assert.Equal(basicNetwork, networkIdentifier)
+	assert.Equal(basicNetworkStatus, networkStatus)
+	assert.Equal(3, requests)
+	assert.FileExists(cachePath)
+
+	// Cache is fresh, so no requests are made.
+	f = New(ts.URL, WithCachedNetworkOptions(cachePath, time.Hour))
+	networkIdentifier, networkStatus, fetchErr = f.InitializeAsserter(ctx, basicNetwork, "")
+	assert.Nil(fetchErr)
+	assert.Equal(basicNetwork, networkIdentifier)
+	assert.Equal(basicNetworkStatus, networkStatus)
+	assert.NotNil(f.Asserter)
+	assert.Equal(3, requests)
+
+	// Cache was written for another network.
+	f = New(ts.URL, WithCachedNetworkOptions(cachePath, time.Hour))
+	networkIdentifier, networkStatus, fetchErr = f.InitializeAsserter(ctx, otherNetwork, "")
+	assert.True(checkError(fetchErr, ErrNetworkMissing))
+	assert.Nil(networkIdentifier)
+	assert.Nil(networkStatus)
+	assert.Equal(4, requests)
+
+	// Cache is stale, so we fetch from the server.
+	f = New(ts.URL, WithCachedNetworkOptions(cachePath, 0))
+	networkIdentifier, networkStatus, fetchErr = f.InitializeAsserter(ctx, nil, "")
+	assert.Nil(fetchErr)
+	assert.Equal(basicNetwork, networkIdentifier)
+	assert.Equal(basicNetworkStatus, networkStatus)
+	assert.Equal(7, requests)
+}
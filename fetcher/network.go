@@ -177,6 +177,40 @@ func (f *Fetcher) NetworkListRetry(
 	}
 }
 
+// EnsureNetworkSupported fetches the NetworkList (with retries)
+// and returns ErrNetworkMissing (including all available networks)
+// if the provided *types.NetworkIdentifier is not supported. This
+// is useful for callers that do not use InitializeAsserter.
+func (f *Fetcher) EnsureNetworkSupported(
+	ctx context.Context,
+	network *types.NetworkIdentifier,
+) *Error {
+	if err := asserter.NetworkIdentifier(network); err != nil {
+		return &Error{
+			Err: fmt.Errorf("%w: invalid network identifier", err),
+		}
+	}
+
+	networkList, err := f.NetworkListRetry(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	exists, supportedNetworks := CheckNetworkListForNetwork(networkList, network)
+	if !exists {
+		return &Error{
+			Err: fmt.Errorf(
+				"%w: %s not in %s",
+				ErrNetworkMissing,
+				types.PrintStruct(network),
+				types.PrintStruct(supportedNetworks),
+			),
+		}
+	}
+
+	return nil
+}
+
 // NetworkOptions returns the validated response
 // from the NetworkOptions method.
 func (f *Fetcher) NetworkOptions(
@@ -0,0 +1,121 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+const (
+	// networkCacheFilePermissions specifies that only the
+	// user can read and write the network cache.
+	networkCacheFilePermissions = 0600
+)
+
+// networkCache is the on-disk representation of the
+// responses used to initialize the Asserter.
+type networkCache struct {
+	// RosettaVersion is the types.RosettaAPIVersion of the
+	// SDK that wrote the cache. If the SDK is upgraded,
+	// the cache is ignored.
+	RosettaVersion string `json:"rosetta_version"`
+
+	// Timestamp is the time the cache was written (in milliseconds).
+	Timestamp int64 `json:"timestamp"`
+
+	NetworkIdentifier *types.NetworkIdentifier      `json:"network_identifier"`
+	NetworkStatus     *types.NetworkStatusResponse  `json:"network_status"`
+	NetworkOptions    *types.NetworkOptionsResponse `json:"network_options"`
+}
+
+// loadNetworkCache attempts to load a valid *networkCache
+// for the provided *types.NetworkIdentifier. If the cache
+// is missing, stale, or was written for another network, it returns
+// an error.
+func (f *Fetcher) loadNetworkCache(
+	networkIdentifier *types.NetworkIdentifier,
+) (*networkCache, error) {
+	b, err := ioutil.ReadFile(path.Clean(f.networkCachePath))
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to read network cache", err)
+	}
+
+	var cache networkCache
+	if err := json.Unmarshal(b, &cache); err != nil {
+		return nil, fmt.Errorf("%w: unable to unmarshal network cache", err)
+	}
+
+	if cache.RosettaVersion != types.RosettaAPIVersion {
+		return nil, fmt.Errorf(
+			"network cache written for version %s but using %s",
+			cache.RosettaVersion,
+			types.RosettaAPIVersion,
+		)
+	}
+
+	cacheAge := time.Since(time.Unix(0, cache.Timestamp*int64(time.Millisecond)))
+	if cacheAge > f.networkCacheTTL {
+		return nil, fmt.Errorf("network cache is stale (age %s)", cacheAge)
+	}
+
+	if cache.NetworkIdentifier == nil ||
+		cache.NetworkStatus == nil ||
+		cache.NetworkOptions == nil {
+		return nil, fmt.Errorf("network cache is incomplete")
+	}
+
+	if networkIdentifier != nil &&
+		types.Hash(networkIdentifier) != types.Hash(cache.NetworkIdentifier) {
+		return nil, fmt.Errorf(
+			"network cache written for %s",
+			types.PrintStruct(cache.NetworkIdentifier),
+		)
+	}
+
+	return &cache, nil
+}
+
+// storeNetworkCache persists the responses used to initialize
+// the Asserter to f.networkCachePath.
+func (f *Fetcher) storeNetworkCache(
+	networkIdentifier *types.NetworkIdentifier,
+	networkStatus *types.NetworkStatusResponse,
+	networkOptions *types.NetworkOptionsResponse,
+) error {
+	cache := &networkCache{
+		RosettaVersion:    types.RosettaAPIVersion,
+		Timestamp:         time.Now().UnixNano() / int64(time.Millisecond),
+		NetworkIdentifier: networkIdentifier,
+		NetworkStatus:     networkStatus,
+		NetworkOptions:    networkOptions,
+	}
+
+	err := ioutil.WriteFile(
+		f.networkCachePath,
+		[]byte(types.PrettyPrintStruct(cache)),
+		networkCacheFilePermissions,
+	)
+	if err != nil {
+		return fmt.Errorf("%w: unable to write network cache", err)
+	}
+
+	return nil
+}
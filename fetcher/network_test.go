@@ -25,6 +25,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/coinbase/rosetta-sdk-go/asserter"
 	"github.com/coinbase/rosetta-sdk-go/types"
 )
 
@@ -279,6 +280,65 @@ func TestNetworkListRetry(t *testing.T) {
 	}
 }
 
+func TestEnsureNetworkSupported(t *testing.T) {
+	var tests = map[string]struct {
+		network *types.NetworkIdentifier
+
+		expectedError   error
+		expectedMessage string
+	}{
+		"supported network": {
+			network: basicNetwork,
+		},
+		"other supported network": {
+			network: otherNetwork,
+		},
+		"missing network": {
+			network: &types.NetworkIdentifier{
+				Blockchain: "missing",
+				Network:    "missing",
+			},
+			expectedError:   ErrNetworkMissing,
+			expectedMessage: types.PrintStruct(complexNetworkList.NetworkIdentifiers),
+		},
+		"invalid network": {
+			network: &types.NetworkIdentifier{
+				Blockchain: "missing",
+			},
+			expectedError: asserter.ErrNetworkIdentifierNetworkMissing,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var (
+				assert = assert.New(t)
+				ctx    = context.Background()
+			)
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal("POST", r.Method)
+				assert.Equal("/network/list", r.URL.RequestURI())
+
+				w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+				w.WriteHeader(http.StatusOK)
+				fmt.Fprintln(w, types.PrettyPrintStruct(complexNetworkList))
+			}))
+
+			defer ts.Close()
+
+			f := New(ts.URL)
+			err := f.EnsureNetworkSupported(ctx, test.network)
+			if test.expectedError == nil {
+				assert.Nil(err)
+				return
+			}
+
+			assert.True(checkError(err, test.expectedError))
+			assert.Contains(err.Err.Error(), test.expectedMessage)
+		})
+	}
+}
+
 func TestNetworkOptionsRetry(t *testing.T) {
 	var tests = map[string]struct {
 		network *types.NetworkIdentifier
@@ -0,0 +1,212 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/coinbase/rosetta-sdk-go/asserter"
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+// NonceManager assigns monotonically increasing nonces to
+// transactions constructed concurrently for the same account.
+// The node only reports the nonce of the next transaction it
+// expects (ignoring transactions that have been constructed but not
+// yet broadcast), so using the metadata returned by the node directly
+// would assign the same nonce to multiple transactions.
+type NonceManager struct {
+	fetcher  *Fetcher
+	network  *types.NetworkIdentifier
+	nonceKey string
+
+	nonces     map[string]*big.Int
+	noncesLock sync.Mutex
+}
+
+// NewNonceManager returns a new *NonceManager that reads and
+// overwrites the nonce stored at nonceKey in the
+// metadata returned by /construction/metadata.
+func NewNonceManager(
+	fetcher *Fetcher,
+	network *types.NetworkIdentifier,
+	nonceKey string,
+) *NonceManager {
+	return &NonceManager{
+		fetcher:  fetcher,
+		network:  network,
+		nonceKey: nonceKey,
+		nonces:   map[string]*big.Int{},
+	}
+}
+
+// parseNonce returns the *big.Int representation
+// of a nonce (a JSON number or decimal string).
+func parseNonce(value interface{}) (*big.Int, error) {
+	switch v := value.(type) {
+	case string:
+		return types.BigInt(v)
+	case float64:
+		nonce, accuracy := big.NewFloat(v).Int(nil)
+		if accuracy != big.Exact {
+			return nil, fmt.Errorf("%f is not an integer", v)
+		}
+
+		return nonce, nil
+	default:
+		return nil, fmt.Errorf("%v has unsupported type %T", value, value)
+	}
+}
+
+// formatNonce returns the representation of nonce
+// using the same type as original.
+func formatNonce(nonce *big.Int, original interface{}) interface{} {
+	if _, ok := original.(string); ok {
+		return nonce.String()
+	}
+
+	value, _ := new(big.Float).SetInt(nonce).Float64()
+	return value
+}
+
+// assignNonce returns the nonce to use for the next transaction
+// from account given the nonce expected by the node.
+func (n *NonceManager) assignNonce(
+	account *types.AccountIdentifier,
+	expected *big.Int,
+) *big.Int {
+	n.noncesLock.Lock()
+	defer n.noncesLock.Unlock()
+
+	key := types.Hash(account)
+	nonce := new(big.Int).Set(expected)
+	if last, ok := n.nonces[key]; ok && last.Cmp(nonce) >= 0 {
+		nonce = new(big.Int).Add(last, big.NewInt(1))
+	}
+	n.nonces[key] = nonce
+
+	return new(big.Int).Set(nonce)
+}
+
+// ConstructionMetadata fetches /construction/metadata (with retries)
+// and overwrites the nonce in the returned metadata with the next
+// nonce for account. The nonce returned by the node is used if it
+// is greater than any nonce already assigned to account (i.e. all
+// previously constructed transactions have been included or
+// transactions were sent by someone else). Otherwise, the last
+// assigned nonce is incremented.
+//
+// If a transaction using an assigned nonce is never broadcast, all
+// later transactions from account will be stuck behind the nonce
+// gap. Callers should invoke Reset for account when this occurs.
+func (n *NonceManager) ConstructionMetadata(
+	ctx context.Context,
+	account *types.AccountIdentifier,
+	options map[string]interface{},
+	publicKeys []*types.PublicKey,
+) (map[string]interface{}, []*types.Amount, *Error) {
+	backoffRetries := backoffRetries(
+		n.fetcher.retryElapsedTime,
+		n.fetcher.maxRetries,
+	)
+
+	for {
+		metadata, suggestedFee, err := n.fetcher.ConstructionMetadata(
+			ctx,
+			n.network,
+			options,
+			publicKeys,
+		)
+		if err == nil {
+			return n.populateNonce(account, metadata, suggestedFee)
+		}
+
+		if ctx.Err() != nil {
+			return nil, nil, &Error{
+				Err: ctx.Err(),
+			}
+		}
+
+		if is, _ := asserter.Err(err.Err); is {
+			fetcherErr := &Error{
+				Err:       fmt.Errorf("%w: /construction/metadata not attempting retry", err.Err),
+				ClientErr: err.ClientErr,
+			}
+			return nil, nil, fetcherErr
+		}
+
+		if err := tryAgain(
+			fmt.Sprintf("construction metadata %s", types.PrintStruct(options)),
+			backoffRetries,
+			err,
+		); err != nil {
+			return nil, nil, err
+		}
+	}
+}
+
+func (n *NonceManager) populateNonce(
+	account *types.AccountIdentifier,
+	metadata map[string]interface{},
+	suggestedFee []*types.Amount,
+) (map[string]interface{}, []*types.Amount, *Error) {
+	rawNonce, ok := metadata[n.nonceKey]
+	if !ok {
+		return nil, nil, &Error{
+			Err: fmt.Errorf("%w: %s", ErrNonceMissing, n.nonceKey),
+		}
+	}
+
+	expected, err := parseNonce(rawNonce)
+	if err != nil {
+		return nil, nil, &Error{
+			Err: fmt.Errorf("%w: %s %v", ErrNonceInvalid, n.nonceKey, err),
+		}
+	}
+
+	updatedMetadata := make(map[string]interface{}, len(metadata))
+	for k, v := range metadata {
+		updatedMetadata[k] = v
+	}
+	updatedMetadata[n.nonceKey] = formatNonce(n.assignNonce(account, expected), rawNonce)
+
+	return updatedMetadata, suggestedFee, nil
+}
+
+// Nonce returns the last nonce assigned to account
+// (if any nonce has been assigned).
+func (n *NonceManager) Nonce(account *types.AccountIdentifier) (*big.Int, bool) {
+	n.noncesLock.Lock()
+	defer n.noncesLock.Unlock()
+
+	nonce, ok := n.nonces[types.Hash(account)]
+	if !ok {
+		return nil, false
+	}
+
+	return new(big.Int).Set(nonce), true
+}
+
+// Reset forgets all nonces assigned to account so that the
+// next nonce assigned is the nonce returned by the node.
+func (n *NonceManager) Reset(account *types.AccountIdentifier) {
+	n.noncesLock.Lock()
+	defer n.noncesLock.Unlock()
+
+	delete(n.nonces, types.Hash(account))
+}
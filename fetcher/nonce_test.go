@@ -0,0 +1,111 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+func TestNonceManager(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		ctx    = context.Background()
+
+		account1 = &types.AccountIdentifier{Address: "addr1"}
+		account2 = &types.AccountIdentifier{Address: "addr2"}
+	)
+
+	// nodeNonce is the nonce reported by the node
+	var nodeNonce interface{} = float64(5)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("/construction/metadata", r.URL.RequestURI())
+
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		metadata := map[string]interface{}{"other": "data"}
+		if nodeNonce != nil {
+			metadata["nonce"] = nodeNonce
+		}
+		_, err := w.Write([]byte(types.PrettyPrintStruct(&types.ConstructionMetadataResponse{
+			Metadata: metadata,
+		})))
+		assert.NoError(err)
+	}))
+	defer ts.Close()
+
+	n := NewNonceManager(New(ts.URL), basicNetwork, "nonce")
+	_, ok := n.Nonce(account1)
+	assert.False(ok)
+
+	// Nonces are incremented locally while the node
+	// reports the same nonce.
+	for _, expected := range []float64{5, 6, 7} {
+		metadata, _, err := n.ConstructionMetadata(ctx, account1, nil, nil)
+		assert.Nil(err)
+		assert.Equal(map[string]interface{}{"nonce": expected, "other": "data"}, metadata)
+	}
+
+	nonce, ok := n.Nonce(account1)
+	assert.True(ok)
+	assert.Equal(big.NewInt(7), nonce)
+
+	// Each account is tracked separately
+	metadata, _, err := n.ConstructionMetadata(ctx, account2, nil, nil)
+	assert.Nil(err)
+	assert.Equal(float64(5), metadata["nonce"])
+
+	// A higher nonce from the node is used (the
+	// representation of the nonce is preserved)
+	nodeNonce = "10"
+	metadata, _, err = n.ConstructionMetadata(ctx, account1, nil, nil)
+	assert.Nil(err)
+	assert.Equal("10", metadata["nonce"])
+
+	// A lower nonce from the node is ignored until
+	// the account is reset
+	nodeNonce = "8"
+	metadata, _, err = n.ConstructionMetadata(ctx, account1, nil, nil)
+	assert.Nil(err)
+	assert.Equal("11", metadata["nonce"])
+
+	n.Reset(account1)
+	metadata, _, err = n.ConstructionMetadata(ctx, account1, nil, nil)
+	assert.Nil(err)
+	assert.Equal("8", metadata["nonce"])
+
+	// Invalid and missing nonces
+	nodeNonce = "hello"
+	metadata, _, err = n.ConstructionMetadata(ctx, account1, nil, nil)
+	assert.Nil(metadata)
+	assert.True(errors.Is(err.Err, ErrNonceInvalid))
+
+	nodeNonce = nil
+	metadata, _, err = n.ConstructionMetadata(ctx, account1, nil, nil)
+	assert.Nil(metadata)
+	assert.True(errors.Is(err.Err, ErrNonceMissing))
+
+	nonce, ok = n.Nonce(account1)
+	assert.True(ok)
+	assert.Equal(big.NewInt(8), nonce)
+}
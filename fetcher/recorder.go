@@ -0,0 +1,201 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetcher
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+
+	"github.com/coinbase/rosetta-sdk-go/client"
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+const (
+	// recordingFilePermissions specifies that only the
+	// user can read and write recordings.
+	recordingFilePermissions = 0600
+
+	// replayServerAddress is the placeholder server address
+	// used by fetchers returned by NewReplayFetcher.
+	replayServerAddress = "http://replay"
+)
+
+// recording is the on-disk representation of
+// a response to a request.
+type recording struct {
+	Method     string      `json:"method"`
+	Path       string      `json:"path"`
+	Request    string      `json:"request"`
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Response   string      `json:"response"`
+}
+
+// readRequestBody reads the body of req and replaces it
+// so that req can still be sent.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return []byte{}, nil
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	req.Body.Close()
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+// recordingPath returns the path of the recording for a request,
+// keyed by a hash of its method, path, and body.
+func recordingPath(dir string, req *http.Request, body []byte) string {
+	key := types.Hash(fmt.Sprintf("%s %s %s", req.Method, req.URL.Path, body))
+	return filepath.Join(dir, key+".json")
+}
+
+// recordingTransport is an http.RoundTripper that
+// writes each request and its response to dir.
+type recordingTransport struct {
+	transport http.RoundTripper
+	dir       string
+}
+
+func newRecordingTransport(transport http.RoundTripper, dir string) *recordingTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &recordingTransport{transport: transport, dir: dir}
+}
+
+// RoundTrip executes a single HTTP transaction and
+// records the response.
+func (r *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to read request body", err)
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to read response body", err)
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(responseBody))
+
+	rec := &recording{
+		Method:     req.Method,
+		Path:       req.URL.Path,
+		Request:    string(requestBody),
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Response:   string(responseBody),
+	}
+	err = ioutil.WriteFile(
+		recordingPath(r.dir, req, requestBody),
+		[]byte(types.PrettyPrintStruct(rec)),
+		recordingFilePermissions,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to write recording", err)
+	}
+
+	return resp, nil
+}
+
+// replayTransport is an http.RoundTripper that serves
+// responses written by a recordingTransport.
+type replayTransport struct {
+	dir string
+}
+
+// RoundTrip loads the recorded response to req. If there
+// is no recording, ErrRecordingNotFound is returned.
+func (r *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to read request body", err)
+	}
+
+	b, err := ioutil.ReadFile(recordingPath(r.dir, req, requestBody))
+	if err != nil {
+		return nil, fmt.Errorf(
+			"%w: %s %s: %v",
+			ErrRecordingNotFound,
+			req.Method,
+			req.URL.Path,
+			err,
+		)
+	}
+
+	var rec recording
+	if err := json.Unmarshal(b, &rec); err != nil {
+		return nil, fmt.Errorf("%w: unable to unmarshal recording", err)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rec.StatusCode, http.StatusText(rec.StatusCode)),
+		StatusCode:    rec.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        rec.Header,
+		Body:          ioutil.NopCloser(bytes.NewReader([]byte(rec.Response))),
+		ContentLength: int64(len(rec.Response)),
+		Request:       req,
+	}, nil
+}
+
+// NewReplayFetcher constructs a new Fetcher that serves all
+// requests from responses recorded in dir by a Fetcher
+// created with WithRecorder. No network requests are made.
+//
+// Requests are matched by method, path, and body, so the
+// same requests (including network identifiers) must be made
+// as when recording. Retries are disabled by default because
+// a missing recording will never succeed (this can be
+// overridden by providing WithMaxRetries).
+func NewReplayFetcher(dir string, options ...Option) *Fetcher {
+	httpClient := &http.Client{
+		Transport: &replayTransport{dir: dir},
+	}
+	clientCfg := client.NewConfiguration(
+		replayServerAddress,
+		DefaultUserAgent,
+		httpClient,
+	)
+
+	return New(
+		replayServerAddress,
+		append(
+			[]Option{
+				WithClient(client.NewAPIClient(clientCfg)),
+				WithMaxRetries(0),
+			},
+			options...,
+		)...,
+	)
+}
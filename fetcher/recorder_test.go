@@ -0,0 +1,122 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetcher
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/coinbase/rosetta-sdk-go/client"
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+func TestRecorder(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		ctx    = context.Background()
+
+		clientErr = &types.Error{
+			Code:    12,
+			Message: "unavailable",
+		}
+	)
+
+	dir, err := ioutil.TempDir("", "")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	requests := map[string]int{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.RequestURI()]++
+
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		switch r.URL.RequestURI() {
+		case "/network/list":
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(types.PrettyPrintStruct(basicNetworkList)))
+			assert.NoError(err)
+		case "/network/status":
+			w.WriteHeader(http.StatusInternalServerError)
+			_, err := w.Write([]byte(types.PrettyPrintStruct(clientErr)))
+			assert.NoError(err)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	recorder := New(ts.URL, WithRecorder(dir), WithMaxRetries(0))
+	networkList, fetchErr := recorder.NetworkList(ctx, nil)
+	assert.Nil(fetchErr)
+	assert.Equal(basicNetworkList, networkList)
+
+	networkStatus, fetchErr := recorder.NetworkStatus(ctx, basicNetwork, nil)
+	assert.Nil(networkStatus)
+	assert.Equal(clientErr, fetchErr.ClientErr)
+	ts.Close()
+
+	// Recorded responses are served without a server
+	replay := NewReplayFetcher(dir)
+	networkList, fetchErr = replay.NetworkList(ctx, nil)
+	assert.Nil(fetchErr)
+	assert.Equal(basicNetworkList, networkList)
+
+	networkStatus, fetchErr = replay.NetworkStatusRetry(ctx, basicNetwork, nil)
+	assert.Nil(networkStatus)
+	assert.Equal(clientErr, fetchErr.ClientErr)
+
+	// Requests are matched by body
+	networkStatus, fetchErr = replay.NetworkStatus(
+		ctx,
+		basicNetwork,
+		map[string]interface{}{"hello": "world"},
+	)
+	assert.Nil(networkStatus)
+	assert.Contains(fetchErr.Err.Error(), ErrRecordingNotFound.Error())
+
+	assert.Equal(map[string]int{"/network/list": 1, "/network/status": 1}, requests)
+}
+
+func TestRecorderSharedClient(t *testing.T) {
+	// Fetchers created from the same client
+	// must not wrap the transport of that client.
+	var assert = assert.New(t)
+	transport := &http.Transport{}
+	httpClient := &http.Client{Transport: transport}
+	apiClient := client.NewAPIClient(
+		client.NewConfiguration(
+			"https://serveraddress",
+			DefaultUserAgent,
+			httpClient,
+		),
+	)
+
+	recorder := New("https://serveraddress", WithClient(apiClient), WithRecorder("dir1"))
+	recorder2 := New("https://serveraddress", WithClient(apiClient), WithRecorder("dir2"))
+	assert.Same(httpClient, apiClient.GetConfig().HTTPClient)
+	assert.Same(transport, httpClient.Transport)
+
+	for dir, f := range map[string]*Fetcher{"dir1": recorder, "dir2": recorder2} {
+		wrapped, ok := f.rosettaClient.GetConfig().HTTPClient.Transport.(*recordingTransport)
+		assert.True(ok)
+		assert.Same(transport, wrapped.transport)
+		assert.Equal(dir, wrapped.dir)
+	}
+}
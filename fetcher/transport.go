@@ -0,0 +1,107 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetcher
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/DataDog/zstd"
+)
+
+const (
+	contentEncodingHeader = "Content-Encoding"
+	acceptEncodingHeader  = "Accept-Encoding"
+
+	gzipEncoding = "gzip"
+	zstdEncoding = "zstd"
+)
+
+// compressionTransport is an http.RoundTripper that
+// advertises support for zstd (and gzip) compressed
+// responses and transparently decompresses them.
+//
+// Advertising any Accept-Encoding manually disables
+// the automatic gzip handling of http.Transport, so
+// gzip responses must be decompressed here as well.
+type compressionTransport struct {
+	transport http.RoundTripper
+}
+
+func newCompressionTransport(transport http.RoundTripper) *compressionTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &compressionTransport{transport: transport}
+}
+
+// decompressedBody closes both the decompressor and
+// the underlying response body.
+type decompressedBody struct {
+	io.ReadCloser
+	body io.Closer
+}
+
+func (d *decompressedBody) Close() error {
+	err := d.ReadCloser.Close()
+	if bodyErr := d.body.Close(); err == nil {
+		err = bodyErr
+	}
+
+	return err
+}
+
+// RoundTrip executes a single HTTP transaction and
+// decompresses the response body, if necessary.
+func (c *compressionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// If the caller has already specified an encoding,
+	// we leave the response untouched.
+	if req.Header.Get(acceptEncodingHeader) != "" {
+		return c.transport.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set(acceptEncodingHeader, zstdEncoding+", "+gzipEncoding)
+
+	resp, err := c.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var reader io.ReadCloser
+	switch strings.ToLower(resp.Header.Get(contentEncodingHeader)) {
+	case zstdEncoding:
+		reader = zstd.NewReader(resp.Body)
+	case gzipEncoding:
+		reader, err = gzip.NewReader(resp.Body)
+		if err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+	default:
+		return resp, nil
+	}
+
+	resp.Body = &decompressedBody{ReadCloser: reader, body: resp.Body}
+	resp.Header.Del(contentEncodingHeader)
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+
+	return resp, nil
+}
@@ -15,10 +15,14 @@
 package fetcher
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log"
+	"net/http"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -44,6 +48,73 @@ const (
 	serverClosedIdleConnection = "server closed idle connection"
 )
 
+// statusCodeRegex matches the HTTP status code included
+// in errors returned by the client for unexpected responses.
+var statusCodeRegex = regexp.MustCompile(`code: (\d+)`)
+
+// bodyPrefix precedes the response body in errors
+// returned by the client for unexpected responses.
+const bodyPrefix = "body: "
+
+// RetryClassifier determines if a failed request should be
+// retried. statusCode is the HTTP status code of the response
+// (0 if no response was received) and rosettaError is populated
+// if the Rosetta server returned a *types.Error.
+type RetryClassifier func(err error, statusCode int, rosettaError *types.Error) bool
+
+// statusCode returns the HTTP status code associated with
+// a failed request. If no status code can be determined
+// (i.e. the request was never completed), 0 is returned.
+func statusCode(rosettaErr *types.Error, err error) int {
+	if rosettaErr != nil {
+		return http.StatusInternalServerError
+	}
+
+	if err == nil {
+		return 0
+	}
+
+	matches := statusCodeRegex.FindStringSubmatch(err.Error())
+	if len(matches) != 2 {
+		return 0
+	}
+
+	code, convErr := strconv.Atoi(matches[1])
+	if convErr != nil {
+		return 0
+	}
+
+	return code
+}
+
+// errorBody attempts to parse a *types.Error from the response
+// body included in errors returned by the client for non-500
+// responses. If the body is not a valid *types.Error, nil
+// is returned.
+func errorBody(err error) *types.Error {
+	if err == nil {
+		return nil
+	}
+
+	message := err.Error()
+	bodyStart := strings.Index(message, bodyPrefix)
+	if bodyStart == -1 {
+		return nil
+	}
+
+	var rosettaErr types.Error
+	body := message[bodyStart+len(bodyPrefix):]
+	if err := json.Unmarshal([]byte(body), &rosettaErr); err != nil {
+		return nil
+	}
+
+	if len(rosettaErr.Message) == 0 {
+		return nil
+	}
+
+	return &rosettaErr
+}
+
 // Backoff wraps backoff.BackOff so we can
 // access the retry count (which is private
 // on backoff.BackOff).
@@ -209,6 +209,20 @@ func (k *KeyPair) IsValid() error {
 	return nil
 }
 
+// ToPublicKey returns a copy of the *types.PublicKey
+// of the KeyPair (modifying the returned *types.PublicKey
+// does not modify the KeyPair).
+func (k *KeyPair) ToPublicKey() *types.PublicKey {
+	if k.PublicKey == nil {
+		return nil
+	}
+
+	return &types.PublicKey{
+		Bytes:     append([]byte{}, k.PublicKey.Bytes...),
+		CurveType: k.PublicKey.CurveType,
+	}
+}
+
 // Signer returns the constructs a Signer
 // for the KeyPair.
 func (k *KeyPair) Signer() (Signer, error) {
@@ -74,6 +74,41 @@ func TestGenerateKeypairEdwards25519(t *testing.T) {
 	assert.Len(t, keypair.PrivateKey, PrivKeyBytesLen)
 }
 
+func TestToPublicKey(t *testing.T) {
+	network := &types.NetworkIdentifier{
+		Blockchain: "hello",
+		Network:    "world",
+	}
+
+	curves := []types.CurveType{
+		types.Secp256k1,
+		types.Secp256r1,
+		types.Edwards25519,
+	}
+	for _, curve := range curves {
+		t.Run(string(curve), func(t *testing.T) {
+			keypair, err := GenerateKeypair(curve)
+			assert.NoError(t, err)
+
+			publicKey := keypair.ToPublicKey()
+			assert.Equal(t, keypair.PublicKey, publicKey)
+			assert.NoError(t, asserter.PublicKey(publicKey))
+
+			request := types.NewConstructionDeriveRequest(network, publicKey)
+			assert.Equal(t, network, request.NetworkIdentifier)
+			assert.Equal(t, publicKey, request.PublicKey)
+
+			// Modifying the returned *types.PublicKey does
+			// not modify the KeyPair.
+			publicKey.Bytes[0]++
+			assert.NotEqual(t, keypair.PublicKey, publicKey)
+			assert.NoError(t, keypair.IsValid())
+		})
+	}
+
+	assert.Nil(t, (&KeyPair{}).ToPublicKey())
+}
+
 func mockKeyPair(privKey []byte, curveType types.CurveType) *KeyPair {
 	keypair, _ := GenerateKeypair(curveType)
 	keypair.PrivateKey = privKey
@@ -30,6 +30,27 @@ func (_m *Database) Close(_a0 context.Context) error {
 	return r0
 }
 
+// DeletePrefix provides a mock function with given fields: ctx, prefix
+func (_m *Database) DeletePrefix(ctx context.Context, prefix []byte) (int, error) {
+	ret := _m.Called(ctx, prefix)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) int); ok {
+		r0 = rf(ctx, prefix)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = rf(ctx, prefix)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Encoder provides a mock function with given fields:
 func (_m *Database) Encoder() *encoder.Encoder {
 	ret := _m.Called()
@@ -62,6 +83,20 @@ func (_m *Database) ReadTransaction(_a0 context.Context) database.Transaction {
 	return r0
 }
 
+// RunInTransaction provides a mock function with given fields: ctx, identifier, priority, fn
+func (_m *Database) RunInTransaction(ctx context.Context, identifier string, priority bool, fn func(database.Transaction) error) error {
+	ret := _m.Called(ctx, identifier, priority, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, func(database.Transaction) error) error); ok {
+		r0 = rf(ctx, identifier, priority, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Transaction provides a mock function with given fields: _a0
 func (_m *Database) Transaction(_a0 context.Context) database.Transaction {
 	ret := _m.Called(_a0)
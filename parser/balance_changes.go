@@ -137,3 +137,49 @@ func (p *Parser) BalanceChanges(
 
 	return allChanges, nil
 }
+
+// IsBalanced returns a map (keyed by types.Hash(currency))
+// indicating if the amounts of all successful operations in a
+// transaction net to zero for each *types.Currency. Operations
+// without an amount are ignored.
+//
+// On chains that conserve value, every currency in a transaction
+// should be balanced (fees usually need to be accounted for
+// with a separate operation).
+func (p *Parser) IsBalanced(tx *types.Transaction) (map[string]bool, error) {
+	sums := map[string]string{}
+	for _, op := range tx.Operations {
+		if op.Amount == nil {
+			continue
+		}
+
+		successful, err := p.Asserter.OperationSuccessful(op)
+		if err != nil {
+			// Should only occur if responses not validated
+			return nil, err
+		}
+
+		if !successful {
+			continue
+		}
+
+		key := types.Hash(op.Amount.Currency)
+		sum, ok := sums[key]
+		if !ok {
+			sum = "0"
+		}
+
+		newSum, err := types.AddValues(sum, op.Amount.Value)
+		if err != nil {
+			return nil, err
+		}
+		sums[key] = newSum
+	}
+
+	balanced := make(map[string]bool, len(sums))
+	for key, sum := range sums {
+		balanced[key] = sum == "0"
+	}
+
+	return balanced, nil
+}
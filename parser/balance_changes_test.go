@@ -16,6 +16,7 @@ package parser
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -279,6 +280,152 @@ func TestBalanceChanges(t *testing.T) {
 	}
 }
 
+func TestIsBalanced(t *testing.T) {
+	var (
+		btc = &types.Currency{
+			Symbol:   "BTC",
+			Decimals: 8,
+		}
+
+		eth = &types.Currency{
+			Symbol:   "ETH",
+			Decimals: 18,
+		}
+
+		operation = func(
+			index int64,
+			status string,
+			value string,
+			currency *types.Currency,
+		) *types.Operation {
+			return &types.Operation{
+				OperationIdentifier: &types.OperationIdentifier{
+					Index: index,
+				},
+				Type:   "Transfer",
+				Status: types.String(status),
+				Account: &types.AccountIdentifier{
+					Address: fmt.Sprintf("addr%d", index),
+				},
+				Amount: &types.Amount{
+					Value:    value,
+					Currency: currency,
+				},
+			}
+		}
+
+		allowedStatus = []*types.OperationStatus{
+			{
+				Status:     "Success",
+				Successful: true,
+			},
+			{
+				Status:     "Failure",
+				Successful: false,
+			},
+		}
+	)
+
+	var tests = map[string]struct {
+		operations []*types.Operation
+
+		balanced map[string]bool
+		err      bool
+	}{
+		"no operations": {
+			operations: []*types.Operation{},
+			balanced:   map[string]bool{},
+		},
+		"balanced": {
+			operations: []*types.Operation{
+				operation(0, "Success", "-100", btc),
+				operation(1, "Success", "100", btc),
+			},
+			balanced: map[string]bool{
+				types.Hash(btc): true,
+			},
+		},
+		"unbalanced": {
+			operations: []*types.Operation{
+				operation(0, "Success", "-100", btc),
+				operation(1, "Success", "90", btc),
+			},
+			balanced: map[string]bool{
+				types.Hash(btc): false,
+			},
+		},
+		"unsuccessful operations are ignored": {
+			operations: []*types.Operation{
+				operation(0, "Success", "-100", btc),
+				operation(1, "Success", "100", btc),
+				operation(2, "Failure", "100", btc),
+			},
+			balanced: map[string]bool{
+				types.Hash(btc): true,
+			},
+		},
+		"operations without amount are ignored": {
+			operations: []*types.Operation{
+				{
+					OperationIdentifier: &types.OperationIdentifier{
+						Index: 0,
+					},
+					Type:   "Transfer",
+					Status: types.String("Success"),
+				},
+			},
+			balanced: map[string]bool{},
+		},
+		"multiple currencies": {
+			operations: []*types.Operation{
+				operation(0, "Success", "-100", btc),
+				operation(1, "Success", "-10", eth),
+				operation(2, "Success", "100", btc),
+				operation(3, "Success", "5", eth),
+			},
+			balanced: map[string]bool{
+				types.Hash(btc): true,
+				types.Hash(eth): false,
+			},
+		},
+		"invalid status": {
+			operations: []*types.Operation{
+				operation(0, "Pending", "-100", btc),
+			},
+			err: true,
+		},
+		"invalid amount": {
+			operations: []*types.Operation{
+				operation(0, "Success", "blah", btc),
+			},
+			err: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			asserter, err := simpleAsserterConfiguration(allowedStatus)
+			assert.NoError(t, err)
+			assert.NotNil(t, asserter)
+
+			parser := New(asserter, nil, nil)
+			balanced, err := parser.IsBalanced(&types.Transaction{
+				TransactionIdentifier: &types.TransactionIdentifier{
+					Hash: "tx",
+				},
+				Operations: test.operations,
+			})
+			if test.err {
+				assert.Error(t, err)
+				assert.Nil(t, balanced)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.balanced, balanced)
+			}
+		})
+	}
+}
+
 func simpleTransactionFactory(
 	hash string,
 	address string,
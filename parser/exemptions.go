@@ -28,7 +28,7 @@ func (p *Parser) FindExemptions(
 ) []*types.BalanceExemption {
 	matches := []*types.BalanceExemption{}
 	for _, exemption := range p.BalanceExemptions {
-		if exemption.Currency != nil && types.Hash(currency) != types.Hash(exemption.Currency) {
+		if exemption.Currency != nil && !types.CurrenciesEqual(currency, exemption.Currency) {
 			continue
 		}
 
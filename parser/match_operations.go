@@ -270,7 +270,7 @@ func amountMatch(req *AmountDescription, amount *types.Amount) error {
 		return nil
 	}
 
-	if amount.Currency == nil || types.Hash(amount.Currency) != types.Hash(req.Currency) {
+	if !types.CurrenciesEqual(amount.Currency, req.Currency) {
 		return fmt.Errorf(
 			"%w: expected %+v but got %+v",
 			ErrAmountMatchUnexpectedCurrency,
@@ -110,3 +110,14 @@ func WithBacklogSize(size int) Option {
 		r.backlogSize = size
 	}
 }
+
+// WithCoverageTracking records every *types.AccountCurrency
+// seen in balance changes and whether it was ever reconciled
+// successfully (see Coverage). Tracking requires memory
+// proportional to the number of accounts seen, so it is
+// disabled by default.
+func WithCoverageTracking() Option {
+	return func(r *Reconciler) {
+		r.coverage = map[string]*coverageEntry{}
+	}
+}
@@ -38,6 +38,11 @@ var (
 	ErrBlockExistsFailed        = errors.New("unable to check if block exists")
 	ErrGetComputedBalanceFailed = errors.New("unable to get computed balance")
 	ErrLiveBalanceLookupFailed  = errors.New("unable to lookup live balance")
+
+	// ErrCoverageTrackingDisabled is returned when coverage
+	// is requested from a Reconciler that was not created
+	// with WithCoverageTracking.
+	ErrCoverageTrackingDisabled = errors.New("coverage tracking is not enabled")
 )
 
 // Err takes an error as an argument and returns
@@ -50,6 +55,7 @@ func Err(err error) bool {
 		ErrBlockExistsFailed,
 		ErrGetComputedBalanceFailed,
 		ErrLiveBalanceLookupFailed,
+		ErrCoverageTrackingDisabled,
 	}
 
 	return utils.FindError(reconcilerErrors, err)
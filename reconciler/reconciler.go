@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"sort"
 	"time"
 
 	"golang.org/x/sync/errgroup"
@@ -214,6 +215,7 @@ func (r *Reconciler) queueChanges(
 			Account:  change.Account,
 			Currency: change.Currency,
 		}
+		r.trackCoverage(acctCurrency, false)
 
 		r.inactiveQueueMutex.Lock(true)
 		err := r.inactiveAccountQueue(false, acctCurrency, block, true)
@@ -564,6 +566,7 @@ func (r *Reconciler) accountReconciliation(
 			)
 		}
 
+		r.trackCoverage(accountCurrency, true)
 		return r.handler.ReconciliationSucceeded(
 			ctx,
 			reconciliationType,
@@ -577,6 +580,67 @@ func (r *Reconciler) accountReconciliation(
 	return ctx.Err()
 }
 
+// trackCoverage records that accountCurrency was seen
+// (and reconciled, if reconciled is true) when coverage
+// tracking is enabled.
+func (r *Reconciler) trackCoverage(
+	accountCurrency *types.AccountCurrency,
+	reconciled bool,
+) {
+	if r.coverage == nil {
+		return
+	}
+
+	r.coverageMutex.Lock()
+	defer r.coverageMutex.Unlock()
+
+	key := types.Hash(accountCurrency)
+	entry, ok := r.coverage[key]
+	if !ok {
+		entry = &coverageEntry{accountCurrency: accountCurrency}
+		r.coverage[key] = entry
+	}
+
+	if reconciled {
+		entry.reconciled = true
+	}
+}
+
+// Coverage returns a *CoverageReport of all
+// *types.AccountCurrency seen so far. NeverReconciled
+// is sorted by the hash of each *types.AccountCurrency
+// so that the report is deterministic.
+func (r *Reconciler) Coverage() (*CoverageReport, error) {
+	if r.coverage == nil {
+		return nil, ErrCoverageTrackingDisabled
+	}
+
+	r.coverageMutex.Lock()
+	defer r.coverageMutex.Unlock()
+
+	keys := make([]string, 0, len(r.coverage))
+	for key := range r.coverage {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	report := &CoverageReport{
+		Seen:            len(r.coverage),
+		NeverReconciled: []*types.AccountCurrency{},
+	}
+	for _, key := range keys {
+		entry := r.coverage[key]
+		if entry.reconciled {
+			report.Reconciled++
+			continue
+		}
+
+		report.NeverReconciled = append(report.NeverReconciled, entry.accountCurrency)
+	}
+
+	return report, nil
+}
+
 func (r *Reconciler) inactiveAccountQueue(
 	inactive bool,
 	accountCurrency *types.AccountCurrency,
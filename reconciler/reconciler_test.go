@@ -587,6 +587,105 @@ func TestAccountReconciliation(t *testing.T) {
 	})
 }
 
+func TestCoverage(t *testing.T) {
+	var (
+		account1 = &types.AccountIdentifier{
+			Address: "addr1",
+		}
+
+		account2 = &types.AccountIdentifier{
+			Address: "addr2",
+		}
+
+		currency = &types.Currency{
+			Symbol:   "curr1",
+			Decimals: 4,
+		}
+
+		block = &types.BlockIdentifier{
+			Hash:  "block0",
+			Index: 0,
+		}
+
+		ctx = context.Background()
+
+		mockHelper  = &mocks.Helper{}
+		mockHandler = &mocks.Handler{}
+	)
+
+	_, err := New(mockHelper, mockHandler, nil).Coverage()
+	assert.True(t, errors.Is(err, ErrCoverageTrackingDisabled))
+
+	reconciler := New(
+		mockHelper,
+		mockHandler,
+		nil,
+		WithCoverageTracking(),
+	)
+
+	report, err := reconciler.Coverage()
+	assert.NoError(t, err)
+	assert.Equal(t, &CoverageReport{NeverReconciled: []*types.AccountCurrency{}}, report)
+
+	assert.NoError(t, reconciler.queueChanges(ctx, block, []*parser.BalanceChange{
+		{
+			Account:    account1,
+			Currency:   currency,
+			Block:      block,
+			Difference: "100",
+		},
+		{
+			Account:    account2,
+			Currency:   currency,
+			Block:      block,
+			Difference: "-100",
+		},
+	}))
+
+	mtxn := &mockDatabase.Transaction{}
+	mtxn.On("Discard", ctx).Once()
+	mockHelper.On("DatabaseTransaction", ctx).Return(mtxn).Once()
+	mockHelper.On("CurrentBlock", ctx, mtxn).Return(block, nil).Once()
+	mockHelper.On("CanonicalBlock", ctx, mtxn, block).Return(true, nil).Once()
+	mockHelper.On(
+		"ComputedBalance",
+		ctx,
+		mtxn,
+		account1,
+		currency,
+		block.Index,
+	).Return(
+		&types.Amount{Value: "100", Currency: currency},
+		nil,
+	).Once()
+	mockHandler.On(
+		"ReconciliationSucceeded",
+		ctx,
+		ActiveReconciliation,
+		account1,
+		currency,
+		"100",
+		block,
+	).Return(
+		nil,
+	).Once()
+	err = reconciler.accountReconciliation(ctx, account1, currency, "100", block, false)
+	assert.NoError(t, err)
+
+	report, err = reconciler.Coverage()
+	assert.NoError(t, err)
+	assert.Equal(t, &CoverageReport{
+		Seen:       2,
+		Reconciled: 1,
+		NeverReconciled: []*types.AccountCurrency{
+			{Account: account2, Currency: currency},
+		},
+	}, report)
+
+	mockHelper.AssertExpectations(t)
+	mockHandler.AssertExpectations(t)
+}
+
 func assertContainsAllAccounts(t *testing.T, m map[string]struct{}, a []*types.AccountCurrency) {
 	for _, account := range a {
 		_, exists := m[types.Hash(account)]
@@ -312,4 +312,33 @@ type Reconciler struct {
 	// blocks asynchronously so that we don't slow down the sync
 	// loop.
 	processQueue chan *blockRequest
+
+	// coverage tracks which *types.AccountCurrency have been
+	// seen in balance changes and whether each has been
+	// successfully reconciled. If nil, coverage is not tracked.
+	coverage      map[string]*coverageEntry
+	coverageMutex sync.Mutex
+}
+
+// coverageEntry is the tracked reconciliation status
+// of a single *types.AccountCurrency.
+type coverageEntry struct {
+	accountCurrency *types.AccountCurrency
+	reconciled      bool
+}
+
+// CoverageReport summarizes which *types.AccountCurrency
+// seen in balance changes have been successfully reconciled.
+type CoverageReport struct {
+	// Seen is the number of unique *types.AccountCurrency
+	// observed in balance changes.
+	Seen int `json:"seen"`
+
+	// Reconciled is the number of seen *types.AccountCurrency
+	// that have been successfully reconciled at least once.
+	Reconciled int `json:"reconciled"`
+
+	// NeverReconciled contains all seen *types.AccountCurrency
+	// that have not yet been successfully reconciled.
+	NeverReconciled []*types.AccountCurrency `json:"never_reconciled"`
 }
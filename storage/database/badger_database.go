@@ -26,8 +26,10 @@ import (
 	"path"
 	"path/filepath"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/cenkalti/backoff"
 	"github.com/dgraph-io/badger/v2"
 	"github.com/dgraph-io/badger/v2/options"
 
@@ -78,6 +80,19 @@ const (
 	defaultGCInterval     = 1 * time.Minute
 	defualtGCDiscardRatio = 0.1
 	defaultGCSleep        = 10 * time.Second
+
+	// DefaultMaxConflictRetries is the number of times
+	// RunInTransaction will retry a transaction that
+	// failed to commit because of a conflict.
+	DefaultMaxConflictRetries = 5
+
+	// deletePrefixBatchSize is the maximum number of keys
+	// DeletePrefix loads into memory at once.
+	deletePrefixBatchSize = 10000
+
+	// defaultConflictBackoff is the initial interval to wait
+	// before retrying a conflicting transaction.
+	defaultConflictBackoff = 10 * time.Millisecond
 )
 
 // BadgerDatabase is a wrapper around Badger DB
@@ -94,6 +109,16 @@ type BadgerDatabase struct {
 	writer       *utils.MutexMap
 	writerShards int
 
+	maxConflictRetries int
+
+	// If gcDeleteThreshold is set, value log GC is
+	// triggered (via gcTrigger) once pendingDeletes
+	// reaches it. gcRuns counts all GC attempts.
+	gcDeleteThreshold int64
+	pendingDeletes    int64
+	gcTrigger         chan struct{}
+	gcRuns            int64
+
 	// Track the closed status to ensure we exit garbage
 	// collection when the db closes.
 	closed chan struct{}
@@ -208,9 +233,12 @@ func NewBadgerDatabase(
 	b := &BadgerDatabase{
 		badgerOptions: DefaultBadgerOptions(dir),
 		closed:        make(chan struct{}),
+		gcTrigger:     make(chan struct{}, 1),
 		pool:          encoder.NewBufferPool(),
 		compress:      true,
 		writerShards:  utils.DefaultShards,
+
+		maxConflictRetries: DefaultMaxConflictRetries,
 	}
 	for _, opt := range storageOptions {
 		opt(b)
@@ -253,7 +281,8 @@ func (b *BadgerDatabase) Close(ctx context.Context) error {
 }
 
 // periodicGC attempts to reclaim storage every
-// defaultGCInterval.
+// defaultGCInterval (and whenever the number of deleted
+// keys reaches the threshold provided in WithDeleteGCThreshold).
 //
 // Inspired by:
 // https://github.com/ipfs/go-ds-badger/blob/a69f1020ba3954680900097e0c9d0181b88930ad/datastore.go#L173-L199
@@ -272,31 +301,64 @@ func (b *BadgerDatabase) periodicGC(ctx context.Context) {
 			return
 		case <-ctx.Done():
 			return
+		case <-b.gcTrigger:
+			// We don't reset the timer here so that GC triggered
+			// by deletions doesn't delay periodic GC.
+			b.runGC()
 		case <-gcTimeout.C:
-			start := time.Now()
-			err := b.db.RunValueLogGC(defualtGCDiscardRatio)
-			switch err {
-			case badger.ErrNoRewrite, badger.ErrRejected:
-				// No rewrite means we've fully garbage collected.
-				// Rejected means someone else is running a GC
-				// or we're closing.
-				gcTimeout.Reset(defaultGCInterval)
-			case nil:
-				// Nil error means that we've successfully garbage
-				// collected. We should sleep instead of waiting
-				// the full GC collection interval to see if there
-				// is anything else to collect.
-				log.Printf("successful value log garbage collection (%s)", time.Since(start))
-				gcTimeout.Reset(defaultGCSleep)
-			default:
-				// Not much we can do on a random error but log it and continue.
-				log.Printf("error during a GC cycle: %s\n", err.Error())
-				gcTimeout.Reset(defaultGCInterval)
-			}
+			gcTimeout.Reset(b.runGC())
 		}
 	}
 }
 
+// runGC runs a single value log garbage collection
+// and returns how long to wait before running it again.
+func (b *BadgerDatabase) runGC() time.Duration {
+	atomic.AddInt64(&b.gcRuns, 1)
+
+	start := time.Now()
+	err := b.db.RunValueLogGC(defualtGCDiscardRatio)
+	switch err {
+	case badger.ErrNoRewrite, badger.ErrRejected:
+		// No rewrite means we've fully garbage collected.
+		// Rejected means someone else is running a GC
+		// or we're closing.
+		return defaultGCInterval
+	case nil:
+		// Nil error means that we've successfully garbage
+		// collected. We should sleep instead of waiting
+		// the full GC collection interval to see if there
+		// is anything else to collect.
+		log.Printf("successful value log garbage collection (%s)", time.Since(start))
+		return defaultGCSleep
+	default:
+		// Not much we can do on a random error but log it and continue.
+		log.Printf("error during a GC cycle: %s\n", err.Error())
+		return defaultGCInterval
+	}
+}
+
+// recordDeletes adds to the number of keys deleted since
+// GC was last triggered by deletions and triggers GC
+// if the threshold provided in WithDeleteGCThreshold
+// is reached.
+func (b *BadgerDatabase) recordDeletes(deletes int64) {
+	if b.gcDeleteThreshold <= 0 || deletes == 0 {
+		return
+	}
+
+	if atomic.AddInt64(&b.pendingDeletes, deletes) < b.gcDeleteThreshold {
+		return
+	}
+
+	atomic.StoreInt64(&b.pendingDeletes, 0)
+	select {
+	case b.gcTrigger <- struct{}{}:
+	default:
+		// GC has already been triggered
+	}
+}
+
 // Encoder returns the BadgerDatabase encoder.
 func (b *BadgerDatabase) Encoder() *encoder.Encoder {
 	return b.encoder
@@ -313,6 +375,10 @@ type BadgerTransaction struct {
 	holdGlobal bool
 	identifier string
 
+	// deletes is the number of keys deleted
+	// in the transaction.
+	deletes int64
+
 	// We MUST wait to reclaim any memory until after
 	// the transaction is committed or discarded.
 	// Source: https://godoc.org/github.com/dgraph-io/badger#Txn.Set
@@ -366,6 +432,145 @@ func (b *BadgerDatabase) WriteTransaction(
 	}
 }
 
+// RunInTransaction invokes fn in a new transaction and commits
+// it, retrying up to maxConflictRetries times if the commit
+// fails because of a conflict.
+func (b *BadgerDatabase) RunInTransaction(
+	ctx context.Context,
+	identifier string,
+	priority bool,
+	fn func(Transaction) error,
+) error {
+	exponentialBackoff := backoff.NewExponentialBackOff()
+	exponentialBackoff.InitialInterval = defaultConflictBackoff
+	retryBackoff := backoff.WithMaxRetries(exponentialBackoff, uint64(b.maxConflictRetries))
+
+	for {
+		err := b.runInTransaction(ctx, identifier, priority, fn)
+		if !errors.Is(err, storageErrs.ErrTransactionConflict) {
+			return err
+		}
+
+		nextBackoff := retryBackoff.NextBackOff()
+		if nextBackoff == backoff.Stop {
+			return fmt.Errorf(
+				"%w: exhausted %d retries",
+				err,
+				b.maxConflictRetries,
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(nextBackoff):
+		}
+	}
+}
+
+func (b *BadgerDatabase) runInTransaction(
+	ctx context.Context,
+	identifier string,
+	priority bool,
+	fn func(Transaction) error,
+) error {
+	var dbTx Transaction
+	if len(identifier) == 0 {
+		dbTx = b.Transaction(ctx)
+	} else {
+		dbTx = b.WriteTransaction(ctx, identifier, priority)
+	}
+	defer dbTx.Discard(ctx)
+
+	if err := fn(dbTx); err != nil {
+		return err
+	}
+
+	return dbTx.Commit(ctx)
+}
+
+// DeletePrefix removes all keys with a prefix in batches
+// of deletePrefixBatchSize keys. If a batch is too large for
+// a single transaction, it is split across multiple commits.
+func (b *BadgerDatabase) DeletePrefix(ctx context.Context, prefix []byte) (int, error) {
+	b.writer.GLock()
+	defer b.writer.GUnlock()
+
+	deleted := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return deleted, err
+		}
+
+		keys := b.prefixKeys(prefix)
+		if len(keys) == 0 {
+			return deleted, nil
+		}
+
+		if err := b.deleteKeys(keys); err != nil {
+			return deleted, fmt.Errorf("%w: %v", storageErrs.ErrDeletePrefixFailed, err)
+		}
+
+		deleted += len(keys)
+		b.recordDeletes(int64(len(keys)))
+		log.Printf("deleted %d entries for %s\n", deleted, string(prefix))
+	}
+}
+
+// prefixKeys returns a copy of up to deletePrefixBatchSize
+// keys with a prefix.
+func (b *BadgerDatabase) prefixKeys(prefix []byte) [][]byte {
+	txn := b.db.NewTransaction(false)
+	defer txn.Discard()
+
+	opts := badger.DefaultIteratorOptions
+	opts.PrefetchValues = false
+	opts.Prefix = prefix
+	it := txn.NewIterator(opts)
+	defer it.Close()
+
+	keys := [][]byte{}
+	for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+		keys = append(keys, it.Item().KeyCopy(nil))
+		if len(keys) == deletePrefixBatchSize {
+			break
+		}
+	}
+
+	return keys
+}
+
+// deleteKeys removes keys, committing early
+// whenever the transaction becomes too big.
+func (b *BadgerDatabase) deleteKeys(keys [][]byte) error {
+	txn := b.db.NewTransaction(true)
+	defer func() {
+		txn.Discard()
+	}()
+
+	for _, key := range keys {
+		err := txn.Delete(key)
+		if errors.Is(err, badger.ErrTxnTooBig) {
+			if err := txn.Commit(); err != nil {
+				return fmt.Errorf("%w: %v", storageErrs.ErrCommitFailed, err)
+			}
+
+			txn = b.db.NewTransaction(true)
+			err = txn.Delete(key)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("%w: %v", storageErrs.ErrCommitFailed, err)
+	}
+
+	return nil
+}
+
 func (b *BadgerTransaction) releaseLocks() {
 	if b.holdGlobal {
 		b.holdGlobal = false
@@ -395,10 +600,17 @@ func (b *BadgerTransaction) Commit(context.Context) error {
 	// In this case, we only unlock if we hold the lock to avoid a panic.
 	b.releaseLocks()
 
+	if errors.Is(err, badger.ErrConflict) {
+		return fmt.Errorf("%w: %v", storageErrs.ErrTransactionConflict, err)
+	}
+
 	if err != nil {
 		return fmt.Errorf("%w: %v", storageErrs.ErrCommitFailed, err)
 	}
 
+	b.db.recordDeletes(b.deletes)
+	b.deletes = 0
+
 	return nil
 }
 
@@ -473,7 +685,12 @@ func (b *BadgerTransaction) Delete(ctx context.Context, key []byte) error {
 	b.rwLock.Lock()
 	defer b.rwLock.Unlock()
 
-	return b.txn.Delete(key)
+	if err := b.txn.Delete(key); err != nil {
+		return err
+	}
+	b.deletes++
+
+	return nil
 }
 
 // Scan calls a worker for each item in a scan instead
@@ -16,6 +16,7 @@ package database
 
 import (
 	"github.com/dgraph-io/badger/v2"
+	"github.com/dgraph-io/badger/v2/options"
 
 	"github.com/coinbase/rosetta-sdk-go/storage/encoder"
 )
@@ -34,6 +35,15 @@ func WithCompressorEntries(entries []*encoder.CompressorEntry) BadgerOption {
 	}
 }
 
+// WithBufferPoolLimits bounds the buffers retained
+// for reuse by the compressor (see
+// encoder.NewBoundedBufferPool).
+func WithBufferPoolLimits(maxBuffers int, maxBytes int) BadgerOption {
+	return func(b *BadgerDatabase) {
+		b.pool = encoder.NewBoundedBufferPool(maxBuffers, maxBytes)
+	}
+}
+
 // WithoutCompression disables zstd compression.
 func WithoutCompression() BadgerOption {
 	return func(b *BadgerDatabase) {
@@ -51,6 +61,71 @@ func WithIndexCacheSize(size int64) BadgerOption {
 	}
 }
 
+// WithNumMemtables overrides the number of memtables
+// BadgerDB keeps in memory. Increasing this value (to 2-5)
+// reduces write stalls during a write-heavy initial sync
+// at the cost of ~MaxTableSize of RAM per memtable. When
+// serving a read-heavy workload, keep the default of 1.
+// If you provide custom BadgerDB settings, do not use this
+// config as it will be overridden by your custom settings.
+func WithNumMemtables(count int) BadgerOption {
+	return func(b *BadgerDatabase) {
+		b.badgerOptions.NumMemtables = count
+	}
+}
+
+// WithMaxTableSize overrides the DefaultMaxTableSize
+// setting for the BadgerDB. The size here is in bytes.
+// Larger tables allow for larger commits (useful during
+// a write-heavy initial sync) but increase memory usage.
+// If you provide custom BadgerDB settings, do not use this
+// config as it will be overridden by your custom settings.
+func WithMaxTableSize(size int64) BadgerOption {
+	return func(b *BadgerDatabase) {
+		b.badgerOptions.MaxTableSize = size
+	}
+}
+
+// WithValueLogFileSize overrides the DefaultLogValueSize
+// setting for the BadgerDB. The size here is in bytes.
+// Larger value logs reduce file churn during a write-heavy
+// initial sync while smaller value logs are reclaimed
+// more quickly by value log GC.
+// If you provide custom BadgerDB settings, do not use this
+// config as it will be overridden by your custom settings.
+func WithValueLogFileSize(size int64) BadgerOption {
+	return func(b *BadgerDatabase) {
+		b.badgerOptions.ValueLogFileSize = size
+	}
+}
+
+// WithBadgerCompression overrides the DefaultCompressionMode
+// used by BadgerDB to compress table blocks. Because values are
+// already compressed with zstd by the encoder (unless
+// WithoutCompression is provided), it is rarely useful to enable
+// both layers of compression. Providing options.None disables
+// BadgerDB compression.
+// If you provide custom BadgerDB settings, do not use this
+// config as it will be overridden by your custom settings.
+func WithBadgerCompression(mode options.CompressionType) BadgerOption {
+	return func(b *BadgerDatabase) {
+		b.badgerOptions.Compression = mode
+	}
+}
+
+// WithDeleteGCThreshold triggers value log garbage collection
+// whenever the number of keys deleted (in committed transactions or
+// with DeletePrefix) since it was last triggered reaches deletes.
+// This is useful on chains with frequent reorgs (where removed
+// blocks leave many stale values behind). Periodic garbage
+// collection still runs as usual. Values <= 0 disable this trigger
+// (the default).
+func WithDeleteGCThreshold(deletes int64) BadgerOption {
+	return func(b *BadgerDatabase) {
+		b.gcDeleteThreshold = deletes
+	}
+}
+
 // WithCustomSettings allows for overriding all default BadgerDB
 // options with custom settings.
 func WithCustomSettings(settings badger.Options) BadgerOption {
@@ -68,3 +143,11 @@ func WithWriterShards(shards int) BadgerOption {
 		b.writerShards = shards
 	}
 }
+
+// WithMaxConflictRetries overrides the DefaultMaxConflictRetries
+// used by RunInTransaction.
+func WithMaxConflictRetries(retries int) BadgerOption {
+	return func(b *BadgerDatabase) {
+		b.maxConflictRetries = retries
+	}
+}
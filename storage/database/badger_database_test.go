@@ -16,14 +16,20 @@ package database
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/dgraph-io/badger/v2"
+	"github.com/dgraph-io/badger/v2/options"
 	"github.com/lucasjones/reggen"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/coinbase/rosetta-sdk-go/storage/encoder"
+	storageErrs "github.com/coinbase/rosetta-sdk-go/storage/errors"
 	"github.com/coinbase/rosetta-sdk-go/utils"
 )
 
@@ -223,6 +229,260 @@ func TestDatabaseTransaction(t *testing.T) {
 	})
 }
 
+func TestRunInTransaction(t *testing.T) {
+	ctx := context.Background()
+
+	newDir, err := utils.CreateTempDir()
+	assert.NoError(t, err)
+	defer utils.RemoveTempDir(newDir)
+
+	database, err := NewBadgerDatabase(
+		ctx,
+		newDir,
+		WithIndexCacheSize(TinyIndexCacheSize),
+		WithMaxConflictRetries(2),
+	)
+	assert.NoError(t, err)
+	defer database.Close(ctx)
+
+	key := []byte("counter")
+
+	// conflictingUpdate reads key in dbTx and then commits
+	// a write to key in another transaction so that dbTx
+	// will fail to commit.
+	conflictingUpdate := func(dbTx Transaction) error {
+		if _, _, err := dbTx.Get(ctx, key); err != nil {
+			return err
+		}
+
+		otherTx := database.WriteTransaction(ctx, "other", false)
+		if err := otherTx.Set(ctx, key, []byte("other"), true); err != nil {
+			return err
+		}
+		if err := otherTx.Commit(ctx); err != nil {
+			return err
+		}
+
+		return dbTx.Set(ctx, key, []byte("mine"), true)
+	}
+
+	t.Run("retry after conflict", func(t *testing.T) {
+		attempts := 0
+		err := database.RunInTransaction(ctx, "mine", false, func(dbTx Transaction) error {
+			attempts++
+			if attempts == 1 {
+				return conflictingUpdate(dbTx)
+			}
+
+			return dbTx.Set(ctx, key, []byte("mine"), true)
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, attempts)
+
+		txn := database.ReadTransaction(ctx)
+		exists, value, err := txn.Get(ctx, key)
+		txn.Discard(ctx)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, []byte("mine"), value)
+	})
+
+	t.Run("exhaust retries", func(t *testing.T) {
+		attempts := 0
+		err := database.RunInTransaction(ctx, "mine", false, func(dbTx Transaction) error {
+			attempts++
+			return conflictingUpdate(dbTx)
+		})
+		assert.True(t, errors.Is(err, storageErrs.ErrTransactionConflict))
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("do not retry other errors", func(t *testing.T) {
+		attempts := 0
+		errBogus := errors.New("bogus")
+		err := database.RunInTransaction(ctx, "", false, func(dbTx Transaction) error {
+			attempts++
+			if err := dbTx.Set(ctx, key, []byte("bogus"), true); err != nil {
+				return err
+			}
+
+			return errBogus
+		})
+		assert.True(t, errors.Is(err, errBogus))
+		assert.Equal(t, 1, attempts)
+
+		txn := database.ReadTransaction(ctx)
+		_, value, err := txn.Get(ctx, key)
+		txn.Discard(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("other"), value)
+	})
+}
+
+func TestDeletePrefix(t *testing.T) {
+	ctx := context.Background()
+
+	newDir, err := utils.CreateTempDir()
+	assert.NoError(t, err)
+	defer utils.RemoveTempDir(newDir)
+
+	database, err := newTestBadgerDatabase(ctx, newDir)
+	assert.NoError(t, err)
+	defer database.Close(ctx)
+
+	// Populate more keys than can be deleted in a single batch
+	entries := deletePrefixBatchSize*2 + 1
+	txn := database.Transaction(ctx)
+	for i := 0; i < entries; i++ {
+		assert.NoError(t, txn.Set(ctx, []byte(fmt.Sprintf("prune/%d", i)), []byte("blah"), true))
+	}
+	assert.NoError(t, txn.Set(ctx, []byte("prun"), []byte("blah"), true))
+	assert.NoError(t, txn.Set(ctx, []byte("other/0"), []byte("blah"), true))
+	assert.NoError(t, txn.Commit(ctx))
+
+	deleted, err := database.DeletePrefix(ctx, []byte("prune/"))
+	assert.NoError(t, err)
+	assert.Equal(t, entries, deleted)
+
+	txn = database.ReadTransaction(ctx)
+	count, err := txn.Scan(
+		ctx,
+		[]byte("prune/"),
+		[]byte("prune/"),
+		func(k []byte, v []byte) error { return nil },
+		false,
+		false,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	for _, key := range []string{"prun", "other/0"} {
+		exists, _, err := txn.Get(ctx, []byte(key))
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	}
+	txn.Discard(ctx)
+
+	// Deleting a prefix with no keys is a no-op
+	deleted, err = database.DeletePrefix(ctx, []byte("prune/"))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+}
+
+func TestDeleteGCThreshold(t *testing.T) {
+	ctx := context.Background()
+
+	newDir, err := utils.CreateTempDir()
+	assert.NoError(t, err)
+	defer utils.RemoveTempDir(newDir)
+
+	database, err := NewBadgerDatabase(
+		ctx,
+		newDir,
+		WithIndexCacheSize(TinyIndexCacheSize),
+		WithDeleteGCThreshold(100),
+	)
+	assert.NoError(t, err)
+	defer database.Close(ctx)
+	badgerDatabase := database.(*BadgerDatabase)
+	gcRuns := func() int64 { return atomic.LoadInt64(&badgerDatabase.gcRuns) }
+
+	// Simulate blocks being added...
+	txn := database.Transaction(ctx)
+	for i := 0; i < 250; i++ {
+		assert.NoError(t, txn.Set(ctx, []byte(fmt.Sprintf("block/%d", i)), []byte("blah"), true))
+	}
+	assert.NoError(t, txn.Commit(ctx))
+
+	// ...and removed (below the threshold)
+	txn = database.Transaction(ctx)
+	for i := 0; i < 99; i++ {
+		assert.NoError(t, txn.Delete(ctx, []byte(fmt.Sprintf("block/%d", i))))
+	}
+	assert.NoError(t, txn.Commit(ctx))
+	assert.Equal(t, int64(99), atomic.LoadInt64(&badgerDatabase.pendingDeletes))
+	assert.Equal(t, int64(0), gcRuns())
+
+	// Discarded deletes are not counted
+	txn = database.Transaction(ctx)
+	assert.NoError(t, txn.Delete(ctx, []byte("block/99")))
+	txn.Discard(ctx)
+	assert.Equal(t, int64(99), atomic.LoadInt64(&badgerDatabase.pendingDeletes))
+
+	// Reaching the threshold triggers GC
+	txn = database.Transaction(ctx)
+	assert.NoError(t, txn.Delete(ctx, []byte("block/99")))
+	assert.NoError(t, txn.Commit(ctx))
+	assert.Eventually(t, func() bool { return gcRuns() == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int64(0), atomic.LoadInt64(&badgerDatabase.pendingDeletes))
+
+	// DeletePrefix deletions are also counted
+	deleted, err := database.DeletePrefix(ctx, []byte("block/"))
+	assert.NoError(t, err)
+	assert.Equal(t, 150, deleted)
+	assert.Eventually(t, func() bool { return gcRuns() == 2 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestBadgerOptions(t *testing.T) {
+	tests := map[string]struct {
+		options []BadgerOption
+		check   func(*testing.T, badger.Options)
+	}{
+		"defaults": {
+			check: func(t *testing.T, opts badger.Options) {
+				assert.Equal(t, 1, opts.NumMemtables)
+				assert.Equal(t, int64(DefaultMaxTableSize), opts.MaxTableSize)
+				assert.Equal(t, int64(DefaultLogValueSize), opts.ValueLogFileSize)
+				assert.Equal(t, DefaultCompressionMode, opts.Compression)
+			},
+		},
+		"write heavy": {
+			options: []BadgerOption{
+				WithNumMemtables(3),
+				WithMaxTableSize(64 << 20),
+				WithValueLogFileSize(128 << 20),
+			},
+			check: func(t *testing.T, opts badger.Options) {
+				assert.Equal(t, 3, opts.NumMemtables)
+				assert.Equal(t, int64(64<<20), opts.MaxTableSize)
+				assert.Equal(t, int64(128<<20), opts.ValueLogFileSize)
+			},
+		},
+		"badger compression": {
+			options: []BadgerOption{
+				WithBadgerCompression(options.Snappy),
+			},
+			check: func(t *testing.T, opts badger.Options) {
+				assert.Equal(t, options.Snappy, opts.Compression)
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			newDir, err := utils.CreateTempDir()
+			assert.NoError(t, err)
+			defer utils.RemoveTempDir(newDir)
+
+			opts := append(
+				[]BadgerOption{WithIndexCacheSize(TinyIndexCacheSize)},
+				test.options...,
+			)
+			database, err := NewBadgerDatabase(ctx, newDir, opts...)
+			assert.NoError(t, err)
+			defer database.Close(ctx)
+
+			test.check(t, database.(*BadgerDatabase).badgerOptions)
+
+			txn := database.Transaction(ctx)
+			assert.NoError(t, txn.Set(ctx, []byte("hello"), []byte("world"), true))
+			assert.NoError(t, txn.Commit(ctx))
+		})
+	}
+}
+
 type BogusEntry struct {
 	Index int    `json:"index"`
 	Stuff string `json:"stuff"`
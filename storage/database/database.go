@@ -41,6 +41,25 @@ type Database interface {
 	// committed or discarded.
 	WriteTransaction(ctx context.Context, identifier string, priority bool) Transaction
 
+	// RunInTransaction invokes fn with a new write transaction for
+	// identifier (or an exclusive Transaction if identifier is empty)
+	// and commits it if fn returns no error. If the commit fails
+	// because of a conflict with another transaction, fn is invoked
+	// again (with backoff) in a fresh transaction.
+	RunInTransaction(
+		ctx context.Context,
+		identifier string,
+		priority bool,
+		fn func(Transaction) error,
+	) error
+
+	// DeletePrefix removes all keys that start with prefix and
+	// returns the number of keys removed. Keys are removed in
+	// multiple transactions (to stay within transaction size
+	// limits), so the removal is not atomic. An exclusive write
+	// lock is held until all keys are removed.
+	DeletePrefix(ctx context.Context, prefix []byte) (int, error)
+
 	// Close shuts down the database.
 	Close(context.Context) error
 
@@ -17,22 +17,65 @@ package encoder
 import (
 	"bytes"
 	"sync"
+	"sync/atomic"
 )
 
 // BufferPool contains a sync.Pool
 // of *bytes.Buffer.
+//
+// If limits are set (see NewBoundedBufferPool), buffers are
+// instead retained in a list that never holds more than
+// maxBuffers buffers or maxBytes of total capacity. Buffers
+// returned to a full pool are discarded.
 type BufferPool struct {
 	pool sync.Pool
+
+	// bounded, maxBuffers, and maxBytes are set on construction
+	// and never modified. lock is only used by a bounded
+	// *BufferPool (to protect buffers and retainedBytes).
+	bounded       bool
+	maxBuffers    int
+	maxBytes      int
+	buffers       []*bytes.Buffer
+	retainedBytes int
+	lock          sync.Mutex
+
+	hits     int64
+	misses   int64
+	discards int64
+}
+
+// BufferPoolStats contains the usage of a *BufferPool.
+type BufferPoolStats struct {
+	// Hits is the number of calls to Get that
+	// reused a buffer and Misses is the number
+	// that allocated a new buffer.
+	Hits   int64
+	Misses int64
+
+	// Discards is the number of buffers that were
+	// not retained because the pool was full.
+	Discards int64
+
+	// RetainedBuffers and RetainedBytes are only
+	// populated for a bounded *BufferPool.
+	RetainedBuffers int
+	RetainedBytes   int
 }
 
 // NewBufferPool returns a new *BufferPool.
 func NewBufferPool() *BufferPool {
+	return &BufferPool{}
+}
+
+// NewBoundedBufferPool returns a new *BufferPool that
+// retains at most maxBuffers buffers with a total capacity
+// of at most maxBytes. A limit of 0 is not enforced.
+func NewBoundedBufferPool(maxBuffers int, maxBytes int) *BufferPool {
 	return &BufferPool{
-		pool: sync.Pool{
-			New: func() interface{} {
-				return new(bytes.Buffer)
-			},
-		},
+		bounded:    maxBuffers > 0 || maxBytes > 0,
+		maxBuffers: maxBuffers,
+		maxBytes:   maxBytes,
 	}
 }
 
@@ -40,7 +83,23 @@ func NewBufferPool() *BufferPool {
 // it in the pool for reuse.
 func (p *BufferPool) Put(buffer *bytes.Buffer) {
 	buffer.Reset()
-	p.pool.Put(buffer)
+
+	if !p.bounded {
+		p.pool.Put(buffer)
+		return
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if (p.maxBuffers > 0 && len(p.buffers) >= p.maxBuffers) ||
+		(p.maxBytes > 0 && p.retainedBytes+buffer.Cap() > p.maxBytes) {
+		atomic.AddInt64(&p.discards, 1)
+		return
+	}
+
+	p.buffers = append(p.buffers, buffer)
+	p.retainedBytes += buffer.Cap()
 }
 
 // PutByteSlice creates a *bytes.Buffer from the provided
@@ -51,5 +110,45 @@ func (p *BufferPool) PutByteSlice(buffer []byte) {
 
 // Get returns a new or reused *bytes.Buffer.
 func (p *BufferPool) Get() *bytes.Buffer {
-	return p.pool.Get().(*bytes.Buffer)
+	if !p.bounded {
+		buffer, _ := p.pool.Get().(*bytes.Buffer)
+		return p.record(buffer)
+	}
+
+	var buffer *bytes.Buffer
+	p.lock.Lock()
+	if len(p.buffers) > 0 {
+		buffer = p.buffers[len(p.buffers)-1]
+		p.buffers = p.buffers[:len(p.buffers)-1]
+		p.retainedBytes -= buffer.Cap()
+	}
+	p.lock.Unlock()
+
+	return p.record(buffer)
+}
+
+// record updates hits and misses, allocating
+// a new *bytes.Buffer if buffer is nil.
+func (p *BufferPool) record(buffer *bytes.Buffer) *bytes.Buffer {
+	if buffer == nil {
+		atomic.AddInt64(&p.misses, 1)
+		return new(bytes.Buffer)
+	}
+
+	atomic.AddInt64(&p.hits, 1)
+	return buffer
+}
+
+// Stats returns the usage of the *BufferPool.
+func (p *BufferPool) Stats() *BufferPoolStats {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return &BufferPoolStats{
+		Hits:            atomic.LoadInt64(&p.hits),
+		Misses:          atomic.LoadInt64(&p.misses),
+		Discards:        atomic.LoadInt64(&p.discards),
+		RetainedBuffers: len(p.buffers),
+		RetainedBytes:   p.retainedBytes,
+	}
 }
@@ -0,0 +1,130 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encoder
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sync/errgroup"
+)
+
+func TestBufferPool(t *testing.T) {
+	p := NewBufferPool()
+	b := p.Get()
+	b.WriteString("hello")
+	p.Put(b)
+
+	stats := p.Stats()
+	assert.Equal(t, int64(1), stats.Misses)
+	assert.Equal(t, int64(0), stats.Discards)
+	assert.Equal(t, 0, stats.RetainedBuffers)
+}
+
+func TestBoundedBufferPool(t *testing.T) {
+	var tests = map[string]struct {
+		maxBuffers int
+		maxBytes   int
+	}{
+		"max buffers": {
+			maxBuffers: 5,
+		},
+		"max bytes": {
+			maxBytes: 4096,
+		},
+		"max buffers and bytes": {
+			maxBuffers: 5,
+			maxBytes:   4096,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := NewBoundedBufferPool(test.maxBuffers, test.maxBytes)
+
+			// Reused buffers are reset
+			b := p.Get()
+			b.WriteString("hello")
+			p.Put(b)
+			assert.Equal(t, 0, p.Get().Len())
+
+			g, _ := errgroup.WithContext(context.Background())
+			for i := 0; i < 50; i++ {
+				g.Go(func() error {
+					for j := 0; j < 100; j++ {
+						buffers := []*bytes.Buffer{p.Get(), p.Get()}
+						for _, b := range buffers {
+							b.Write(make([]byte, 256))
+						}
+
+						for _, b := range buffers {
+							p.Put(b)
+						}
+
+						stats := p.Stats()
+						if test.maxBuffers > 0 {
+							assert.LessOrEqual(t, stats.RetainedBuffers, test.maxBuffers)
+						}
+						if test.maxBytes > 0 {
+							assert.LessOrEqual(t, stats.RetainedBytes, test.maxBytes)
+						}
+					}
+
+					return nil
+				})
+			}
+			assert.NoError(t, g.Wait())
+
+			// Buffers returned to a full pool are discarded
+			for i := 0; i < 10; i++ {
+				p.Put(bytes.NewBuffer(make([]byte, 0, 1024)))
+			}
+
+			stats := p.Stats()
+			assert.Equal(t, int64(50*100*2+2), stats.Hits+stats.Misses)
+			assert.Greater(t, stats.Hits, int64(0))
+			assert.Greater(t, stats.Discards, int64(0))
+			assert.Greater(t, stats.RetainedBuffers, 0)
+			if test.maxBuffers > 0 {
+				assert.LessOrEqual(t, stats.RetainedBuffers, test.maxBuffers)
+			}
+			if test.maxBytes > 0 {
+				assert.LessOrEqual(t, stats.RetainedBytes, test.maxBytes)
+			}
+		})
+	}
+}
+
+func TestEncoderBufferPoolLimits(t *testing.T) {
+	e, err := NewEncoder(nil, NewBufferPool(), true, WithBufferPoolLimits(2, 0))
+	assert.NoError(t, err)
+
+	g, _ := errgroup.WithContext(context.Background())
+	for i := 0; i < 10; i++ {
+		g.Go(func() error {
+			runCompressions(e, t)
+
+			return nil
+		})
+	}
+	assert.NoError(t, g.Wait())
+
+	stats := e.PoolStats()
+	assert.LessOrEqual(t, stats.RetainedBuffers, 2)
+	assert.Greater(t, stats.Hits, int64(0))
+	assert.Greater(t, stats.Discards, int64(0))
+}
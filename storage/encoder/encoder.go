@@ -21,11 +21,14 @@ import (
 	"io"
 	"io/ioutil"
 	"log"
+	"math/big"
 	"path"
+	"reflect"
 	"strconv"
 
 	"github.com/DataDog/zstd"
 	msgpack "github.com/vmihailenco/msgpack/v5"
+	"github.com/vmihailenco/msgpack/v5/msgpcode"
 
 	"github.com/coinbase/rosetta-sdk-go/storage/errors"
 	"github.com/coinbase/rosetta-sdk-go/types"
@@ -33,6 +36,15 @@ import (
 
 const (
 	jsonTag = "json"
+
+	// bigIntExtID is the msgpack extension type used to
+	// encode *big.Int. It is chosen to avoid the low IDs
+	// most commonly registered by other packages.
+	bigIntExtID int8 = 98
+
+	// bigIntNegative is the first byte of an
+	// encoded *big.Int that is negative.
+	bigIntNegative byte = 1
 )
 
 // Encoder handles the encoding/decoding of structs and the
@@ -63,6 +75,7 @@ func NewEncoder(
 	entries []*CompressorEntry,
 	pool *BufferPool,
 	compress bool,
+	opts ...EncoderOption,
 ) (*Encoder, error) {
 	dicts := map[string][]byte{}
 	for _, entry := range entries {
@@ -80,11 +93,142 @@ func NewEncoder(
 		dicts[entry.Namespace] = b
 	}
 
-	return &Encoder{
+	e := &Encoder{
 		compressionDicts: dicts,
 		pool:             pool,
 		compress:         compress,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e, nil
+}
+
+// PoolStats returns the usage of the
+// *BufferPool used by the *Encoder.
+func (e *Encoder) PoolStats() *BufferPoolStats {
+	return e.pool.Stats()
+}
+
+// init registers a msgpack extension for *big.Int (a sign
+// byte followed by the big-endian bytes of its absolute
+// value), which is much more compact than the default text
+// encoding.
+//
+// msgpack registrations are process-wide, so this changes
+// how *big.Int is encoded by every msgpack user in a process
+// that imports this package (not only by the *Encoder). The
+// *Encoder is the only user of msgpack in this SDK. Values
+// encoded as text (before the extension was registered) can
+// still be decoded.
+func init() {
+	msgpack.RegisterExtEncoder(bigIntExtID, (*big.Int)(nil), encodeBigInt)
+	msgpack.RegisterExtDecoder(bigIntExtID, (*big.Int)(nil), decodeBigIntExt)
+
+	// Replace the decoders registered by RegisterExtDecoder
+	// (which only accept the extension) so that *big.Int
+	// values encoded before the extension was registered
+	// can still be decoded.
+	msgpack.Register((*big.Int)(nil), nil, decodeBigInt)
+	msgpack.Register(big.Int{}, nil, func(d *msgpack.Decoder, v reflect.Value) error {
+		if !v.CanAddr() {
+			return fmt.Errorf("%w: big.Int is not addressable", errors.ErrObjectDecodeFailed)
+		}
+
+		return decodeBigInt(d, v.Addr())
+	})
+}
+
+// encodeBigInt encodes a *big.Int as a sign byte
+// followed by the big-endian bytes of its absolute value.
+func encodeBigInt(e *msgpack.Encoder, v reflect.Value) ([]byte, error) {
+	i := v.Interface().(*big.Int)
+
+	sign := byte(0)
+	if i.Sign() < 0 {
+		sign = bigIntNegative
+	}
+
+	return append([]byte{sign}, i.Bytes()...), nil
+}
+
+// decodeBigIntExt decodes a *big.Int encoded
+// with encodeBigInt.
+func decodeBigIntExt(d *msgpack.Decoder, v reflect.Value, extLen int) error {
+	if extLen < 1 {
+		return fmt.Errorf("%w: empty big.Int extension", errors.ErrObjectDecodeFailed)
+	}
+
+	b := make([]byte, extLen)
+	if err := d.ReadFull(b); err != nil {
+		return err
+	}
+
+	if v.IsNil() {
+		v.Set(reflect.New(v.Type().Elem()))
+	}
+
+	i := v.Interface().(*big.Int)
+	i.SetBytes(b[1:])
+	if b[0] == bigIntNegative {
+		i.Neg(i)
+	}
+
+	return nil
+}
+
+// decodeBigInt decodes a *big.Int encoded with
+// encodeBigInt or as text (using big.Int.MarshalText).
+func decodeBigInt(d *msgpack.Decoder, v reflect.Value) error {
+	c, err := d.PeekCode()
+	if err != nil {
+		return err
+	}
+
+	if c == msgpcode.Nil {
+		if err := d.DecodeNil(); err != nil {
+			return err
+		}
+
+		// v is not settable when decoding
+		// into a big.Int (instead of a *big.Int).
+		if !v.CanSet() {
+			v.Interface().(*big.Int).SetInt64(0)
+			return nil
+		}
+
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+
+	if msgpcode.IsExt(c) {
+		extID, extLen, err := d.DecodeExtHeader()
+		if err != nil {
+			return err
+		}
+
+		if extID != bigIntExtID {
+			return fmt.Errorf(
+				"%w: unexpected extension %d for big.Int",
+				errors.ErrObjectDecodeFailed,
+				extID,
+			)
+		}
+
+		return decodeBigIntExt(d, v, extLen)
+	}
+
+	text, err := d.DecodeBytes()
+	if err != nil {
+		return err
+	}
+
+	if v.IsNil() {
+		v.Set(reflect.New(v.Type().Elem()))
+	}
+
+	return v.Interface().(*big.Int).UnmarshalText(text)
 }
 
 func getEncoder(w io.Writer) *msgpack.Encoder {
@@ -209,8 +353,21 @@ func (e *Encoder) decode(b []byte, zstdDict []byte) ([]byte, error) {
 // CopyStruct performs a deep copy of an entire struct
 // using its JSON representation.
 func CopyStruct(input interface{}, output interface{}) error {
-	inputString := types.PrintStruct(input)
-	if err := json.Unmarshal([]byte(inputString), &output); err != nil {
+	// Decode the JSON representation of input as it
+	// is written instead of collecting it in a buffer.
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(types.WriteStruct(writer, input))
+	}()
+
+	err := json.NewDecoder(reader).Decode(&output)
+	if err == nil {
+		// Drain any trailing bytes (i.e. the newline) so
+		// the writer can exit.
+		_, err = io.Copy(ioutil.Discard, reader)
+	}
+	reader.CloseWithError(err)
+	if err != nil {
 		return fmt.Errorf("%w: %v", errors.ErrCopyBlockFailed, err)
 	}
 
@@ -0,0 +1,32 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encoder
+
+// EncoderOption is used to overwrite default values in
+// Encoder construction. Any Option not provided
+// falls back to the default value.
+type EncoderOption func(e *Encoder)
+
+// WithBufferPoolLimits replaces the *BufferPool provided
+// to NewEncoder with a new *BufferPool that retains at most
+// maxBuffers buffers with a total capacity of at most maxBytes
+// (see NewBoundedBufferPool). To share a bounded *BufferPool
+// with other users, provide one created with NewBoundedBufferPool
+// to NewEncoder instead.
+func WithBufferPoolLimits(maxBuffers int, maxBytes int) EncoderOption {
+	return func(e *Encoder) {
+		e.pool = NewBoundedBufferPool(maxBuffers, maxBytes)
+	}
+}
@@ -15,13 +15,18 @@
 package encoder
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	msgpack "github.com/vmihailenco/msgpack/v5"
+	"github.com/vmihailenco/msgpack/v5/msgpcode"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/coinbase/rosetta-sdk-go/storage/errors"
 	"github.com/coinbase/rosetta-sdk-go/types"
 )
 
@@ -329,3 +334,110 @@ func TestEncodeDecodeAccountCurrency(t *testing.T) {
 		})
 	}
 }
+
+type bigInts struct {
+	Pointer *big.Int `json:"pointer"`
+	Value   big.Int  `json:"value"`
+	Nil     *big.Int `json:"nil"`
+}
+
+func TestEncodeDecodeBigInt(t *testing.T) {
+	large, ok := new(big.Int).SetString("123456789012345678901234567890123456789012345678901234567890", 10)
+	assert.True(t, ok)
+
+	var tests = map[string]*big.Int{
+		"zero":           big.NewInt(0),
+		"positive":       big.NewInt(1),
+		"negative":       big.NewInt(-1),
+		"max uint64":     new(big.Int).SetUint64(^uint64(0)),
+		"large positive": large,
+		"large negative": new(big.Int).Neg(large),
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			for _, compress := range []bool{true, false} {
+				e, err := NewEncoder(nil, NewBufferPool(), compress)
+				assert.NoError(t, err)
+
+				original := &bigInts{Pointer: test, Value: *test}
+				enc, err := e.Encode("", original)
+				assert.NoError(t, err)
+
+				var decoded bigInts
+				assert.NoError(t, e.Decode("", enc, &decoded, true))
+				assert.Equal(t, 0, test.Cmp(decoded.Pointer))
+				assert.Equal(t, 0, test.Cmp(&decoded.Value))
+				assert.Nil(t, decoded.Nil)
+
+				var decodedInt big.Int
+				enc, err = e.Encode("", test)
+				assert.NoError(t, err)
+				if !compress {
+					assert.True(t, msgpcode.IsExt(enc[0]))
+				}
+				assert.NoError(t, e.Decode("", enc, &decodedInt, true))
+				assert.Equal(t, 0, test.Cmp(&decodedInt))
+			}
+
+			// Values encoded as text before the
+			// extension was registered can be decoded.
+			buf := new(bytes.Buffer)
+			assert.NoError(t, getEncoder(buf).Encode(&struct {
+				Pointer []byte `json:"pointer"`
+				Value   string `json:"value"`
+			}{
+				Pointer: []byte(test.String()),
+				Value:   test.String(),
+			}))
+
+			var decoded bigInts
+			assert.NoError(t, getDecoder(buf).Decode(&decoded))
+			assert.Equal(t, 0, test.Cmp(decoded.Pointer))
+			assert.Equal(t, 0, test.Cmp(&decoded.Value))
+
+			// The extension is registered process-wide.
+			enc, err := msgpack.Marshal(test)
+			assert.NoError(t, err)
+			assert.True(t, msgpcode.IsExt(enc[0]))
+		})
+	}
+}
+
+func TestCopyStruct(t *testing.T) {
+	t.Run("copy block", func(t *testing.T) {
+		block := &types.Block{
+			BlockIdentifier: &types.BlockIdentifier{Index: 1, Hash: "block 1"},
+			ParentBlockIdentifier: &types.BlockIdentifier{
+				Index: 0,
+				Hash:  "block 0",
+			},
+			Transactions: []*types.Transaction{
+				{
+					TransactionIdentifier: &types.TransactionIdentifier{Hash: "tx 1"},
+					Metadata:              map[string]interface{}{"a": "b"},
+				},
+			},
+		}
+
+		var copied types.Block
+		assert.NoError(t, CopyStruct(block, &copied))
+		assert.Equal(t, block, &copied)
+
+		// Modifying the copy must not modify the original.
+		copied.Transactions[0].Metadata["a"] = "c"
+		assert.Equal(t, "b", block.Transactions[0].Metadata["a"])
+	})
+
+	t.Run("mismatched output", func(t *testing.T) {
+		var output int
+		err := CopyStruct(&types.BlockIdentifier{Index: 1, Hash: "block 1"}, &output)
+		assert.ErrorIs(t, err, errors.ErrCopyBlockFailed)
+	})
+
+	t.Run("unsupported input", func(t *testing.T) {
+		var output map[string]interface{}
+		err := CopyStruct(make(chan int), &output)
+		assert.ErrorIs(t, err, errors.ErrCopyBlockFailed)
+	})
+}
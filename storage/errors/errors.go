@@ -43,6 +43,8 @@ var (
 	ErrInvokeZSTDFailed           = errors.New("unable to start zstd")
 	ErrTrainZSTDFailed            = errors.New("unable to train zstd")
 	ErrWalkFilesFailed            = errors.New("unable to walk files")
+	ErrTransactionConflict        = errors.New("transaction conflict")
+	ErrDeletePrefixFailed         = errors.New("unable to delete prefix")
 
 	BadgerStorageErrs = []error{
 		ErrDatabaseOpenFailed,
@@ -66,6 +68,8 @@ var (
 		ErrInvokeZSTDFailed,
 		ErrTrainZSTDFailed,
 		ErrWalkFilesFailed,
+		ErrTransactionConflict,
+		ErrDeletePrefixFailed,
 	}
 )
 
@@ -279,6 +283,12 @@ var (
 	ErrAddrImportFailed         = errors.New("unable to import prefunded account")
 	ErrPrefundedAcctStoreFailed = errors.New("unable to store prefunded account")
 	ErrRandomAddress            = errors.New("cannot select random address")
+	ErrKeyImportFailed          = errors.New("unable to import key")
+
+	// ErrAddrMismatch is returned when an imported private
+	// key does not derive the address it is imported for.
+	ErrAddrMismatch     = errors.New("private key does not derive address")
+	ErrAddrDeriveFailed = errors.New("unable to derive address")
 
 	KeyStorageErrs = []error{
 		ErrAddrExists,
@@ -300,6 +310,9 @@ var (
 		ErrAddrImportFailed,
 		ErrPrefundedAcctStoreFailed,
 		ErrRandomAddress,
+		ErrKeyImportFailed,
+		ErrAddrMismatch,
+		ErrAddrDeriveFailed,
 	}
 )
 
@@ -366,6 +379,41 @@ var (
 	// hash cannot be stored because it is a duplicate.
 	ErrDuplicateTransactionHash = errors.New("duplicate transaction hash")
 
+	// ErrNonContiguousBlock is returned when a block is added
+	// with an index that is not greater than the index of the
+	// current head block (without first removing the head block).
+	ErrNonContiguousBlock = errors.New("non-contiguous block")
+
+	// ErrGlobalDuplicateTransactionHash is returned when a block is
+	// added with a transaction hash that is already present in a
+	// prior block (only when transaction hashes must be unique).
+	ErrGlobalDuplicateTransactionHash = errors.New("transaction hash exists in prior block")
+
+	// ErrBlockRangeInvalid is returned when a requested
+	// block range is malformed or extends past the head block.
+	ErrBlockRangeInvalid = errors.New("invalid block range")
+
+	// ErrBlockRangeTooLarge is returned when a requested
+	// block range contains more than MaxBlockRange blocks.
+	ErrBlockRangeTooLarge = errors.New("block range too large")
+
+	// ErrBlockExportFailed is returned when a block
+	// cannot be written by ExportNDJSON.
+	ErrBlockExportFailed = errors.New("unable to export block")
+
+	// ErrBlockIdentifierInvalid is returned when a block is
+	// requested with an invalid *types.PartialBlockIdentifier.
+	ErrBlockIdentifierInvalid = errors.New("invalid block identifier")
+
+	// ErrPruneWithinReorgWindow is returned by PruneBlocks when
+	// pruning would remove the head block or any block within
+	// the reorg window (see WithReorgWindow).
+	ErrPruneWithinReorgWindow = errors.New("cannot prune blocks within reorg window")
+
+	// ErrPreCommitValidationFailed is returned when a
+	// PreCommitValidator rejects a block in AddBlock.
+	ErrPreCommitValidationFailed = errors.New("pre-commit validation failed")
+
 	ErrBlockGetFailed                  = errors.New("unable to get block")
 	ErrTransactionGetFailed            = errors.New("could not get transaction")
 	ErrBlockEncodeFailed               = errors.New("unable to encode block")
@@ -411,6 +459,14 @@ var (
 		ErrBlockNotFound,
 		ErrDuplicateKey,
 		ErrDuplicateTransactionHash,
+		ErrNonContiguousBlock,
+		ErrGlobalDuplicateTransactionHash,
+		ErrBlockRangeInvalid,
+		ErrBlockRangeTooLarge,
+		ErrBlockExportFailed,
+		ErrBlockIdentifierInvalid,
+		ErrPruneWithinReorgWindow,
+		ErrPreCommitValidationFailed,
 		ErrBlockGetFailed,
 		ErrTransactionGetFailed,
 		ErrBlockEncodeFailed,
@@ -75,7 +75,12 @@ func GetAccountKey(
 	currency *types.Currency,
 ) []byte {
 	return []byte(
-		fmt.Sprintf("%s/%s/%s", namespace, types.Hash(account), types.Hash(currency)),
+		fmt.Sprintf(
+			"%s/%s/%s",
+			namespace,
+			types.AccountIdentifierHash(account),
+			types.Hash(currency),
+		),
 	)
 }
 
@@ -90,7 +95,7 @@ func GetHistoricalBalanceKey(
 		fmt.Sprintf(
 			"%s/%s/%s/%020d",
 			historicalBalanceNamespace,
-			types.Hash(account),
+			types.AccountIdentifierHash(account),
 			types.Hash(currency),
 			blockIndex,
 		),
@@ -104,7 +109,7 @@ func GetHistoricalBalancePrefix(account *types.AccountIdentifier, currency *type
 		fmt.Sprintf(
 			"%s/%s/%s/",
 			historicalBalanceNamespace,
-			types.Hash(account),
+			types.AccountIdentifierHash(account),
 			types.Hash(currency),
 		),
 	)
@@ -893,7 +898,14 @@ func (b *BalanceStorage) UpdateBalance(
 }
 
 // GetBalance returns the balance of a types.AccountIdentifier
-// at the canonical block of a certain index.
+// at the canonical block of a certain index (the most recent
+// balance recorded at or below the index).
+//
+// A historical record is stored for every balance change (keyed
+// by account, currency, and index), so storage grows with the number
+// of balance changes. If historical balances are only needed for a
+// limited window, use PruneBalances to remove older records (querying
+// a pruned index returns ErrBalancePruned).
 func (b *BalanceStorage) GetBalance(
 	ctx context.Context,
 	account *types.AccountIdentifier,
@@ -917,8 +929,9 @@ func (b *BalanceStorage) GetBalance(
 	return amount, nil
 }
 
-// GetBalanceTransactional returns all the balances of a types.AccountIdentifier
-// and the types.BlockIdentifier it was last updated at in a database transaction.
+// GetBalanceTransactional returns the balance of a types.AccountIdentifier
+// at the canonical block of a certain index in a database transaction
+// (see GetBalance).
 func (b *BalanceStorage) GetBalanceTransactional(
 	ctx context.Context,
 	dbTx database.Transaction,
@@ -15,9 +15,11 @@
 package modules
 
 import (
+	"bufio"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"strconv"
 	"strings"
@@ -140,6 +142,20 @@ type BlockWorker interface {
 	) (database.CommitWorker, error)
 }
 
+// BlockCommitHook is invoked after a block has been durably
+// added to (adding is true) or removed from (adding is false)
+// storage.
+type BlockCommitHook func(ctx context.Context, block *types.Block, adding bool)
+
+// PreCommitValidator is invoked in AddBlock after a block (and
+// the changes of all BlockWorkers) has been written to transaction
+// but before it is committed. Returning an error aborts the commit.
+type PreCommitValidator func(
+	ctx context.Context,
+	block *types.Block,
+	transaction database.Transaction,
+) error
+
 // BlockStorage implements block specific storage methods
 // on top of a database.Database and database.Transaction interface.
 type BlockStorage struct {
@@ -147,17 +163,61 @@ type BlockStorage struct {
 
 	workers           []BlockWorker
 	workerConcurrency int
+
+	commitHooks         []BlockCommitHook
+	preCommitValidators []PreCommitValidator
+
+	clock utils.Clock
+
+	uniqueTransactionHashes bool
+
+	// reorgWindow is the number of most recent
+	// blocks that PruneBlocks will not remove.
+	reorgWindow int64
+}
+
+// BlockStorageOption is used to overwrite default values in
+// BlockStorage construction. Any BlockStorageOption not
+// provided falls back to the default value.
+type BlockStorageOption func(b *BlockStorage)
+
+// WithUniqueTransactionHashes causes AddBlock to reject
+// any block containing a transaction hash that is already
+// present in a prior canonical block. By default, the same
+// transaction hash may appear in multiple blocks.
+func WithUniqueTransactionHashes() BlockStorageOption {
+	return func(b *BlockStorage) {
+		b.uniqueTransactionHashes = true
+	}
+}
+
+// WithReorgWindow prevents PruneBlocks from removing any of
+// the window most recent blocks (which may still be needed
+// to handle a reorg). By default, PruneBlocks only refuses
+// to remove the head block.
+func WithReorgWindow(window int64) BlockStorageOption {
+	return func(b *BlockStorage) {
+		b.reorgWindow = window
+	}
 }
 
 // NewBlockStorage returns a new BlockStorage.
 func NewBlockStorage(
 	db database.Database,
 	workerConcurrency int,
+	options ...BlockStorageOption,
 ) *BlockStorage {
-	return &BlockStorage{
+	b := &BlockStorage{
 		db:                db,
 		workerConcurrency: workerConcurrency,
+		clock:             utils.RealClock{},
+	}
+
+	for _, opt := range options {
+		opt(b)
 	}
+
+	return b
 }
 
 // Initialize adds a []BlockWorker to BlockStorage. Usually
@@ -169,6 +229,44 @@ func (b *BlockStorage) Initialize(workers []BlockWorker) {
 	b.workers = workers
 }
 
+// SetClock overrides the utils.Clock used to determine
+// if a block is at tip (defaults to utils.RealClock).
+//
+// This must be called prior to syncing!
+func (b *BlockStorage) SetClock(clock utils.Clock) {
+	b.clock = clock
+}
+
+// AddCommitHook registers a BlockCommitHook that is called
+// exactly once after each successful commit in AddBlock or
+// RemoveBlock. It is not called if the commit fails or the
+// transaction is discarded.
+//
+// Hooks run synchronously (in registration order) before
+// AddBlock/RemoveBlock return, so they should be fast or
+// dispatch any slow work to a goroutine.
+//
+// This must be called prior to syncing!
+func (b *BlockStorage) AddCommitHook(hook BlockCommitHook) {
+	b.commitHooks = append(b.commitHooks, hook)
+}
+
+// AddPreCommitValidator registers a PreCommitValidator that is
+// called (in registration order) before each block is committed
+// in AddBlock. If any validator returns an error, the entire
+// block is rolled back and AddBlock returns an error wrapping
+// ErrPreCommitValidationFailed.
+//
+// The transaction provided to a validator can be used to read
+// the pending state of the block. Validators must be deterministic
+// and must not have side effects (other than writes to the
+// provided transaction), as a rejected block may be added again.
+//
+// This must be called prior to syncing!
+func (b *BlockStorage) AddPreCommitValidator(validator PreCommitValidator) {
+	b.preCommitValidators = append(b.preCommitValidators, validator)
+}
+
 func (b *BlockStorage) setOldestBlockIndex(
 	ctx context.Context,
 	dbTx database.Transaction,
@@ -354,6 +452,50 @@ func (b *BlockStorage) Prune(
 	return -1, -1, ctx.Err()
 }
 
+// PruneBlocks removes block and transaction data from
+// all blocks with index < beforeIndex (see Prune) and
+// returns the number of blocks pruned (including any
+// omitted blocks).
+//
+// If any block within the reorg window (see WithReorgWindow)
+// or the head block would be pruned, PruneBlocks returns
+// ErrPruneWithinReorgWindow without pruning any blocks.
+func (b *BlockStorage) PruneBlocks(
+	ctx context.Context,
+	beforeIndex int64,
+) (int64, error) {
+	head, err := b.GetHeadBlockIdentifier(ctx)
+	if err != nil {
+		return -1, fmt.Errorf("%w: %v", storageErrs.ErrHeadBlockGetFailed, err)
+	}
+
+	window := b.reorgWindow
+	if window < 1 {
+		window = 1
+	}
+
+	if beforeIndex > head.Index-window+1 {
+		return -1, fmt.Errorf(
+			"%w: cannot prune before %d (head: %d, window: %d)",
+			storageErrs.ErrPruneWithinReorgWindow,
+			beforeIndex,
+			head.Index,
+			window,
+		)
+	}
+
+	firstPruned, lastPruned, err := b.Prune(ctx, beforeIndex-1, window)
+	if err != nil {
+		return -1, err
+	}
+
+	if firstPruned == -1 {
+		return 0, nil
+	}
+
+	return lastPruned - firstPruned + 1, nil
+}
+
 // GetHeadBlockIdentifier returns the head block identifier,
 // if it exists.
 func (b *BlockStorage) GetHeadBlockIdentifier(
@@ -417,6 +559,12 @@ func (b *BlockStorage) GetBlockLazyTransactional(
 	blockIdentifier *types.PartialBlockIdentifier,
 	transaction database.Transaction,
 ) (*types.BlockResponse, error) {
+	if err := types.ValidatePartialBlockIdentifier(blockIdentifier); err != nil {
+		return nil, fmt.Errorf("%w: %v", storageErrs.ErrBlockIdentifierInvalid, err)
+	}
+
+	lookupMode := types.PartialBlockIdentifierLookupMode(blockIdentifier)
+
 	var namespace string
 	var key []byte
 	var exists bool
@@ -452,8 +600,9 @@ func (b *BlockStorage) GetBlockLazyTransactional(
 
 	if !exists {
 		return nil, fmt.Errorf(
-			"%w: %s",
+			"%w: %s lookup of %s",
 			storageErrs.ErrBlockNotFound,
+			lookupMode,
 			types.PrintStruct(blockIdentifier),
 		)
 	}
@@ -468,6 +617,19 @@ func (b *BlockStorage) GetBlockLazyTransactional(
 		return nil, err
 	}
 
+	// When both index and hash are provided, the block
+	// is looked up by hash and must have the provided index.
+	if lookupMode == types.LookupByIndexAndHash &&
+		rosettaBlockResponse.Block.BlockIdentifier.Index != *blockIdentifier.Index {
+		return nil, fmt.Errorf(
+			"%w: %s lookup of %s (found index %d)",
+			storageErrs.ErrBlockNotFound,
+			lookupMode,
+			types.PrintStruct(blockIdentifier),
+			rosettaBlockResponse.Block.BlockIdentifier.Index,
+		)
+	}
+
 	return &rosettaBlockResponse, nil
 }
 
@@ -586,6 +748,277 @@ func (b *BlockStorage) GetBlock(
 	return b.GetBlockTransactional(ctx, transaction, blockIdentifier)
 }
 
+// MaxBlockRange is the maximum number of blocks that
+// can be retrieved with a single call to GetBlockRange.
+const MaxBlockRange = 1000
+
+// GetBlockRange returns all blocks in the inclusive range
+// [startIndex, endIndex] (in order) using a single read
+// transaction. Omitted blocks are returned as nil entries.
+// An error is returned if the range contains more than
+// MaxBlockRange blocks or extends past the head block.
+func (b *BlockStorage) GetBlockRange(
+	ctx context.Context,
+	startIndex int64,
+	endIndex int64,
+) ([]*types.Block, error) {
+	if startIndex < 0 || endIndex < startIndex {
+		return nil, fmt.Errorf(
+			"%w: [%d, %d]",
+			storageErrs.ErrBlockRangeInvalid,
+			startIndex,
+			endIndex,
+		)
+	}
+
+	if endIndex-startIndex+1 > MaxBlockRange {
+		return nil, fmt.Errorf(
+			"%w: [%d, %d] contains more than %d blocks",
+			storageErrs.ErrBlockRangeTooLarge,
+			startIndex,
+			endIndex,
+			MaxBlockRange,
+		)
+	}
+
+	transaction := b.db.ReadTransaction(ctx)
+	defer transaction.Discard(ctx)
+
+	head, err := b.GetHeadBlockIdentifierTransactional(ctx, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	if endIndex > head.Index {
+		return nil, fmt.Errorf(
+			"%w: end index %d is after head block %d",
+			storageErrs.ErrBlockRangeInvalid,
+			endIndex,
+			head.Index,
+		)
+	}
+
+	blocks := make([]*types.Block, 0, endIndex-startIndex+1)
+	for i := startIndex; i <= endIndex; i++ {
+		index := i
+		block, err := b.GetBlockTransactional(
+			ctx,
+			transaction,
+			&types.PartialBlockIdentifier{Index: &index},
+		)
+		if errors.Is(err, storageErrs.ErrBlockNotFound) {
+			blocks = append(blocks, nil)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: unable to get block %d", err, index)
+		}
+
+		blocks = append(blocks, block)
+	}
+
+	return blocks, nil
+}
+
+// exportFlushInterval is the number of blocks
+// ExportNDJSON writes between each flush.
+const exportFlushInterval = 100
+
+// ExportNDJSON writes all blocks in the inclusive range
+// [startIndex, endIndex] (in order) to w as newline-delimited JSON
+// (one block per line) using a single read transaction. Omitted
+// blocks are skipped. Unlike GetBlockRange, blocks are not held in
+// memory so the range is not limited by MaxBlockRange.
+func (b *BlockStorage) ExportNDJSON(
+	ctx context.Context,
+	w io.Writer,
+	startIndex int64,
+	endIndex int64,
+) error {
+	if startIndex < 0 || endIndex < startIndex {
+		return fmt.Errorf(
+			"%w: [%d, %d]",
+			storageErrs.ErrBlockRangeInvalid,
+			startIndex,
+			endIndex,
+		)
+	}
+
+	transaction := b.db.ReadTransaction(ctx)
+	defer transaction.Discard(ctx)
+
+	head, err := b.GetHeadBlockIdentifierTransactional(ctx, transaction)
+	if err != nil {
+		return err
+	}
+
+	if endIndex > head.Index {
+		return fmt.Errorf(
+			"%w: end index %d is after head block %d",
+			storageErrs.ErrBlockRangeInvalid,
+			endIndex,
+			head.Index,
+		)
+	}
+
+	buffered := bufio.NewWriter(w)
+	for i := startIndex; i <= endIndex; i++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		index := i
+		block, err := b.GetBlockTransactional(
+			ctx,
+			transaction,
+			&types.PartialBlockIdentifier{Index: &index},
+		)
+		if errors.Is(err, storageErrs.ErrBlockNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%w: unable to get block %d", err, index)
+		}
+
+		if err := types.WriteStruct(buffered, block); err != nil {
+			return fmt.Errorf("%w %d: %v", storageErrs.ErrBlockExportFailed, index, err)
+		}
+
+		if (index-startIndex+1)%exportFlushInterval == 0 {
+			if err := buffered.Flush(); err != nil {
+				return fmt.Errorf("%w %d: %v", storageErrs.ErrBlockExportFailed, index, err)
+			}
+		}
+	}
+
+	if err := buffered.Flush(); err != nil {
+		return fmt.Errorf("%w: %v", storageErrs.ErrBlockExportFailed, err)
+	}
+
+	return nil
+}
+
+// IterateBlocks invokes fn with each stored block (in ascending
+// order) starting at startIndex (or the oldest unpruned block, if
+// it is later) through the head block using a single read
+// transaction. Omitted blocks are skipped. Iteration stops when fn
+// returns an error (which is returned) or there are no more blocks.
+// Blocks are loaded one at a time, so this can be used to walk all
+// stored blocks without holding them in memory.
+func (b *BlockStorage) IterateBlocks(
+	ctx context.Context,
+	startIndex int64,
+	fn func(*types.Block) error,
+) error {
+	transaction := b.db.ReadTransaction(ctx)
+	defer transaction.Discard(ctx)
+
+	head, err := b.GetHeadBlockIdentifierTransactional(ctx, transaction)
+	if errors.Is(err, storageErrs.ErrHeadBlockNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	oldestIndex, err := b.GetOldestBlockIndexTransactional(ctx, transaction)
+	if err != nil {
+		return fmt.Errorf("%w: %v", storageErrs.ErrOldestIndexRead, err)
+	}
+
+	if startIndex < oldestIndex {
+		startIndex = oldestIndex
+	}
+
+	for i := startIndex; i <= head.Index; i++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		index := i
+		block, err := b.GetBlockTransactional(
+			ctx,
+			transaction,
+			&types.PartialBlockIdentifier{Index: &index},
+		)
+		if errors.Is(err, storageErrs.ErrBlockNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%w: unable to get block %d", err, index)
+		}
+
+		if err := fn(block); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FindGaps returns the inclusive ranges of indices in
+// [startIndex, endIndex] that have no stored block (in
+// ascending order). Only the presence of each block index key
+// is checked, so blocks are never loaded or decoded.
+func (b *BlockStorage) FindGaps(
+	ctx context.Context,
+	startIndex int64,
+	endIndex int64,
+) ([][2]int64, error) {
+	if startIndex < 0 || endIndex < startIndex {
+		return nil, fmt.Errorf(
+			"%w: [%d, %d]",
+			storageErrs.ErrBlockRangeInvalid,
+			startIndex,
+			endIndex,
+		)
+	}
+
+	transaction := b.db.ReadTransaction(ctx)
+	defer transaction.Discard(ctx)
+
+	head, err := b.GetHeadBlockIdentifierTransactional(ctx, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	if endIndex > head.Index {
+		return nil, fmt.Errorf(
+			"%w: end index %d is after head block %d",
+			storageErrs.ErrBlockRangeInvalid,
+			endIndex,
+			head.Index,
+		)
+	}
+
+	gaps := [][2]int64{}
+	gapStart := int64(-1)
+	for i := startIndex; i <= endIndex; i++ {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		exists, _, err := transaction.Get(ctx, getBlockIndexKey(i))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", storageErrs.ErrBlockGetFailed, err)
+		}
+
+		switch {
+		case !exists && gapStart == -1:
+			gapStart = i
+		case exists && gapStart != -1:
+			gaps = append(gaps, [2]int64{gapStart, i - 1})
+			gapStart = -1
+		}
+	}
+
+	if gapStart != -1 {
+		gaps = append(gaps, [2]int64{gapStart, endIndex})
+	}
+
+	return gaps, nil
+}
+
 func (b *BlockStorage) seeBlock(
 	ctx context.Context,
 	transaction database.Transaction,
@@ -643,6 +1076,23 @@ func (b *BlockStorage) storeBlock(
 		return fmt.Errorf("%w: %v", storageErrs.ErrBlockIndexStoreFailed, err)
 	}
 
+	// We refuse to move the head backwards (or to replace it) unless
+	// the head is first removed with RemoveBlock. Otherwise, a block
+	// committed out-of-order could corrupt the head pointer.
+	head, err := b.GetHeadBlockIdentifierTransactional(ctx, transaction)
+	if err != nil && !errors.Is(err, storageErrs.ErrHeadBlockNotFound) {
+		return fmt.Errorf("%w: %v", storageErrs.ErrHeadBlockGetFailed, err)
+	}
+
+	if head != nil && blockIdentifier.Index <= head.Index {
+		return fmt.Errorf(
+			"%w: block %d is not greater than head block %d",
+			storageErrs.ErrNonContiguousBlock,
+			blockIdentifier.Index,
+			head.Index,
+		)
+	}
+
 	if err := b.StoreHeadBlockIdentifier(ctx, transaction, blockIdentifier); err != nil {
 		return fmt.Errorf("%w: %v", storageErrs.ErrBlockIdentifierUpdateFailed, err)
 	}
@@ -732,21 +1182,180 @@ func (b *BlockStorage) SeeBlock(
 	return transaction.Commit(ctx)
 }
 
-// AddBlock stores a block or returns an error.
+// AddBlock stores a block or returns an error. If the index
+// of the block is not greater than the index of the current
+// head block, ErrNonContiguousBlock is returned (the head
+// block must be removed with RemoveBlock first).
 func (b *BlockStorage) AddBlock(
 	ctx context.Context,
 	block *types.Block,
 ) error {
-	transaction := b.db.WriteTransaction(ctx, blockSyncIdentifier, true)
-	defer transaction.Discard(ctx)
+	var commitWorkers []database.CommitWorker
+	err := b.db.RunInTransaction(
+		ctx,
+		blockSyncIdentifier,
+		true,
+		func(transaction database.Transaction) error {
+			var err error
+			commitWorkers, err = b.addBlock(ctx, transaction, block)
+			return err
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return b.runCommitWorkers(ctx, block, true, commitWorkers)
+}
 
+// AddBlocks stores a contiguous run of blocks (each block
+// must be the parent of the next) in a single database
+// transaction, which is much faster than invoking AddBlock
+// for each block during an initial sync. All checks performed
+// by AddBlock are applied to each block (including the check
+// for duplicate transaction hashes in prior blocks, if
+// WithUniqueTransactionHashes is provided, which considers
+// earlier blocks in the batch). If any block is rejected,
+// none of the blocks are stored. If a commit worker fails
+// after the blocks are stored, the commit hooks and workers
+// of the remaining blocks are still run and the first
+// commit worker error is returned.
+//
+// Large batches may exceed the maximum size of a
+// database transaction, so batches should be kept
+// to a moderate number of blocks.
+func (b *BlockStorage) AddBlocks(
+	ctx context.Context,
+	blocks []*types.Block,
+) error {
+	for i := 1; i < len(blocks); i++ {
+		if types.Hash(blocks[i].ParentBlockIdentifier) !=
+			types.Hash(blocks[i-1].BlockIdentifier) {
+			return fmt.Errorf(
+				"%w: parent of block %s is not %s",
+				storageErrs.ErrNonContiguousBlock,
+				types.PrintStruct(blocks[i].BlockIdentifier),
+				types.PrintStruct(blocks[i-1].BlockIdentifier),
+			)
+		}
+	}
+
+	commitWorkers := make([][]database.CommitWorker, len(blocks))
+	err := b.db.RunInTransaction(
+		ctx,
+		blockSyncIdentifier,
+		true,
+		func(transaction database.Transaction) error {
+			for i, block := range blocks {
+				var err error
+				commitWorkers[i], err = b.addBlock(ctx, transaction, block)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// All blocks are committed, so the commit hooks and
+	// workers of every block are run even if a commit
+	// worker of an earlier block fails.
+	var firstErr error
+	for i, block := range blocks {
+		err := b.runCommitWorkers(ctx, block, true, commitWorkers[i])
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// addBlock stores a block within transaction and
+// returns the CommitWorkers to run after the
+// transaction is committed.
+func (b *BlockStorage) addBlock(
+	ctx context.Context,
+	transaction database.Transaction,
+	block *types.Block,
+) ([]database.CommitWorker, error) {
 	// Store block
 	err := b.storeBlock(ctx, transaction, block.BlockIdentifier)
 	if err != nil {
-		return fmt.Errorf("%w: %v", storageErrs.ErrBlockStoreFailed, err)
+		return nil, fmt.Errorf("%w: %v", storageErrs.ErrBlockStoreFailed, err)
+	}
+
+	if b.uniqueTransactionHashes {
+		if err := b.checkPriorTransactions(ctx, transaction, block); err != nil {
+			return nil, err
+		}
+	}
+
+	commitWorkers, err := b.callWorkers(ctx, block, transaction, true)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, validator := range b.preCommitValidators {
+		if err := validator(ctx, block, transaction); err != nil {
+			return nil, fmt.Errorf("%w: %v", storageErrs.ErrPreCommitValidationFailed, err)
+		}
+	}
+
+	return commitWorkers, nil
+}
+
+// checkPriorTransactions returns an error if any transaction
+// in block was already included in a prior canonical block.
+func (b *BlockStorage) checkPriorTransactions(
+	ctx context.Context,
+	transaction database.Transaction,
+	block *types.Block,
+) error {
+	for _, tx := range block.Transactions {
+		blockTransactions, err := b.getAllTransactionsByIdentifier(
+			ctx,
+			tx.TransactionIdentifier,
+			transaction,
+		)
+		if err != nil {
+			return fmt.Errorf("%w: %v", storageErrs.ErrTransactionDBQueryFailed, err)
+		}
+
+		for _, blockTransaction := range blockTransactions {
+			priorBlock := blockTransaction.BlockIdentifier
+			if priorBlock.Index >= block.BlockIdentifier.Index {
+				continue
+			}
+
+			// Transactions are stored when a block is seen, so
+			// we must ensure the prior block is canonical (and not
+			// just a seen block on a fork).
+			exists, hashKey, err := transaction.Get(ctx, getBlockIndexKey(priorBlock.Index))
+			if err != nil {
+				return fmt.Errorf("%w: %v", storageErrs.ErrBlockGetFailed, err)
+			}
+
+			_, priorHashKey := getBlockHashKey(priorBlock.Hash)
+			if !exists || string(hashKey) != string(priorHashKey) {
+				continue
+			}
+
+			return fmt.Errorf(
+				"%w: transaction %s in block %s was already included in block %s",
+				storageErrs.ErrGlobalDuplicateTransactionHash,
+				tx.TransactionIdentifier.Hash,
+				types.PrintStruct(block.BlockIdentifier),
+				types.PrintStruct(priorBlock),
+			)
+		}
 	}
 
-	return b.callWorkersAndCommit(ctx, block, transaction, true)
+	return nil
 }
 
 func (b *BlockStorage) deleteBlock(
@@ -838,6 +1447,26 @@ func (b *BlockStorage) callWorkersAndCommit(
 	txn database.Transaction,
 	adding bool,
 ) error {
+	commitWorkers, err := b.callWorkers(ctx, block, txn, adding)
+	if err != nil {
+		return err
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return err
+	}
+
+	return b.runCommitWorkers(ctx, block, adding, commitWorkers)
+}
+
+// callWorkers invokes all BlockWorkers on block within txn
+// and returns the resulting CommitWorkers.
+func (b *BlockStorage) callWorkers(
+	ctx context.Context,
+	block *types.Block,
+	txn database.Transaction,
+	adding bool,
+) ([]database.CommitWorker, error) {
 	commitWorkers := make([]database.CommitWorker, len(b.workers))
 
 	// Provision global errgroup to use for all workers
@@ -853,18 +1482,32 @@ func (b *BlockStorage) callWorkersAndCommit(
 			cw, err = w.RemovingBlock(gctx, g, block, txn)
 		}
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		commitWorkers[i] = cw
 	}
 
 	if err := g.Wait(); err != nil {
-		return err
+		return nil, err
 	}
 
-	if err := txn.Commit(ctx); err != nil {
-		return err
+	return commitWorkers, nil
+}
+
+// runCommitWorkers invokes all commit hooks and commitWorkers
+// after a block has been committed.
+func (b *BlockStorage) runCommitWorkers(
+	ctx context.Context,
+	block *types.Block,
+	adding bool,
+	commitWorkers []database.CommitWorker,
+) error {
+	// Commit hooks are invoked before commit workers so that
+	// a commit worker error cannot suppress a hook for a
+	// block that was already committed.
+	for _, hook := range b.commitHooks {
+		hook(ctx, block, adding)
 	}
 
 	for _, cw := range commitWorkers {
@@ -1367,7 +2010,7 @@ func (b *BlockStorage) AtTipTransactional(
 	}
 	block := blockResponse.Block
 
-	atTip := utils.AtTip(tipDelay, block.Timestamp)
+	atTip := utils.AtTipWithClock(b.clock, tipDelay, block.Timestamp)
 	if !atTip {
 		return false, nil, nil
 	}
@@ -1413,7 +2056,7 @@ func (b *BlockStorage) IndexAtTip(
 	// tip.
 	headBlock := headBlockResponse.Block
 	if headBlock.BlockIdentifier.Index < index {
-		return utils.AtTip(tipDelay, headBlock.Timestamp), nil
+		return utils.AtTipWithClock(b.clock, tipDelay, headBlock.Timestamp), nil
 	}
 
 	// Query block at index
@@ -1427,5 +2070,5 @@ func (b *BlockStorage) IndexAtTip(
 	}
 	block := blockResponse.Block
 
-	return utils.AtTip(tipDelay, block.Timestamp), nil
+	return utils.AtTipWithClock(b.clock, tipDelay, block.Timestamp), nil
 }
@@ -15,13 +15,22 @@
 package modules
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
+	mocks "github.com/coinbase/rosetta-sdk-go/mocks/storage/modules"
+	mockUtils "github.com/coinbase/rosetta-sdk-go/mocks/utils"
+	"github.com/coinbase/rosetta-sdk-go/storage/database"
 	storageErrs "github.com/coinbase/rosetta-sdk-go/storage/errors"
 	"github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/coinbase/rosetta-sdk-go/utils"
@@ -46,15 +55,8 @@ func TestHeadBlockIdentifier(t *testing.T) {
 
 	ctx := context.Background()
 
-	newDir, err := utils.CreateTempDir()
-	assert.NoError(t, err)
-	defer utils.RemoveTempDir(newDir)
-
-	database, err := newTestBadgerDatabase(ctx, newDir)
-	assert.NoError(t, err)
-	defer database.Close(ctx)
-
-	storage := NewBlockStorage(database, blockWorkerConcurrency)
+	storage, cleanup := newTestBlockStorage(t)
+	defer cleanup()
 
 	t.Run("No head block set", func(t *testing.T) {
 		blockIdentifier, err := storage.GetHeadBlockIdentifier(ctx)
@@ -454,6 +456,34 @@ func TestBlock(t *testing.T) {
 		assert.Nil(t, block)
 	})
 
+	t.Run("Get block with invalid identifier", func(t *testing.T) {
+		for _, identifier := range []*types.PartialBlockIdentifier{
+			{Index: types.Int64(-1)},
+			{Hash: types.String("")},
+		} {
+			block, err := storage.GetBlock(ctx, identifier)
+			assert.True(
+				t,
+				errors.Is(err, storageErrs.ErrBlockIdentifierInvalid),
+			)
+			assert.Nil(t, block)
+		}
+	})
+
+	t.Run("Get block with mismatched index and hash", func(t *testing.T) {
+		identifier := &types.PartialBlockIdentifier{
+			Index: types.Int64(newBlock.BlockIdentifier.Index + 1),
+			Hash:  types.String(newBlock.BlockIdentifier.Hash),
+		}
+		block, err := storage.GetBlock(ctx, identifier)
+		assert.True(
+			t,
+			errors.Is(err, storageErrs.ErrBlockNotFound),
+		)
+		assert.Contains(t, err.Error(), string(types.LookupByIndexAndHash))
+		assert.Nil(t, block)
+	})
+
 	t.Run("Set duplicate block hash", func(t *testing.T) {
 		err = storage.AddBlock(ctx, newBlock)
 		assert.Contains(t, err.Error(), storageErrs.ErrDuplicateKey.Error())
@@ -723,6 +753,350 @@ func TestBlock(t *testing.T) {
 	})
 }
 
+func TestUniqueTransactionHashes(t *testing.T) {
+	ctx := context.Background()
+
+	var tests = map[string]struct {
+		options []BlockStorageOption
+		err     error
+	}{
+		"permissive (default)": {},
+		"unique transaction hashes": {
+			options: []BlockStorageOption{WithUniqueTransactionHashes()},
+			err:     storageErrs.ErrGlobalDuplicateTransactionHash,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			newDir, err := utils.CreateTempDir()
+			assert.NoError(t, err)
+			defer utils.RemoveTempDir(newDir)
+
+			database, err := newTestBadgerDatabase(ctx, newDir)
+			assert.NoError(t, err)
+			defer database.Close(ctx)
+
+			storage := NewBlockStorage(database, blockWorkerConcurrency, test.options...)
+			for _, block := range []*types.Block{genesisBlock, newBlock} {
+				assert.NoError(t, storage.SeeBlock(ctx, block))
+				assert.NoError(t, storage.AddBlock(ctx, block))
+			}
+
+			// newBlock2 contains the same transaction hash as newBlock.
+			assert.NoError(t, storage.SeeBlock(ctx, newBlock2))
+			err = storage.AddBlock(ctx, newBlock2)
+			head, headErr := storage.GetHeadBlockIdentifier(ctx)
+			assert.NoError(t, headErr)
+			if test.err == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, newBlock2.BlockIdentifier, head)
+				return
+			}
+
+			assert.True(t, errors.Is(err, test.err))
+			assert.Equal(t, newBlock.BlockIdentifier, head)
+
+			// Once the prior block is orphaned, the transaction
+			// can be included in a block on the new fork.
+			forkBlock := &types.Block{
+				BlockIdentifier: &types.BlockIdentifier{
+					Hash:  "blah 1 fork",
+					Index: 1,
+				},
+				ParentBlockIdentifier: genesisBlock.BlockIdentifier,
+				Timestamp:             1,
+				Transactions:          newBlock.Transactions,
+			}
+			assert.NoError(t, storage.RemoveBlock(ctx, newBlock.BlockIdentifier))
+			assert.NoError(t, storage.SeeBlock(ctx, forkBlock))
+			assert.NoError(t, storage.AddBlock(ctx, forkBlock))
+		})
+	}
+}
+
+func TestGetBlockRange(t *testing.T) {
+	ctx := context.Background()
+
+	newDir, err := utils.CreateTempDir()
+	assert.NoError(t, err)
+	defer utils.RemoveTempDir(newDir)
+
+	database, err := newTestBadgerDatabase(ctx, newDir)
+	assert.NoError(t, err)
+	defer database.Close(ctx)
+
+	storage := NewBlockStorage(database, blockWorkerConcurrency)
+
+	t.Run("no blocks", func(t *testing.T) {
+		blocks, err := storage.GetBlockRange(ctx, 0, 0)
+		assert.True(t, errors.Is(err, storageErrs.ErrHeadBlockNotFound))
+		assert.Nil(t, blocks)
+	})
+
+	// Block 2 is omitted
+	gapBlock := &types.Block{
+		BlockIdentifier: &types.BlockIdentifier{
+			Hash:  "blah 3",
+			Index: 3,
+		},
+		ParentBlockIdentifier: newBlock.BlockIdentifier,
+		Timestamp:             1,
+	}
+	for _, block := range []*types.Block{genesisBlock, newBlock, gapBlock} {
+		assert.NoError(t, storage.SeeBlock(ctx, block))
+		assert.NoError(t, storage.AddBlock(ctx, block))
+	}
+
+	t.Run("range spanning gap", func(t *testing.T) {
+		blocks, err := storage.GetBlockRange(ctx, 0, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, []*types.Block{genesisBlock, newBlock, nil, gapBlock}, blocks)
+	})
+
+	t.Run("single block", func(t *testing.T) {
+		blocks, err := storage.GetBlockRange(ctx, 1, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []*types.Block{newBlock}, blocks)
+	})
+
+	t.Run("range past head", func(t *testing.T) {
+		blocks, err := storage.GetBlockRange(ctx, 2, 4)
+		assert.True(t, errors.Is(err, storageErrs.ErrBlockRangeInvalid))
+		assert.Nil(t, blocks)
+	})
+
+	t.Run("end before start", func(t *testing.T) {
+		blocks, err := storage.GetBlockRange(ctx, 3, 1)
+		assert.True(t, errors.Is(err, storageErrs.ErrBlockRangeInvalid))
+		assert.Nil(t, blocks)
+	})
+
+	t.Run("range too large", func(t *testing.T) {
+		blocks, err := storage.GetBlockRange(ctx, 0, MaxBlockRange)
+		assert.True(t, errors.Is(err, storageErrs.ErrBlockRangeTooLarge))
+		assert.Nil(t, blocks)
+	})
+}
+
+func TestExportNDJSON(t *testing.T) {
+	ctx := context.Background()
+
+	storage, cleanup := newTestBlockStorage(t)
+	defer cleanup()
+
+	t.Run("no blocks", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := storage.ExportNDJSON(ctx, &buf, 0, 0)
+		assert.True(t, errors.Is(err, storageErrs.ErrHeadBlockNotFound))
+		assert.Equal(t, 0, buf.Len())
+	})
+
+	// Block 2 is omitted
+	gapBlock := &types.Block{
+		BlockIdentifier: &types.BlockIdentifier{
+			Hash:  "blah 3",
+			Index: 3,
+		},
+		ParentBlockIdentifier: newBlock.BlockIdentifier,
+		Timestamp:             1,
+	}
+	for _, block := range []*types.Block{genesisBlock, newBlock, gapBlock} {
+		assert.NoError(t, storage.SeeBlock(ctx, block))
+		assert.NoError(t, storage.AddBlock(ctx, block))
+	}
+
+	t.Run("range spanning gap", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, storage.ExportNDJSON(ctx, &buf, 0, 3))
+
+		blocks := []*types.Block{}
+		scanner := bufio.NewScanner(&buf)
+		for scanner.Scan() {
+			var block types.Block
+			assert.NoError(t, json.Unmarshal(scanner.Bytes(), &block))
+			blocks = append(blocks, &block)
+		}
+		assert.NoError(t, scanner.Err())
+		assert.Equal(t, []*types.Block{genesisBlock, newBlock, gapBlock}, blocks)
+	})
+
+	t.Run("omitted block only", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, storage.ExportNDJSON(ctx, &buf, 2, 2))
+		assert.Equal(t, 0, buf.Len())
+	})
+
+	t.Run("range past head", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := storage.ExportNDJSON(ctx, &buf, 2, 4)
+		assert.True(t, errors.Is(err, storageErrs.ErrBlockRangeInvalid))
+	})
+
+	t.Run("end before start", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := storage.ExportNDJSON(ctx, &buf, 3, 1)
+		assert.True(t, errors.Is(err, storageErrs.ErrBlockRangeInvalid))
+	})
+}
+
+func TestIterateBlocks(t *testing.T) {
+	ctx := context.Background()
+
+	storage, cleanup := newTestBlockStorage(t)
+	defer cleanup()
+
+	iterate := func(startIndex int64, stopAt int64) ([]int64, error) {
+		indices := []int64{}
+		err := storage.IterateBlocks(ctx, startIndex, func(block *types.Block) error {
+			indices = append(indices, block.BlockIdentifier.Index)
+			if block.BlockIdentifier.Index == stopAt {
+				return errors.New("stop")
+			}
+
+			return nil
+		})
+
+		return indices, err
+	}
+
+	t.Run("no blocks", func(t *testing.T) {
+		indices, err := iterate(0, -1)
+		assert.NoError(t, err)
+		assert.Empty(t, indices)
+	})
+
+	// Indices 2-99 are omitted
+	blocks := chainBlocks(0, 1, "")
+	gapBlock := chainBlocks(100, 100, "")[0]
+	gapBlock.ParentBlockIdentifier = blocks[1].BlockIdentifier
+	blocks = append(blocks, gapBlock)
+	for _, block := range blocks {
+		assert.NoError(t, storage.SeeBlock(ctx, block))
+		assert.NoError(t, storage.AddBlock(ctx, block))
+	}
+
+	t.Run("all blocks", func(t *testing.T) {
+		blockCount := 0
+		err := storage.IterateBlocks(ctx, 0, func(block *types.Block) error {
+			assert.Equal(t, blocks[blockCount], block)
+			blockCount++
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, len(blocks), blockCount)
+	})
+
+	t.Run("start in gap", func(t *testing.T) {
+		indices, err := iterate(2, -1)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{100}, indices)
+	})
+
+	t.Run("start after head", func(t *testing.T) {
+		indices, err := iterate(101, -1)
+		assert.NoError(t, err)
+		assert.Empty(t, indices)
+	})
+
+	t.Run("stop on error", func(t *testing.T) {
+		indices, err := iterate(0, 1)
+		assert.EqualError(t, err, "stop")
+		assert.Equal(t, []int64{0, 1}, indices)
+	})
+}
+
+func TestFindGaps(t *testing.T) {
+	ctx := context.Background()
+
+	storage, cleanup := newTestBlockStorage(t)
+	defer cleanup()
+
+	t.Run("no blocks", func(t *testing.T) {
+		gaps, err := storage.FindGaps(ctx, 0, 0)
+		assert.True(t, errors.Is(err, storageErrs.ErrHeadBlockNotFound))
+		assert.Nil(t, gaps)
+	})
+
+	// Blocks 2, 4, and 5 are omitted
+	blocks := []*types.Block{genesisBlock, newBlock}
+	parent := newBlock.BlockIdentifier
+	for _, index := range []int64{3, 6, 7} {
+		block := &types.Block{
+			BlockIdentifier: &types.BlockIdentifier{
+				Hash:  fmt.Sprintf("gap block %d", index),
+				Index: index,
+			},
+			ParentBlockIdentifier: parent,
+			Timestamp:             1,
+		}
+		blocks = append(blocks, block)
+		parent = block.BlockIdentifier
+	}
+	for _, block := range blocks {
+		assert.NoError(t, storage.SeeBlock(ctx, block))
+		assert.NoError(t, storage.AddBlock(ctx, block))
+	}
+
+	var tests = map[string]struct {
+		startIndex int64
+		endIndex   int64
+
+		gaps [][2]int64
+		err  error
+	}{
+		"no gaps": {
+			startIndex: 0,
+			endIndex:   1,
+			gaps:       [][2]int64{},
+		},
+		"single gap": {
+			startIndex: 0,
+			endIndex:   3,
+			gaps:       [][2]int64{{2, 2}},
+		},
+		"multiple gaps": {
+			startIndex: 0,
+			endIndex:   7,
+			gaps:       [][2]int64{{2, 2}, {4, 5}},
+		},
+		"gap at end of range": {
+			startIndex: 3,
+			endIndex:   4,
+			gaps:       [][2]int64{{4, 4}},
+		},
+		"range inside gap": {
+			startIndex: 5,
+			endIndex:   5,
+			gaps:       [][2]int64{{5, 5}},
+		},
+		"range past head": {
+			startIndex: 6,
+			endIndex:   8,
+			err:        storageErrs.ErrBlockRangeInvalid,
+		},
+		"end before start": {
+			startIndex: 3,
+			endIndex:   1,
+			err:        storageErrs.ErrBlockRangeInvalid,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gaps, err := storage.FindGaps(ctx, test.startIndex, test.endIndex)
+			if test.err != nil {
+				assert.True(t, errors.Is(err, test.err))
+				assert.Nil(t, gaps)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.gaps, gaps)
+		})
+	}
+}
+
 func TestManyBlocks(t *testing.T) {
 	ctx := context.Background()
 
@@ -775,6 +1149,552 @@ func TestManyBlocks(t *testing.T) {
 	assert.True(t, errors.Is(err, storageErrs.ErrCannotAccessPrunedData))
 }
 
+func TestPruneBlocks(t *testing.T) {
+	ctx := context.Background()
+
+	storage, cleanup := newTestBlockStorage(t, WithReorgWindow(100))
+	defer cleanup()
+
+	for i := int64(0); i < 10000; i++ {
+		parentBlockIndex := i - 1
+		if parentBlockIndex < 0 {
+			parentBlockIndex = 0
+		}
+
+		block := &types.Block{
+			BlockIdentifier: &types.BlockIdentifier{
+				Index: i,
+				Hash:  fmt.Sprintf("block %d", i),
+			},
+			ParentBlockIdentifier: &types.BlockIdentifier{
+				Index: parentBlockIndex,
+				Hash:  fmt.Sprintf("block %d", parentBlockIndex),
+			},
+			Transactions: []*types.Transaction{
+				{
+					TransactionIdentifier: &types.TransactionIdentifier{
+						Hash: fmt.Sprintf("tx %d", i),
+					},
+				},
+			},
+		}
+
+		assert.NoError(t, storage.SeeBlock(ctx, block))
+		assert.NoError(t, storage.AddBlock(ctx, block))
+	}
+
+	t.Run("within reorg window", func(t *testing.T) {
+		pruned, err := storage.PruneBlocks(ctx, 9901)
+		assert.Equal(t, int64(-1), pruned)
+		assert.True(t, errors.Is(err, storageErrs.ErrPruneWithinReorgWindow))
+
+		oldestIndex, err := storage.GetOldestBlockIndex(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), oldestIndex)
+	})
+
+	t.Run("prune before 9000", func(t *testing.T) {
+		pruned, err := storage.PruneBlocks(ctx, 9000)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(9000), pruned)
+
+		for _, index := range []int64{0, 4500, 8999} {
+			i := index
+			_, err := storage.GetBlock(ctx, &types.PartialBlockIdentifier{Index: &i})
+			assert.True(t, errors.Is(err, storageErrs.ErrCannotAccessPrunedData))
+
+			_, err = storage.GetBlockTransaction(
+				ctx,
+				&types.BlockIdentifier{Index: i, Hash: fmt.Sprintf("block %d", i)},
+				&types.TransactionIdentifier{Hash: fmt.Sprintf("tx %d", i)},
+			)
+			assert.True(t, errors.Is(err, storageErrs.ErrCannotAccessPrunedData))
+		}
+
+		for _, index := range []int64{9000, 9500, 9999} {
+			i := index
+			block, err := storage.GetBlock(ctx, &types.PartialBlockIdentifier{Index: &i})
+			assert.NoError(t, err)
+			assert.Equal(t, i, block.BlockIdentifier.Index)
+			assert.Len(t, block.Transactions, 1)
+		}
+
+		head, err := storage.GetHeadBlockIdentifier(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(9999), head.Index)
+	})
+
+	t.Run("nothing to prune", func(t *testing.T) {
+		pruned, err := storage.PruneBlocks(ctx, 9000)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), pruned)
+	})
+}
+
+func chainBlocks(start int64, end int64, fork string) []*types.Block {
+	blocks := []*types.Block{}
+	for i := start; i <= end; i++ {
+		parentBlockIndex := i - 1
+		if parentBlockIndex < 0 {
+			parentBlockIndex = 0
+		}
+
+		blocks = append(blocks, &types.Block{
+			BlockIdentifier: &types.BlockIdentifier{
+				Index: i,
+				Hash:  fmt.Sprintf("block %s%d", fork, i),
+			},
+			ParentBlockIdentifier: &types.BlockIdentifier{
+				Index: parentBlockIndex,
+				Hash:  fmt.Sprintf("block %s%d", fork, parentBlockIndex),
+			},
+			Transactions: []*types.Transaction{
+				{
+					TransactionIdentifier: &types.TransactionIdentifier{
+						Hash: fmt.Sprintf("tx %s%d", fork, i),
+					},
+				},
+			},
+		})
+	}
+
+	return blocks
+}
+
+func TestAddBlocks(t *testing.T) {
+	ctx := context.Background()
+
+	singleStorage, singleCleanup := newTestBlockStorage(t, WithUniqueTransactionHashes())
+	defer singleCleanup()
+
+	batchStorage, batchCleanup := newTestBlockStorage(t, WithUniqueTransactionHashes())
+	defer batchCleanup()
+
+	batchHooks := 0
+	batchStorage.AddCommitHook(func(ctx context.Context, block *types.Block, adding bool) {
+		batchHooks++
+	})
+
+	blocks := chainBlocks(0, 999, "")
+	for _, block := range blocks {
+		assert.NoError(t, singleStorage.SeeBlock(ctx, block))
+		assert.NoError(t, singleStorage.AddBlock(ctx, block))
+		assert.NoError(t, batchStorage.SeeBlock(ctx, block))
+	}
+
+	t.Run("batch equivalent to single", func(t *testing.T) {
+		assert.NoError(t, batchStorage.AddBlocks(ctx, blocks))
+		assert.Equal(t, len(blocks), batchHooks)
+
+		singleHead, err := singleStorage.GetHeadBlockIdentifier(ctx)
+		assert.NoError(t, err)
+		batchHead, err := batchStorage.GetHeadBlockIdentifier(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, blocks[999].BlockIdentifier, batchHead)
+		assert.Equal(t, singleHead, batchHead)
+
+		for _, block := range blocks {
+			index := block.BlockIdentifier.Index
+			singleBlock, err := singleStorage.GetBlock(
+				ctx,
+				&types.PartialBlockIdentifier{Index: &index},
+			)
+			assert.NoError(t, err)
+			batchBlock, err := batchStorage.GetBlock(
+				ctx,
+				&types.PartialBlockIdentifier{Index: &index},
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, singleBlock, batchBlock)
+
+			tx := block.Transactions[0].TransactionIdentifier
+			singleTxn := singleStorage.db.ReadTransaction(ctx)
+			singleID, singleTx, err := singleStorage.FindTransaction(ctx, tx, singleTxn)
+			assert.NoError(t, err)
+			singleTxn.Discard(ctx)
+
+			batchTxn := batchStorage.db.ReadTransaction(ctx)
+			batchID, batchTx, err := batchStorage.FindTransaction(ctx, tx, batchTxn)
+			assert.NoError(t, err)
+			batchTxn.Discard(ctx)
+
+			assert.Equal(t, block.BlockIdentifier, batchID)
+			assert.Equal(t, singleID, batchID)
+			assert.Equal(t, singleTx, batchTx)
+		}
+	})
+
+	t.Run("not parent-linked", func(t *testing.T) {
+		batch := chainBlocks(1000, 1002, "")
+		batch[2].ParentBlockIdentifier = blocks[999].BlockIdentifier
+		err := batchStorage.AddBlocks(ctx, batch)
+		assert.True(t, errors.Is(err, storageErrs.ErrNonContiguousBlock))
+	})
+
+	t.Run("duplicate transaction rolls back batch", func(t *testing.T) {
+		batch := chainBlocks(1000, 1002, "")
+		batch[2].Transactions = batch[0].Transactions
+		for _, block := range batch {
+			assert.NoError(t, batchStorage.SeeBlock(ctx, block))
+		}
+
+		hooks := batchHooks
+		err := batchStorage.AddBlocks(ctx, batch)
+		assert.True(t, errors.Is(err, storageErrs.ErrGlobalDuplicateTransactionHash))
+		assert.Equal(t, hooks, batchHooks)
+
+		head, err := batchStorage.GetHeadBlockIdentifier(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, blocks[999].BlockIdentifier, head)
+	})
+
+	t.Run("commit worker error runs all hooks", func(t *testing.T) {
+		storage, cleanup := newTestBlockStorage(t)
+		defer cleanup()
+
+		mockWorker := &mocks.BlockWorker{}
+		storage.Initialize([]BlockWorker{mockWorker})
+
+		hooks := []int64{}
+		storage.AddCommitHook(func(ctx context.Context, block *types.Block, adding bool) {
+			hooks = append(hooks, block.BlockIdentifier.Index)
+		})
+
+		batch := chainBlocks(0, 4, "")
+		workerErr := errors.New("commit worker failed")
+		committed := []int64{}
+		for _, block := range batch {
+			assert.NoError(t, storage.SeeBlock(ctx, block))
+
+			index := block.BlockIdentifier.Index
+			commitWorker := func(ctx context.Context) error {
+				committed = append(committed, index)
+				if index == 2 {
+					return workerErr
+				}
+
+				return nil
+			}
+			mockWorker.On(
+				"AddingBlock",
+				mock.Anything,
+				mock.Anything,
+				block,
+				mock.Anything,
+			).Return(database.CommitWorker(commitWorker), nil).Once()
+		}
+
+		err := storage.AddBlocks(ctx, batch)
+		assert.True(t, errors.Is(err, workerErr))
+		assert.Equal(t, []int64{0, 1, 2, 3, 4}, hooks)
+		assert.Equal(t, []int64{0, 1, 2, 3, 4}, committed)
+
+		head, err := storage.GetHeadBlockIdentifier(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, batch[4].BlockIdentifier, head)
+		mockWorker.AssertExpectations(t)
+	})
+}
+
+func TestAddBlockOutOfOrder(t *testing.T) {
+	ctx := context.Background()
+
+	newDir, err := utils.CreateTempDir()
+	assert.NoError(t, err)
+	defer utils.RemoveTempDir(newDir)
+
+	database, err := newTestBadgerDatabase(ctx, newDir)
+	assert.NoError(t, err)
+	defer database.Close(ctx)
+
+	storage := NewBlockStorage(database, blockWorkerConcurrency)
+
+	blocks := make([]*types.Block, 100)
+	for i := range blocks {
+		parentIndex := int64(i - 1)
+		if parentIndex < 0 {
+			parentIndex = 0
+		}
+
+		blocks[i] = &types.Block{
+			BlockIdentifier: &types.BlockIdentifier{
+				Index: int64(i),
+				Hash:  fmt.Sprintf("block %d", i),
+			},
+			ParentBlockIdentifier: &types.BlockIdentifier{
+				Index: parentIndex,
+				Hash:  fmt.Sprintf("block %d", parentIndex),
+			},
+		}
+	}
+
+	t.Run("concurrent misordered writes", func(t *testing.T) {
+		var (
+			wg       sync.WaitGroup
+			m        sync.Mutex
+			maxAdded int64 = -1
+		)
+		for _, block := range blocks {
+			assert.NoError(t, storage.SeeBlock(ctx, block))
+		}
+
+		for _, block := range blocks {
+			wg.Add(1)
+			go func(block *types.Block) {
+				defer wg.Done()
+
+				err := storage.AddBlock(ctx, block)
+				if err != nil {
+					assert.Contains(t, err.Error(), storageErrs.ErrNonContiguousBlock.Error())
+					return
+				}
+
+				m.Lock()
+				if block.BlockIdentifier.Index > maxAdded {
+					maxAdded = block.BlockIdentifier.Index
+				}
+				m.Unlock()
+			}(block)
+		}
+		wg.Wait()
+
+		// The head must be the highest block added, regardless
+		// of the order in which blocks were committed.
+		head, err := storage.GetHeadBlockIdentifier(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, maxAdded, head.Index)
+		assert.Equal(t, blocks[maxAdded].BlockIdentifier, head)
+	})
+
+	t.Run("add block lower than head", func(t *testing.T) {
+		otherDir, err := utils.CreateTempDir()
+		assert.NoError(t, err)
+		defer utils.RemoveTempDir(otherDir)
+
+		otherDatabase, err := newTestBadgerDatabase(ctx, otherDir)
+		assert.NoError(t, err)
+		defer otherDatabase.Close(ctx)
+
+		otherStorage := NewBlockStorage(otherDatabase, blockWorkerConcurrency)
+		for _, block := range blocks[:3] {
+			assert.NoError(t, otherStorage.SeeBlock(ctx, block))
+		}
+
+		assert.NoError(t, otherStorage.AddBlock(ctx, blocks[0]))
+		assert.NoError(t, otherStorage.AddBlock(ctx, blocks[2]))
+
+		err = otherStorage.AddBlock(ctx, blocks[1])
+		assert.Contains(t, err.Error(), storageErrs.ErrNonContiguousBlock.Error())
+
+		head, err := otherStorage.GetHeadBlockIdentifier(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, blocks[2].BlockIdentifier, head)
+
+		// Adding a different block at the head index is only
+		// allowed after the head is removed (i.e. in a reorg).
+		otherBlock := &types.Block{
+			BlockIdentifier: &types.BlockIdentifier{
+				Index: 2,
+				Hash:  "other block 2",
+			},
+			ParentBlockIdentifier: blocks[1].BlockIdentifier,
+		}
+		assert.NoError(t, otherStorage.SeeBlock(ctx, otherBlock))
+		assert.Error(t, otherStorage.AddBlock(ctx, otherBlock))
+		assert.NoError(t, otherStorage.RemoveBlock(ctx, blocks[2].BlockIdentifier))
+		assert.NoError(t, otherStorage.AddBlock(ctx, otherBlock))
+
+		head, err = otherStorage.GetHeadBlockIdentifier(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, otherBlock.BlockIdentifier, head)
+	})
+}
+
+type commitHookCall struct {
+	block  *types.BlockIdentifier
+	adding bool
+}
+
+func TestCommitHook(t *testing.T) {
+	ctx := context.Background()
+
+	newDir, err := utils.CreateTempDir()
+	assert.NoError(t, err)
+	defer utils.RemoveTempDir(newDir)
+
+	db, err := newTestBadgerDatabase(ctx, newDir)
+	assert.NoError(t, err)
+	defer db.Close(ctx)
+
+	storage := NewBlockStorage(db, blockWorkerConcurrency)
+	mockWorker := &mocks.BlockWorker{}
+	storage.Initialize([]BlockWorker{mockWorker})
+
+	calls := []commitHookCall{}
+	storage.AddCommitHook(func(ctx context.Context, block *types.Block, adding bool) {
+		calls = append(calls, commitHookCall{block: block.BlockIdentifier, adding: adding})
+	})
+
+	blocks := make([]*types.Block, 3)
+	for i := range blocks {
+		parentIndex := int64(i - 1)
+		if parentIndex < 0 {
+			parentIndex = 0
+		}
+
+		blocks[i] = &types.Block{
+			BlockIdentifier: &types.BlockIdentifier{
+				Index: int64(i),
+				Hash:  fmt.Sprintf("block %d", i),
+			},
+			ParentBlockIdentifier: &types.BlockIdentifier{
+				Index: parentIndex,
+				Hash:  fmt.Sprintf("block %d", parentIndex),
+			},
+		}
+		assert.NoError(t, storage.SeeBlock(ctx, blocks[i]))
+	}
+
+	t.Run("add and remove blocks", func(t *testing.T) {
+		mockWorker.On(
+			"AddingBlock",
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+		).Return(nil, nil).Twice()
+		assert.NoError(t, storage.AddBlock(ctx, blocks[0]))
+		assert.NoError(t, storage.AddBlock(ctx, blocks[1]))
+
+		mockWorker.On(
+			"RemovingBlock",
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+		).Return(nil, nil).Once()
+		assert.NoError(t, storage.RemoveBlock(ctx, blocks[1].BlockIdentifier))
+
+		assert.Equal(t, []commitHookCall{
+			{block: blocks[0].BlockIdentifier, adding: true},
+			{block: blocks[1].BlockIdentifier, adding: true},
+			{block: blocks[1].BlockIdentifier, adding: false},
+		}, calls)
+	})
+
+	t.Run("rejected block", func(t *testing.T) {
+		calls = []commitHookCall{}
+		assert.Error(t, storage.AddBlock(ctx, blocks[0]))
+		assert.Len(t, calls, 0)
+	})
+
+	t.Run("worker error causes rollback", func(t *testing.T) {
+		calls = []commitHookCall{}
+		workerErr := errors.New("worker failed")
+		mockWorker.On(
+			"AddingBlock",
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+		).Return(nil, workerErr).Once()
+		assert.ErrorIs(t, storage.AddBlock(ctx, blocks[1]), workerErr)
+		assert.Len(t, calls, 0)
+
+		head, err := storage.GetHeadBlockIdentifier(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, blocks[0].BlockIdentifier, head)
+	})
+
+	t.Run("commit worker error", func(t *testing.T) {
+		calls = []commitHookCall{}
+		mockWorker.On(
+			"AddingBlock",
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+		).Return(database.CommitWorker(func(context.Context) error {
+			return errors.New("commit worker failed")
+		}), nil).Once()
+		assert.Error(t, storage.AddBlock(ctx, blocks[1]))
+
+		// The block was committed, so the hook must still fire.
+		assert.Equal(t, []commitHookCall{
+			{block: blocks[1].BlockIdentifier, adding: true},
+		}, calls)
+	})
+
+	mockWorker.AssertExpectations(t)
+}
+
+func TestPreCommitValidator(t *testing.T) {
+	ctx := context.Background()
+
+	storage, cleanup := newTestBlockStorage(t)
+	defer cleanup()
+
+	storage.Initialize([]BlockWorker{})
+
+	calls := []commitHookCall{}
+	storage.AddCommitHook(func(ctx context.Context, block *types.Block, adding bool) {
+		calls = append(calls, commitHookCall{block: block.BlockIdentifier, adding: adding})
+	})
+
+	validationErr := errors.New("block 1 is invalid")
+	storage.AddPreCommitValidator(func(
+		ctx context.Context,
+		block *types.Block,
+		transaction database.Transaction,
+	) error {
+		// The pending head is visible to the validator
+		head, err := storage.GetHeadBlockIdentifierTransactional(ctx, transaction)
+		assert.NoError(t, err)
+		assert.Equal(t, block.BlockIdentifier, head)
+
+		if block.BlockIdentifier.Index == 1 {
+			return validationErr
+		}
+
+		return nil
+	})
+
+	blocks := make([]*types.Block, 2)
+	for i := range blocks {
+		blocks[i] = &types.Block{
+			BlockIdentifier: &types.BlockIdentifier{
+				Index: int64(i),
+				Hash:  fmt.Sprintf("block %d", i),
+			},
+			ParentBlockIdentifier: &types.BlockIdentifier{
+				Index: 0,
+				Hash:  "block 0",
+			},
+		}
+		assert.NoError(t, storage.SeeBlock(ctx, blocks[i]))
+	}
+
+	assert.NoError(t, storage.AddBlock(ctx, blocks[0]))
+
+	err := storage.AddBlock(ctx, blocks[1])
+	assert.True(t, errors.Is(err, storageErrs.ErrPreCommitValidationFailed))
+	assert.Contains(t, err.Error(), validationErr.Error())
+
+	// The rejected block is rolled back
+	head, err := storage.GetHeadBlockIdentifier(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[0].BlockIdentifier, head)
+
+	_, err = storage.GetBlock(ctx, &types.PartialBlockIdentifier{
+		Index: &blocks[1].BlockIdentifier.Index,
+	})
+	assert.True(t, errors.Is(err, storageErrs.ErrBlockNotFound))
+
+	assert.Equal(t, []commitHookCall{
+		{block: blocks[0].BlockIdentifier, adding: true},
+	}, calls)
+}
+
 func TestCreateBlockCache(t *testing.T) {
 	ctx := context.Background()
 
@@ -931,6 +1851,26 @@ func TestAtTip(t *testing.T) {
 		assert.NoError(t, err)
 		assert.True(t, atTip)
 	})
+
+	t.Run("Clock advances past tip delay", func(t *testing.T) {
+		mockClock := &mockUtils.Clock{}
+		storage.SetClock(mockClock)
+		defer storage.SetClock(utils.RealClock{})
+
+		mockClock.On("Now").Return(time.Now().Add(
+			2 * time.Duration(tipDelay) * time.Second,
+		))
+
+		atTip, blockIdentifier, err := storage.AtTip(ctx, tipDelay)
+		assert.NoError(t, err)
+		assert.False(t, atTip)
+		assert.Nil(t, blockIdentifier)
+
+		atTip, err = storage.IndexAtTip(ctx, tipDelay, 1)
+		assert.NoError(t, err)
+		assert.False(t, atTip)
+		mockClock.AssertExpectations(t)
+	})
 }
 
 func TestRelatedTransactions(t *testing.T) {
@@ -253,6 +253,32 @@ func (c *CoinStorage) skipOperation(
 	return false, nil
 }
 
+// coinAmount returns the *types.Amount of the coin
+// created (or restored) by an operation. When a block
+// is removed, coins spent in the block are restored with
+// the absolute value of the amount spent (which is
+// usually negative).
+func coinAmount(operation *types.Operation) (*types.Amount, error) {
+	if operation.CoinChange.CoinAction != types.CoinSpent {
+		return operation.Amount, nil
+	}
+
+	value, err := types.AmountValue(operation.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	if value.Sign() >= 0 {
+		return operation.Amount, nil
+	}
+
+	return &types.Amount{
+		Value:    new(big.Int).Abs(value).String(),
+		Currency: operation.Amount.Currency,
+		Metadata: operation.Amount.Metadata,
+	}, nil
+}
+
 // updateCoins iterates through the transactions
 // in a block to determine which coins to add
 // and remove from storage.
@@ -304,6 +330,11 @@ func (c *CoinStorage) updateCoins( // nolint:gocognit
 			continue
 		}
 
+		amount, err := coinAmount(val)
+		if err != nil {
+			return fmt.Errorf("%w: %v", errors.ErrCoinParseFailed, err)
+		}
+
 		// We need to set variable before calling goroutine
 		// to avoid getting an updated pointer as loop iteration
 		// continues.
@@ -314,7 +345,7 @@ func (c *CoinStorage) updateCoins( // nolint:gocognit
 				op.Account,
 				&types.Coin{
 					CoinIdentifier: op.CoinChange.CoinIdentifier,
-					Amount:         op.Amount,
+					Amount:         amount,
 				},
 				dbTx,
 			); err != nil {
@@ -995,3 +995,146 @@ func TestCoinStorage(t *testing.T) {
 
 	mockHelper.AssertExpectations(t)
 }
+
+func coinOperation(
+	account *types.AccountIdentifier,
+	value string,
+	action types.CoinAction,
+	identifier string,
+) *types.Operation {
+	return &types.Operation{
+		Account: account,
+		Status:  successStatus,
+		Amount: &types.Amount{
+			Value:    value,
+			Currency: currency,
+		},
+		CoinChange: &types.CoinChange{
+			CoinAction:     action,
+			CoinIdentifier: &types.CoinIdentifier{Identifier: identifier},
+		},
+	}
+}
+
+func coinOperationsBlock(operations ...*types.Operation) *types.Block {
+	return &types.Block{
+		Transactions: []*types.Transaction{
+			{
+				Operations: operations,
+			},
+		},
+	}
+}
+
+func TestCoinStorageReorg(t *testing.T) {
+	ctx := context.Background()
+
+	db, cleanup := newTestDatabase(t)
+	defer cleanup()
+
+	a, err := asserter.NewClientWithOptions(
+		&types.NetworkIdentifier{
+			Blockchain: "bitcoin",
+			Network:    "mainnet",
+		},
+		&types.BlockIdentifier{
+			Hash:  "block 0",
+			Index: 0,
+		},
+		[]string{"Transfer"},
+		[]*types.OperationStatus{
+			{
+				Status:     *successStatus,
+				Successful: true,
+			},
+		},
+		[]*types.Error{},
+		nil,
+		&asserter.Validations{
+			Enabled: false,
+		},
+	)
+	assert.NoError(t, err)
+
+	mockHelper := &mocks.CoinStorageHelper{}
+	mockHelper.On(
+		"CurrentBlockIdentifier",
+		ctx,
+		mock.Anything,
+	).Return(
+		blockIdentifier,
+		nil,
+	)
+
+	c := NewCoinStorage(db, mockHelper, a)
+	updateBlock := func(block *types.Block, adding bool) {
+		tx := c.db.Transaction(ctx)
+		defer tx.Discard(ctx)
+
+		update := c.RemovingBlock
+		if adding {
+			update = c.AddingBlock
+		}
+
+		g, gctx := errgroup.WithContext(ctx)
+		commitFunc, err := update(gctx, g, block, tx)
+		assert.Nil(t, commitFunc)
+		assert.NoError(t, err)
+		assert.NoError(t, g.Wait())
+		assert.NoError(t, tx.Commit(ctx))
+	}
+	assertCoins := func(account *types.AccountIdentifier, expected []*types.Coin) {
+		coins, _, err := c.GetCoins(ctx, account)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, expected, coins)
+	}
+	coin := func(identifier string, value string) *types.Coin {
+		return &types.Coin{
+			CoinIdentifier: &types.CoinIdentifier{Identifier: identifier},
+			Amount: &types.Amount{
+				Value:    value,
+				Currency: currency,
+			},
+		}
+	}
+
+	createBlock := coinOperationsBlock(
+		coinOperation(account, "10", types.CoinCreated, "reorgCoin1"),
+	)
+	spendBlock := coinOperationsBlock(
+		coinOperation(account, "-10", types.CoinSpent, "reorgCoin1"),
+		coinOperation(account2, "7", types.CoinCreated, "reorgCoin2"),
+		coinOperation(account, "3", types.CoinCreated, "reorgCoin3"),
+	)
+	forkBlock := coinOperationsBlock(
+		coinOperation(account, "-10", types.CoinSpent, "reorgCoin1"),
+		coinOperation(account3, "10", types.CoinCreated, "reorgCoin4"),
+	)
+
+	t.Run("create and spend", func(t *testing.T) {
+		updateBlock(createBlock, true)
+		assertCoins(account, []*types.Coin{coin("reorgCoin1", "10")})
+
+		updateBlock(spendBlock, true)
+		assertCoins(account, []*types.Coin{coin("reorgCoin3", "3")})
+		assertCoins(account2, []*types.Coin{coin("reorgCoin2", "7")})
+	})
+
+	t.Run("reorg restores spent coin", func(t *testing.T) {
+		updateBlock(spendBlock, false)
+		assertCoins(account, []*types.Coin{coin("reorgCoin1", "10")})
+		assertCoins(account2, []*types.Coin{})
+
+		updateBlock(forkBlock, true)
+		assertCoins(account, []*types.Coin{})
+		assertCoins(account2, []*types.Coin{})
+		assertCoins(account3, []*types.Coin{coin("reorgCoin4", "10")})
+	})
+
+	t.Run("remove all blocks", func(t *testing.T) {
+		updateBlock(forkBlock, false)
+		updateBlock(createBlock, false)
+		assertCoins(account, []*types.Coin{})
+		assertCoins(account3, []*types.Coin{})
+	})
+}
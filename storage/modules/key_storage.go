@@ -19,9 +19,16 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sort"
+	"strings"
+	"sync"
 
+	"golang.org/x/sync/errgroup"
+
+	"github.com/coinbase/rosetta-sdk-go/asserter"
 	"github.com/coinbase/rosetta-sdk-go/keys"
 	"github.com/coinbase/rosetta-sdk-go/storage/database"
+	"github.com/coinbase/rosetta-sdk-go/storage/encoder"
 	storageErrs "github.com/coinbase/rosetta-sdk-go/storage/errors"
 	"github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/coinbase/rosetta-sdk-go/utils"
@@ -40,6 +47,10 @@ type PrefundedAccount struct {
 
 const (
 	keyNamespace = "key"
+
+	// importBatchSize is the maximum number of accounts
+	// stored in each database transaction by ImportAccounts.
+	importBatchSize = 100
 )
 
 func getAccountKey(account *types.AccountIdentifier) []byte {
@@ -51,16 +62,89 @@ func getAccountKey(account *types.AccountIdentifier) []byte {
 // KeyStorage implements key storage methods
 // on top of a database.Database and database.Transaction interface.
 type KeyStorage struct {
-	db database.Database
+	db      database.Database
+	encoder *encoder.Encoder
+
+	importConcurrency int
+	addressDeriver    AddressDeriver
+}
+
+// KeyStorageOption is used to overwrite default values in
+// KeyStorage construction. Any KeyStorageOption not
+// provided falls back to the default value.
+type KeyStorageOption func(k *KeyStorage)
+
+// WithKeyEncoder overrides the *encoder.Encoder used to
+// serialize keys (by default, the database.Database encoder
+// is used). This allows keys to be stored with different (or
+// no) compression than other records in the same database.
+//
+// Keys are not stored with any record of the encoder used to
+// write them, so a KeyStorage must always be opened with the
+// same encoder configuration that was used to store its keys.
+func WithKeyEncoder(e *encoder.Encoder) KeyStorageOption {
+	return func(k *KeyStorage) {
+		k.encoder = e
+	}
+}
+
+// WithImportConcurrency overrides the number of accounts
+// ImportAccounts will derive keys for concurrently (by
+// default, keys are derived serially). A concurrency < 1
+// is treated as 1.
+func WithImportConcurrency(concurrency int) KeyStorageOption {
+	return func(k *KeyStorage) {
+		if concurrency < 1 {
+			concurrency = 1
+		}
+
+		k.importConcurrency = concurrency
+	}
+}
+
+// AddressDeriver returns the *types.AccountIdentifier
+// controlled by a *types.PublicKey. If an address cannot
+// be deterministically derived for the public key's curve,
+// AddressDeriver should return a nil *types.AccountIdentifier.
+type AddressDeriver func(
+	ctx context.Context,
+	publicKey *types.PublicKey,
+) (*types.AccountIdentifier, error)
+
+// WithVerifyAddresses ensures ImportAccounts only stores
+// keys that derive (using deriver) the address they are
+// imported for.
+func WithVerifyAddresses(deriver AddressDeriver) KeyStorageOption {
+	return func(k *KeyStorage) {
+		k.addressDeriver = deriver
+	}
 }
 
 // NewKeyStorage returns a new KeyStorage.
 func NewKeyStorage(
 	db database.Database,
+	options ...KeyStorageOption,
 ) *KeyStorage {
-	return &KeyStorage{
-		db: db,
+	k := &KeyStorage{
+		db:                db,
+		importConcurrency: 1,
+	}
+
+	for _, opt := range options {
+		opt(k)
+	}
+
+	return k
+}
+
+// Encoder returns the *encoder.Encoder used to
+// serialize keys.
+func (k *KeyStorage) Encoder() *encoder.Encoder {
+	if k.encoder != nil {
+		return k.encoder
 	}
+
+	return k.db.Encoder()
 }
 
 // Key is the struct stored in key storage. This
@@ -96,7 +180,7 @@ func (k *KeyStorage) StoreTransactional(
 		)
 	}
 
-	val, err := k.db.Encoder().Encode("", &Key{
+	val, err := k.Encoder().Encode("", &Key{
 		Account: account,
 		KeyPair: keyPair,
 	})
@@ -119,14 +203,16 @@ func (k *KeyStorage) Store(
 	account *types.AccountIdentifier,
 	keyPair *keys.KeyPair,
 ) error {
-	dbTx := k.db.Transaction(ctx)
-	defer dbTx.Discard(ctx)
-
-	if err := k.StoreTransactional(ctx, account, keyPair, dbTx); err != nil {
-		return fmt.Errorf("%w: unable to store key", err)
+	var storeErr error
+	err := k.db.RunInTransaction(ctx, "", false, func(dbTx database.Transaction) error {
+		storeErr = k.StoreTransactional(ctx, account, keyPair, dbTx)
+		return storeErr
+	})
+	if storeErr != nil {
+		return fmt.Errorf("%w: unable to store key", storeErr)
 	}
 
-	if err := dbTx.Commit(ctx); err != nil {
+	if err != nil {
 		return fmt.Errorf("%w: %v", storageErrs.ErrCommitKeyFailed, err)
 	}
 
@@ -155,7 +241,7 @@ func (k *KeyStorage) GetTransactional(
 	}
 
 	var kp Key
-	if err := k.db.Encoder().Decode("", rawKey, &kp, true); err != nil {
+	if err := k.Encoder().Decode("", rawKey, &kp, true); err != nil {
 		return nil, fmt.Errorf("%w: %v", storageErrs.ErrParseSavedKeyFailed, err)
 	}
 
@@ -173,12 +259,82 @@ func (k *KeyStorage) Get(
 	return k.GetTransactional(ctx, transaction, account)
 }
 
+// keyAccount is used to decode only the Account
+// of a stored Key (skipping the KeyPair).
+type keyAccount struct {
+	Account *types.AccountIdentifier `json:"account"`
+}
+
+// ScanAccountsTransactional invokes worker with each AccountIdentifier
+// in key storage. Only the account of each stored key is decoded, so
+// private key material is never loaded into a *keys.KeyPair.
+func (k *KeyStorage) ScanAccountsTransactional(
+	ctx context.Context,
+	dbTx database.Transaction,
+	worker func(*types.AccountIdentifier) error,
+) error {
+	_, err := dbTx.Scan(
+		ctx,
+		[]byte(keyNamespace),
+		[]byte(keyNamespace),
+		func(key []byte, v []byte) error {
+			var ka keyAccount
+			// We should not reclaim memory during a scan!!
+			if err := k.Encoder().Decode("", v, &ka, false); err != nil {
+				return fmt.Errorf("%w: %v", storageErrs.ErrKeyScanFailed, err)
+			}
+
+			return worker(ka.Account)
+		},
+		false,
+		false,
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %v", storageErrs.ErrKeyScanFailed, err)
+	}
+
+	return nil
+}
+
 // GetAllAccountsTransactional returns all AccountIdentifiers in key storage.
 func (k *KeyStorage) GetAllAccountsTransactional(
 	ctx context.Context,
 	dbTx database.Transaction,
 ) ([]*types.AccountIdentifier, error) {
 	accounts := []*types.AccountIdentifier{}
+	err := k.ScanAccountsTransactional(
+		ctx,
+		dbTx,
+		func(account *types.AccountIdentifier) error {
+			accounts = append(accounts, account)
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+// GetAllAccounts returns all AccountIdentifiers in key storage.
+func (k *KeyStorage) GetAllAccounts(ctx context.Context) ([]*types.AccountIdentifier, error) {
+	dbTx := k.db.ReadTransaction(ctx)
+	defer dbTx.Discard(ctx)
+
+	return k.GetAllAccountsTransactional(ctx, dbTx)
+}
+
+// Export returns all *Key stored in key storage. This
+// is useful for migrating key storage between machines.
+//
+// WARNING: THE RETURNED KEYS INCLUDE PRIVATE KEY MATERIAL!!!!
+// DO NOT LOG OR PERSIST THEM ANYWHERE THAT IS NOT SECURE!!!!
+func (k *KeyStorage) Export(ctx context.Context) ([]*Key, error) {
+	dbTx := k.db.ReadTransaction(ctx)
+	defer dbTx.Discard(ctx)
+
+	exportedKeys := []*Key{}
 	_, err := dbTx.Scan(
 		ctx,
 		[]byte(keyNamespace),
@@ -186,11 +342,11 @@ func (k *KeyStorage) GetAllAccountsTransactional(
 		func(key []byte, v []byte) error {
 			var kp Key
 			// We should not reclaim memory during a scan!!
-			if err := k.db.Encoder().Decode("", v, &kp, false); err != nil {
+			if err := k.Encoder().Decode("", v, &kp, false); err != nil {
 				return fmt.Errorf("%w: %v", storageErrs.ErrKeyScanFailed, err)
 			}
 
-			accounts = append(accounts, kp.Account)
+			exportedKeys = append(exportedKeys, &kp)
 			return nil
 		},
 		false,
@@ -200,24 +356,57 @@ func (k *KeyStorage) GetAllAccountsTransactional(
 		return nil, fmt.Errorf("%w: %v", storageErrs.ErrKeyScanFailed, err)
 	}
 
-	return accounts, nil
+	return exportedKeys, nil
 }
 
-// GetAllAccounts returns all AccountIdentifiers in key storage.
-func (k *KeyStorage) GetAllAccounts(ctx context.Context) ([]*types.AccountIdentifier, error) {
-	dbTx := k.db.ReadTransaction(ctx)
-	defer dbTx.Discard(ctx)
+// Import stores a collection of *Key (usually returned
+// by Export) in key storage. Any *Key with an account
+// that already exists in key storage is skipped.
+func (k *KeyStorage) Import(ctx context.Context, importedKeys []*Key) error {
+	for _, key := range importedKeys {
+		if err := asserter.AccountIdentifier(key.Account); err != nil {
+			return fmt.Errorf("%w: %v", storageErrs.ErrKeyImportFailed, err)
+		}
 
-	return k.GetAllAccountsTransactional(ctx, dbTx)
+		if key.KeyPair == nil {
+			return fmt.Errorf(
+				"%w: keypair missing for %s",
+				storageErrs.ErrKeyImportFailed,
+				types.PrintStruct(key.Account),
+			)
+		}
+
+		if err := key.KeyPair.IsValid(); err != nil {
+			return fmt.Errorf("%w: %v", storageErrs.ErrKeyImportFailed, err)
+		}
+
+		// Skip if key already exists
+		err := k.Store(ctx, key.Account, key.KeyPair)
+		if errors.Is(err, storageErrs.ErrAddrExists) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %v", storageErrs.ErrKeyImportFailed, err)
+		}
+	}
+
+	return nil
 }
 
 // Sign attempts to sign a slice of *types.SigningPayload with the keys in KeyStorage.
+// Each *types.SigningPayload is checked with asserter.SigningPayload (a valid
+// AccountIdentifier, non-empty and non-zero Bytes, and a supported SignatureType
+// if one is populated) immediately before it is signed.
 func (k *KeyStorage) Sign(
 	ctx context.Context,
 	payloads []*types.SigningPayload,
 ) ([]*types.Signature, error) {
 	signatures := make([]*types.Signature, len(payloads))
 	for i, payload := range payloads {
+		if err := asserter.SigningPayload(payload); err != nil {
+			return nil, fmt.Errorf("%w: signing payload %d is invalid", err, i)
+		}
+
 		keyPair, err := k.Get(ctx, payload.AccountIdentifier)
 		if err != nil {
 			return nil, fmt.Errorf(
@@ -267,22 +456,196 @@ func (k *KeyStorage) RandomAccount(ctx context.Context) (*types.AccountIdentifie
 	return accounts[randomNumber.Int64()], nil
 }
 
+// ImportAccountsError is returned by ImportAccounts when
+// any account cannot be imported. Errors are keyed by the
+// index of the account in the provided slice.
+type ImportAccountsError struct {
+	Errors map[int]error
+}
+
+// Error returns the errors for each account (in order).
+func (e *ImportAccountsError) Error() string {
+	indices := make([]int, 0, len(e.Errors))
+	for index := range e.Errors {
+		indices = append(indices, index)
+	}
+	sort.Ints(indices)
+
+	messages := make([]string, len(indices))
+	for i, index := range indices {
+		messages[i] = fmt.Sprintf("account %d: %v", index, e.Errors[index])
+	}
+
+	return fmt.Sprintf(
+		"unable to import %d accounts: %s",
+		len(indices),
+		strings.Join(messages, "; "),
+	)
+}
+
+// Is returns true if the error for any account
+// matches target.
+func (e *ImportAccountsError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// importKey imports the private key of a *PrefundedAccount
+// and, if an AddressDeriver is configured, verifies that it
+// derives the address of the account.
+func (k *KeyStorage) importKey(
+	ctx context.Context,
+	acc *PrefundedAccount,
+) (*keys.KeyPair, error) {
+	keyPair, err := keys.ImportPrivateKey(acc.PrivateKeyHex, acc.CurveType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", storageErrs.ErrAddrImportFailed, err)
+	}
+
+	if k.addressDeriver == nil {
+		return keyPair, nil
+	}
+
+	derived, err := k.addressDeriver(ctx, keyPair.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", storageErrs.ErrAddrDeriveFailed, err)
+	}
+
+	// Skip verification if the address cannot be derived
+	if derived == nil {
+		return keyPair, nil
+	}
+
+	if derived.Address != acc.AccountIdentifier.Address {
+		return nil, fmt.Errorf(
+			"%w: private key derives %s but account is %s",
+			storageErrs.ErrAddrMismatch,
+			derived.Address,
+			acc.AccountIdentifier.Address,
+		)
+	}
+
+	return keyPair, nil
+}
+
+// deriveImportKeys imports the private key of each account
+// using importConcurrency goroutines. Failures are recorded
+// in importErrs (the corresponding keypair is left nil).
+func (k *KeyStorage) deriveImportKeys(
+	ctx context.Context,
+	accounts []*PrefundedAccount,
+	importErrs map[int]error,
+) ([]*keys.KeyPair, error) {
+	keyPairs := make([]*keys.KeyPair, len(accounts))
+	indices := make(chan int)
+	var errsLock sync.Mutex
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		defer close(indices)
+		for i := range accounts {
+			select {
+			case indices <- i:
+			case <-gctx.Done():
+				return gctx.Err()
+			}
+		}
+
+		return nil
+	})
+
+	for i := 0; i < k.importConcurrency; i++ {
+		g.Go(func() error {
+			for index := range indices {
+				keyPair, err := k.importKey(gctx, accounts[index])
+				if err != nil {
+					errsLock.Lock()
+					importErrs[index] = err
+					errsLock.Unlock()
+					continue
+				}
+
+				keyPairs[index] = keyPair
+			}
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return keyPairs, nil
+}
+
 // ImportAccounts loads a set of prefunded accounts into key storage.
+// Keys are derived concurrently (see WithImportConcurrency) and stored
+// in batches of database transactions. Any account that already exists
+// in key storage is skipped.
+//
+// If WithVerifyAddresses is provided, any account whose
+// private key does not derive its address is not imported.
+//
+// If any account cannot be imported, all other accounts are still
+// imported and an *ImportAccountsError is returned.
 func (k *KeyStorage) ImportAccounts(ctx context.Context, accounts []*PrefundedAccount) error {
-	for _, acc := range accounts {
-		keyPair, err := keys.ImportPrivateKey(acc.PrivateKeyHex, acc.CurveType)
-		if err != nil {
-			return fmt.Errorf("%w: %v", storageErrs.ErrAddrImportFailed, err)
+	importErrs := map[int]error{}
+	keyPairs, err := k.deriveImportKeys(ctx, accounts, importErrs)
+	if err != nil {
+		return err
+	}
+
+	for start := 0; start < len(accounts); start += importBatchSize {
+		if ctx.Err() != nil {
+			return ctx.Err()
 		}
 
-		// Skip if key already exists
-		err = k.Store(ctx, acc.AccountIdentifier, keyPair)
-		if errors.Is(err, storageErrs.ErrAddrExists) {
-			continue
+		end := start + importBatchSize
+		if end > len(accounts) {
+			end = len(accounts)
 		}
-		if err != nil {
-			return fmt.Errorf("%w: %v", storageErrs.ErrPrefundedAcctStoreFailed, err)
+
+		dbTx := k.db.Transaction(ctx)
+		stored := []int{}
+		for i := start; i < end; i++ {
+			if keyPairs[i] == nil {
+				continue
+			}
+
+			// Skip if key already exists
+			err := k.StoreTransactional(ctx, accounts[i].AccountIdentifier, keyPairs[i], dbTx)
+			if errors.Is(err, storageErrs.ErrAddrExists) {
+				continue
+			}
+			if err != nil {
+				importErrs[i] = fmt.Errorf("%w: %v", storageErrs.ErrPrefundedAcctStoreFailed, err)
+				continue
+			}
+
+			stored = append(stored, i)
+		}
+
+		if err := dbTx.Commit(ctx); err != nil {
+			for _, i := range stored {
+				importErrs[i] = fmt.Errorf(
+					"%w: %v",
+					storageErrs.ErrPrefundedAcctStoreFailed,
+					err,
+				)
+			}
 		}
+		dbTx.Discard(ctx)
 	}
+
+	if len(importErrs) > 0 {
+		return &ImportAccountsError{Errors: importErrs}
+	}
+
 	return nil
 }
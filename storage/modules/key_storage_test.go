@@ -16,12 +16,19 @@ package modules
 
 import (
 	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"runtime"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/coinbase/rosetta-sdk-go/asserter"
 	"github.com/coinbase/rosetta-sdk-go/keys"
+	"github.com/coinbase/rosetta-sdk-go/storage/encoder"
+	storageErrs "github.com/coinbase/rosetta-sdk-go/storage/errors"
 	"github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/coinbase/rosetta-sdk-go/utils"
 )
@@ -34,15 +41,8 @@ func hash(message string) []byte {
 func TestKeyStorage(t *testing.T) {
 	ctx := context.Background()
 
-	newDir, err := utils.CreateTempDir()
-	assert.NoError(t, err)
-	defer utils.RemoveTempDir(newDir)
-
-	database, err := newTestBadgerDatabase(ctx, newDir)
-	assert.NoError(t, err)
-	defer database.Close(ctx)
-
-	k := NewKeyStorage(database)
+	k, cleanup := newTestKeyStorage(t)
+	defer cleanup()
 
 	kp1, err := keys.GenerateKeypair(types.Edwards25519)
 	assert.NoError(t, err)
@@ -160,6 +160,47 @@ func TestKeyStorage(t *testing.T) {
 		assert.Nil(t, sigs)
 	})
 
+	t.Run("empty bytes in sign", func(t *testing.T) {
+		payloads := []*types.SigningPayload{
+			{
+				AccountIdentifier: &types.AccountIdentifier{Address: "addr1"},
+				SignatureType:     types.Ed25519,
+			},
+		}
+
+		sigs, err := k.Sign(ctx, payloads)
+		assert.True(t, errors.Is(err, asserter.ErrSigningPayloadBytesEmpty))
+		assert.Nil(t, sigs)
+	})
+
+	t.Run("empty address in sign", func(t *testing.T) {
+		payloads := []*types.SigningPayload{
+			{
+				AccountIdentifier: &types.AccountIdentifier{},
+				Bytes:             hash("msg1"),
+				SignatureType:     types.Ed25519,
+			},
+		}
+
+		sigs, err := k.Sign(ctx, payloads)
+		assert.True(t, errors.Is(err, asserter.ErrSigningPayloadAddrEmpty))
+		assert.Nil(t, sigs)
+	})
+
+	t.Run("invalid signature type in sign", func(t *testing.T) {
+		payloads := []*types.SigningPayload{
+			{
+				AccountIdentifier: &types.AccountIdentifier{Address: "addr1"},
+				Bytes:             hash("msg1"),
+				SignatureType:     "blah",
+			},
+		}
+
+		sigs, err := k.Sign(ctx, payloads)
+		assert.True(t, errors.Is(err, asserter.ErrSignatureTypeNotSupported))
+		assert.Nil(t, sigs)
+	})
+
 	t.Run("imports accounts", func(t *testing.T) {
 		accounts, err := k.GetAllAccounts(ctx)
 		assert.NoError(t, err)
@@ -216,4 +257,337 @@ func TestKeyStorage(t *testing.T) {
 
 		assert.Equal(t, endLen, startingLen)
 	})
+
+	t.Run("export and import keys", func(t *testing.T) {
+		exportedKeys, err := k.Export(ctx)
+		assert.NoError(t, err)
+
+		accounts, err := k.GetAllAccounts(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, exportedKeys, len(accounts))
+
+		for _, key := range exportedKeys {
+			kp, err := k.Get(ctx, key.Account)
+			assert.NoError(t, err)
+			assert.Equal(t, kp, key.KeyPair)
+		}
+
+		// Importing into the same key storage skips all keys
+		assert.NoError(t, k.Import(ctx, exportedKeys))
+		accounts, err = k.GetAllAccounts(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, accounts, len(exportedKeys))
+
+		// Import into a new key storage
+		otherDir, err := utils.CreateTempDir()
+		assert.NoError(t, err)
+		defer utils.RemoveTempDir(otherDir)
+
+		otherDatabase, err := newTestBadgerDatabase(ctx, otherDir)
+		assert.NoError(t, err)
+		defer otherDatabase.Close(ctx)
+
+		otherK := NewKeyStorage(otherDatabase)
+		assert.NoError(t, otherK.Import(ctx, exportedKeys))
+
+		otherKeys, err := otherK.Export(ctx)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, exportedKeys, otherKeys)
+	})
+
+	t.Run("import invalid key", func(t *testing.T) {
+		err := k.Import(ctx, []*Key{
+			{
+				Account: &types.AccountIdentifier{Address: "addr4"},
+			},
+		})
+		assert.True(t, errors.Is(err, storageErrs.ErrKeyImportFailed))
+
+		v, err := k.Get(ctx, &types.AccountIdentifier{Address: "addr4"})
+		assert.Error(t, err)
+		assert.Nil(t, v)
+	})
+}
+
+func newPrefundedAccounts(t testing.TB, count int) []*PrefundedAccount {
+	accounts := make([]*PrefundedAccount, count)
+	for i := 0; i < count; i++ {
+		kp, err := keys.GenerateKeypair(types.Secp256k1)
+		if err != nil {
+			t.Fatalf("unable to generate keypair: %v", err)
+		}
+
+		accounts[i] = &PrefundedAccount{
+			PrivateKeyHex:     hex.EncodeToString(kp.PrivateKey),
+			AccountIdentifier: &types.AccountIdentifier{Address: fmt.Sprintf("addr %d", i)},
+			CurveType:         types.Secp256k1,
+		}
+	}
+
+	return accounts
+}
+
+func TestImportAccounts(t *testing.T) {
+	var tests = map[string]struct {
+		concurrency int
+	}{
+		"serial": {
+			concurrency: 1,
+		},
+		"concurrent": {
+			concurrency: 8,
+		},
+		"zero concurrency": {
+			concurrency: 0,
+		},
+		"negative concurrency": {
+			concurrency: -1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			k, cleanup := newTestKeyStorage(t, WithImportConcurrency(test.concurrency))
+			defer cleanup()
+
+			// Span multiple batches
+			accounts := newPrefundedAccounts(t, importBatchSize*2+10)
+
+			// Invalid private key
+			accounts[3].PrivateKeyHex = "hello"
+
+			// Invalid curve type
+			accounts[150].CurveType = "blah"
+
+			// Already stored account
+			existing, err := keys.GenerateKeypair(types.Secp256k1)
+			assert.NoError(t, err)
+			assert.NoError(t, k.Store(ctx, accounts[42].AccountIdentifier, existing))
+
+			// Duplicate account in the same batch
+			accounts[201].AccountIdentifier = accounts[200].AccountIdentifier
+
+			err = k.ImportAccounts(ctx, accounts)
+			var importErr *ImportAccountsError
+			assert.True(t, errors.As(err, &importErr))
+			assert.True(t, errors.Is(err, storageErrs.ErrAddrImportFailed))
+			assert.False(t, errors.Is(err, storageErrs.ErrPrefundedAcctStoreFailed))
+			assert.Len(t, importErr.Errors, 2)
+			assert.Contains(t, importErr.Errors, 3)
+			assert.Contains(t, importErr.Errors, 150)
+
+			stored, err := k.GetAllAccounts(ctx)
+			assert.NoError(t, err)
+			assert.Len(t, stored, len(accounts)-3)
+
+			// Existing accounts are not overwritten
+			kp, err := k.Get(ctx, accounts[42].AccountIdentifier)
+			assert.NoError(t, err)
+			assert.Equal(t, existing, kp)
+
+			// Only the first duplicate is stored
+			kp, err = k.Get(ctx, accounts[200].AccountIdentifier)
+			assert.NoError(t, err)
+			assert.Equal(t, accounts[200].PrivateKeyHex, hex.EncodeToString(kp.PrivateKey))
+
+			// Importing again skips all stored accounts
+			assert.NoError(t, k.ImportAccounts(ctx, accounts[4:150]))
+		})
+	}
+}
+
+func TestImportAccountsVerifyAddresses(t *testing.T) {
+	ctx := context.Background()
+
+	// Only secp256k1 addresses can be derived
+	deriver := func(
+		ctx context.Context,
+		publicKey *types.PublicKey,
+	) (*types.AccountIdentifier, error) {
+		switch publicKey.CurveType {
+		case types.Secp256k1:
+			return &types.AccountIdentifier{Address: hex.EncodeToString(publicKey.Bytes)}, nil
+		case types.Secp256r1:
+			return nil, errors.New("node unavailable")
+		default:
+			return nil, nil
+		}
+	}
+
+	k, cleanup := newTestKeyStorage(t, WithVerifyAddresses(deriver))
+	defer cleanup()
+
+	accounts := newPrefundedAccounts(t, 3)
+	for _, acc := range accounts[:2] {
+		kp, err := keys.ImportPrivateKey(acc.PrivateKeyHex, acc.CurveType)
+		assert.NoError(t, err)
+		acc.AccountIdentifier.Address = hex.EncodeToString(kp.PublicKey.Bytes)
+	}
+
+	// Mismatched address
+	accounts[1].AccountIdentifier.Address = accounts[1].AccountIdentifier.Address[2:]
+
+	// Address cannot be derived
+	accounts = append(accounts, &PrefundedAccount{
+		PrivateKeyHex:     "17d08f5fe8c77af811caa0c9a187e668ce3b74a99acc3f6d976f075fa8e0be55",
+		AccountIdentifier: &types.AccountIdentifier{Address: "edwards"},
+		CurveType:         types.Edwards25519,
+	})
+
+	// Deriver fails
+	r1, err := keys.GenerateKeypair(types.Secp256r1)
+	assert.NoError(t, err)
+	accounts = append(accounts, &PrefundedAccount{
+		PrivateKeyHex:     hex.EncodeToString(r1.PrivateKey),
+		AccountIdentifier: &types.AccountIdentifier{Address: "r1"},
+		CurveType:         types.Secp256r1,
+	})
+
+	err = k.ImportAccounts(ctx, accounts)
+	var importErr *ImportAccountsError
+	assert.True(t, errors.As(err, &importErr))
+	assert.True(t, errors.Is(err, storageErrs.ErrAddrMismatch))
+	assert.True(t, errors.Is(err, storageErrs.ErrAddrDeriveFailed))
+	assert.Len(t, importErr.Errors, 3)
+	assert.Contains(t, importErr.Errors, 1)
+	assert.Contains(t, importErr.Errors, 2)
+	assert.Contains(t, importErr.Errors, 4)
+
+	stored, err := k.GetAllAccounts(ctx)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []*types.AccountIdentifier{
+		accounts[0].AccountIdentifier,
+		accounts[3].AccountIdentifier,
+	}, stored)
+}
+
+func benchmarkImportAccounts(b *testing.B, concurrency int) {
+	ctx := context.Background()
+	accounts := newPrefundedAccounts(b, 10000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		k, cleanup := newTestKeyStorage(b, WithImportConcurrency(concurrency))
+		b.StartTimer()
+
+		if err := k.ImportAccounts(ctx, accounts); err != nil {
+			b.Fatal(err)
+		}
+
+		b.StopTimer()
+		cleanup()
+		b.StartTimer()
+	}
+}
+
+// BenchmarkImportAccountsSerial imports 10k accounts
+// without any derivation concurrency.
+func BenchmarkImportAccountsSerial(b *testing.B) {
+	benchmarkImportAccounts(b, 1)
+}
+
+// BenchmarkImportAccountsConcurrent imports 10k accounts
+// deriving keys with one goroutine per CPU.
+func BenchmarkImportAccountsConcurrent(b *testing.B) {
+	benchmarkImportAccounts(b, runtime.NumCPU())
+}
+
+func TestKeyStorageWithEncoder(t *testing.T) {
+	ctx := context.Background()
+
+	newDir, err := utils.CreateTempDir()
+	assert.NoError(t, err)
+	defer utils.RemoveTempDir(newDir)
+
+	database, err := newTestBadgerDatabase(ctx, newDir)
+	assert.NoError(t, err)
+	defer database.Close(ctx)
+
+	uncompressed, err := encoder.NewEncoder(nil, encoder.NewBufferPool(), false)
+	assert.NoError(t, err)
+
+	k := NewKeyStorage(database, WithKeyEncoder(uncompressed))
+	assert.Equal(t, uncompressed, k.Encoder())
+	assert.Equal(t, database.Encoder(), NewKeyStorage(database).Encoder())
+
+	kp, err := keys.GenerateKeypair(types.Secp256k1)
+	assert.NoError(t, err)
+
+	account := &types.AccountIdentifier{Address: "addr1"}
+	assert.NoError(t, k.Store(ctx, account, kp))
+
+	v, err := k.Get(ctx, account)
+	assert.NoError(t, err)
+	assert.Equal(t, kp, v)
+
+	accounts, err := k.GetAllAccounts(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []*types.AccountIdentifier{account}, accounts)
+
+	// The stored record is not compressed with the
+	// database encoder.
+	txn := database.ReadTransaction(ctx)
+	exists, raw, err := txn.Get(ctx, getAccountKey(account))
+	txn.Discard(ctx)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	var key Key
+	assert.NoError(t, uncompressed.Decode("", raw, &key, false))
+	assert.Equal(t, kp, key.KeyPair)
+
+	v, err = NewKeyStorage(database).Get(ctx, account)
+	assert.True(t, errors.Is(err, storageErrs.ErrParseSavedKeyFailed))
+	assert.Nil(t, v)
+}
+
+func TestScanAccounts(t *testing.T) {
+	ctx := context.Background()
+
+	newDir, err := utils.CreateTempDir()
+	assert.NoError(t, err)
+	defer utils.RemoveTempDir(newDir)
+
+	database, err := newTestBadgerDatabase(ctx, newDir)
+	assert.NoError(t, err)
+	defer database.Close(ctx)
+
+	k := NewKeyStorage(database)
+
+	kp, err := keys.GenerateKeypair(types.Secp256k1)
+	assert.NoError(t, err)
+
+	account := &types.AccountIdentifier{Address: "addr1"}
+	assert.NoError(t, k.Store(ctx, account, kp))
+
+	// Store a key with a private key that cannot be decoded
+	corruptAccount := &types.AccountIdentifier{Address: "addr2"}
+	corrupt, err := database.Encoder().Encode("", map[string]interface{}{
+		"account": corruptAccount,
+		"keypair": "not a keypair",
+	})
+	assert.NoError(t, err)
+	txn := database.Transaction(ctx)
+	assert.NoError(t, txn.Set(ctx, getAccountKey(corruptAccount), corrupt, true))
+	assert.NoError(t, txn.Commit(ctx))
+
+	// Accounts are available without decoding keypairs
+	accounts, err := k.GetAllAccounts(ctx)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []*types.AccountIdentifier{account, corruptAccount}, accounts)
+
+	_, err = k.Get(ctx, corruptAccount)
+	assert.True(t, errors.Is(err, storageErrs.ErrParseSavedKeyFailed))
+
+	// Errors returned by the worker are propagated
+	errWorker := errors.New("bad account")
+	txn = database.ReadTransaction(ctx)
+	defer txn.Discard(ctx)
+	err = k.ScanAccountsTransactional(ctx, txn, func(*types.AccountIdentifier) error {
+		return errWorker
+	})
+	assert.True(t, errors.Is(err, storageErrs.ErrKeyScanFailed))
+	assert.Contains(t, err.Error(), errWorker.Error())
 }
@@ -0,0 +1,132 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modules
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/coinbase/rosetta-sdk-go/asserter"
+	storageErrs "github.com/coinbase/rosetta-sdk-go/storage/errors"
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+// RemoteSigner is implemented by signers that hold private
+// keys outside of this process (like an HSM or KMS).
+type RemoteSigner interface {
+	Sign(
+		ctx context.Context,
+		payload *types.SigningPayload,
+		sigType types.SignatureType,
+	) (*types.Signature, error)
+}
+
+// RemoteSignerStore delegates signing to a RemoteSigner
+// registered for each address. Unlike KeyStorage, it never
+// has access to private key bytes.
+type RemoteSignerStore struct {
+	signers map[string]RemoteSigner
+	lock    sync.RWMutex
+}
+
+// NewRemoteSignerStore returns a new RemoteSignerStore.
+func NewRemoteSignerStore() *RemoteSignerStore {
+	return &RemoteSignerStore{
+		signers: map[string]RemoteSigner{},
+	}
+}
+
+// AddSigner registers a RemoteSigner for an address. It is
+// not possible to replace the signer of an address that
+// has already been registered.
+func (r *RemoteSignerStore) AddSigner(address string, signer RemoteSigner) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, ok := r.signers[address]; ok {
+		return fmt.Errorf("%w: %s", storageErrs.ErrAddrExists, address)
+	}
+
+	r.signers[address] = signer
+	return nil
+}
+
+// signersFor validates each *types.SigningPayload and
+// returns the RemoteSigner registered for its address.
+func (r *RemoteSignerStore) signersFor(
+	payloads []*types.SigningPayload,
+) ([]RemoteSigner, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	signers := make([]RemoteSigner, len(payloads))
+	for i, payload := range payloads {
+		if err := asserter.SigningPayload(payload); err != nil {
+			return nil, fmt.Errorf("%w: signing payload %d is invalid", err, i)
+		}
+
+		if len(payload.SignatureType) == 0 {
+			return nil, fmt.Errorf("%w %d", storageErrs.ErrDetermineSigTypeFailed, i)
+		}
+
+		signer, ok := r.signers[payload.AccountIdentifier.Address]
+		if !ok {
+			return nil, fmt.Errorf(
+				"%w: no remote signer for %s",
+				storageErrs.ErrAddrNotFound,
+				payload.AccountIdentifier.Address,
+			)
+		}
+
+		signers[i] = signer
+	}
+
+	return signers, nil
+}
+
+// Sign attempts to sign a slice of *types.SigningPayload using
+// the RemoteSigner registered for each payload's address. Sign
+// has the same signature as KeyStorage.Sign, so it can be used
+// wherever KeyStorage.Sign is used (i.e. in a constructor helper).
+func (r *RemoteSignerStore) Sign(
+	ctx context.Context,
+	payloads []*types.SigningPayload,
+) ([]*types.Signature, error) {
+	// Only hold the lock while looking up signers so that
+	// slow remote signers do not block AddSigner.
+	signers, err := r.signersFor(payloads)
+	if err != nil {
+		return nil, err
+	}
+
+	signatures := make([]*types.Signature, len(payloads))
+	for i, payload := range payloads {
+		signature, err := signers[i].Sign(ctx, payload, payload.SignatureType)
+		if err != nil {
+			return nil, fmt.Errorf("%w for %d: %v", storageErrs.ErrSignPayloadFailed, i, err)
+		}
+
+		// Remote signers are not trusted to return well-formed
+		// signatures, so we assert each one before returning it.
+		if err := asserter.Signatures([]*types.Signature{signature}); err != nil {
+			return nil, fmt.Errorf("%w for %d: %v", storageErrs.ErrSignPayloadFailed, i, err)
+		}
+
+		signatures[i] = signature
+	}
+
+	return signatures, nil
+}
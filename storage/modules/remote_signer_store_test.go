@@ -0,0 +1,123 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modules
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	mocks "github.com/coinbase/rosetta-sdk-go/mocks/storage/modules"
+	storageErrs "github.com/coinbase/rosetta-sdk-go/storage/errors"
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+func TestRemoteSignerStore(t *testing.T) {
+	ctx := context.Background()
+
+	mockSigner := &mocks.RemoteSigner{}
+	r := NewRemoteSignerStore()
+	assert.NoError(t, r.AddSigner("addr1", mockSigner))
+	assert.ErrorIs(t, r.AddSigner("addr1", mockSigner), storageErrs.ErrAddrExists)
+
+	payload := &types.SigningPayload{
+		AccountIdentifier: &types.AccountIdentifier{Address: "addr1"},
+		Bytes:             hash("msg1"),
+		SignatureType:     types.Ecdsa,
+	}
+	signature := &types.Signature{
+		SigningPayload: payload,
+		PublicKey: &types.PublicKey{
+			Bytes:     []byte("pubkey"),
+			CurveType: types.Secp256k1,
+		},
+		SignatureType: types.Ecdsa,
+		Bytes:         []byte("signature"),
+	}
+
+	t.Run("sign", func(t *testing.T) {
+		mockSigner.On("Sign", ctx, payload, types.Ecdsa).Return(signature, nil).Once()
+
+		sigs, err := r.Sign(ctx, []*types.SigningPayload{payload})
+		assert.NoError(t, err)
+		assert.Equal(t, []*types.Signature{signature}, sigs)
+	})
+
+	t.Run("unknown address", func(t *testing.T) {
+		sigs, err := r.Sign(ctx, []*types.SigningPayload{
+			{
+				AccountIdentifier: &types.AccountIdentifier{Address: "addr2"},
+				Bytes:             hash("msg2"),
+				SignatureType:     types.Ecdsa,
+			},
+		})
+		assert.ErrorIs(t, err, storageErrs.ErrAddrNotFound)
+		assert.Nil(t, sigs)
+	})
+
+	t.Run("missing signature type", func(t *testing.T) {
+		sigs, err := r.Sign(ctx, []*types.SigningPayload{
+			{
+				AccountIdentifier: &types.AccountIdentifier{Address: "addr1"},
+				Bytes:             hash("msg1"),
+			},
+		})
+		assert.ErrorIs(t, err, storageErrs.ErrDetermineSigTypeFailed)
+		assert.Nil(t, sigs)
+	})
+
+	t.Run("remote signer error", func(t *testing.T) {
+		mockSigner.On(
+			"Sign",
+			ctx,
+			payload,
+			types.Ecdsa,
+		).Return(nil, errors.New("kms unavailable")).Once()
+
+		sigs, err := r.Sign(ctx, []*types.SigningPayload{payload})
+		assert.ErrorIs(t, err, storageErrs.ErrSignPayloadFailed)
+		assert.Nil(t, sigs)
+	})
+
+	t.Run("invalid remote signature", func(t *testing.T) {
+		invalidSignature := &types.Signature{
+			SigningPayload: payload,
+			PublicKey:      signature.PublicKey,
+			SignatureType:  types.Ed25519,
+			Bytes:          []byte("signature"),
+		}
+		mockSigner.On("Sign", ctx, payload, types.Ecdsa).Return(invalidSignature, nil).Once()
+
+		sigs, err := r.Sign(ctx, []*types.SigningPayload{payload})
+		assert.ErrorIs(t, err, storageErrs.ErrSignPayloadFailed)
+		assert.Nil(t, sigs)
+	})
+
+	t.Run("add signer while signing", func(t *testing.T) {
+		// AddSigner must not block on a slow remote signer
+		mockSigner.On("Sign", ctx, payload, types.Ecdsa).Run(func(args mock.Arguments) {
+			assert.NoError(t, r.AddSigner("addr3", mockSigner))
+		}).Return(signature, nil).Once()
+
+		sigs, err := r.Sign(ctx, []*types.SigningPayload{payload})
+		assert.NoError(t, err)
+		assert.Equal(t, []*types.Signature{signature}, sigs)
+	})
+
+	mockSigner.AssertExpectations(t)
+}
@@ -0,0 +1,99 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modules
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/coinbase/rosetta-sdk-go/storage/database"
+	"github.com/coinbase/rosetta-sdk-go/utils"
+)
+
+// newTestDatabase creates a new Badger Database in a temporary
+// directory. The returned cleanup function closes the database
+// and removes the directory. It is also registered with t.Cleanup
+// so that the directory is not leaked if the test panics or
+// exits early (calling it more than once is a no-op).
+func newTestDatabase(t testing.TB) (database.Database, func()) {
+	ctx := context.Background()
+
+	newDir, err := utils.CreateTempDir()
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+
+	db, err := newTestBadgerDatabase(ctx, newDir)
+	if err != nil {
+		utils.RemoveTempDir(newDir)
+		t.Fatalf("unable to create database: %v", err)
+	}
+
+	var once sync.Once
+	cleanup := func() {
+		once.Do(func() {
+			db.Close(ctx)
+			utils.RemoveTempDir(newDir)
+		})
+	}
+	t.Cleanup(cleanup)
+
+	return db, cleanup
+}
+
+// newTestBlockStorage creates a new *BlockStorage backed
+// by a temporary Badger Database (see newTestDatabase).
+func newTestBlockStorage(
+	t testing.TB,
+	options ...BlockStorageOption,
+) (*BlockStorage, func()) {
+	db, cleanup := newTestDatabase(t)
+
+	return NewBlockStorage(db, blockWorkerConcurrency, options...), cleanup
+}
+
+// newTestKeyStorage creates a new *KeyStorage backed
+// by a temporary Badger Database (see newTestDatabase).
+func newTestKeyStorage(
+	t testing.TB,
+	options ...KeyStorageOption,
+) (*KeyStorage, func()) {
+	db, cleanup := newTestDatabase(t)
+
+	return NewKeyStorage(db, options...), cleanup
+}
+
+func TestNewTestDatabase(t *testing.T) {
+	ctx := context.Background()
+
+	storage, cleanup := newTestBlockStorage(t)
+	txn := storage.db.Transaction(ctx)
+	assert.NoError(t, txn.Set(ctx, []byte("hello"), []byte("world"), true))
+	assert.NoError(t, txn.Commit(ctx))
+
+	keyStorage, keyCleanup := newTestKeyStorage(t)
+	accounts, err := keyStorage.GetAllAccounts(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, accounts, 0)
+
+	// Cleanup functions are safe to call multiple times
+	// (they are also invoked by t.Cleanup).
+	cleanup()
+	cleanup()
+	keyCleanup()
+}
@@ -15,7 +15,11 @@
 package syncer
 
 import (
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/asserter"
 	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/coinbase/rosetta-sdk-go/utils"
 )
 
 // Option is used to overwrite default values in
@@ -59,9 +63,214 @@ func WithMaxConcurrency(concurrency int64) Option {
 	}
 }
 
+// WithMaxGoroutines caps the number of goroutines fetching
+// blocks (the syncer never adjusts its concurrency above
+// goroutines, even if the block cache has room for more).
+// By default, the number of goroutines is only limited by
+// WithMaxConcurrency.
+func WithMaxGoroutines(goroutines int64) Option {
+	return func(s *Syncer) {
+		s.maxGoroutines = goroutines
+	}
+}
+
 // WithAdjustmentWindow overrides the default adjustment window.
 func WithAdjustmentWindow(adjustmentWindow int64) Option {
 	return func(s *Syncer) {
 		s.adjustmentWindow = adjustmentWindow
 	}
 }
+
+// WithClock overrides the default clock (utils.RealClock)
+// used to measure throughput and to sleep between fetches.
+func WithClock(clock utils.Clock) Option {
+	return func(s *Syncer) {
+		s.clock = clock
+	}
+}
+
+// WithOperationFilter causes the Handler to receive a shallow
+// copy of each added block containing only the operations for
+// which filter returns true. This changes what the Handler sees,
+// not the block that was fetched (transactions with no matching
+// operations are still delivered, with no operations).
+//
+// Because operations are removed, the operation indices
+// delivered to the Handler may no longer be contiguous; the
+// Handler must tolerate this.
+func WithOperationFilter(filter func(*types.Operation) bool) Option {
+	return func(s *Syncer) {
+		s.operationFilter = filter
+	}
+}
+
+// WithBatchHandler causes up to size contiguous added blocks
+// to be delivered in a single call to BlocksAdded, if the
+// Handler implements BatchHandler (otherwise this option has
+// no effect). Pending blocks are delivered when the batch is
+// full, before any block is removed, when the syncer reaches
+// the end of a sync range, and when the context is canceled.
+func WithBatchHandler(size int) Option {
+	return func(s *Syncer) {
+		s.batchSize = size
+	}
+}
+
+// WithBatchSize causes blocks to be fetched in ranges of up
+// to size blocks with a single call to BlocksRange, if the
+// Helper implements BatchHelper (otherwise this option has
+// no effect). If BlocksRange returns an error, each block in
+// the range is fetched individually with Block instead.
+// Because each goroutine holds a full batch of blocks,
+// concurrency is reduced accordingly to respect WithCacheSize.
+//
+// This is unrelated to WithBatchHandler, which controls how
+// added blocks are delivered to the Handler.
+func WithBatchSize(size int64) Option {
+	return func(s *Syncer) {
+		s.fetchBatchSize = size
+	}
+}
+
+// WithSkipGenesis determines if the genesis block is delivered
+// to the Handler (the default) when it is synced. If skip is true,
+// neither BlockSeen nor BlockAdded is invoked for the genesis block
+// but the syncer advances past it as if it were processed.
+//
+// Any balances allocated in the genesis block will not be
+// observed by the Handler. When reconciling balances, the
+// genesis allocations must be loaded by other means
+// (ex: bootstrap balances) or reconciliation will fail.
+func WithSkipGenesis(skip bool) Option {
+	return func(s *Syncer) {
+		s.skipGenesis = skip
+	}
+}
+
+// WithQuietSync reduces the number of "Syncing" lines
+// logged by the syncer to roughly one per interval blocks
+// (a range is logged only when it reaches a multiple of
+// interval that has not been logged yet). All other logs
+// are unaffected. If this option is not provided, every
+// range is logged.
+func WithQuietSync(interval int64) Option {
+	return func(s *Syncer) {
+		s.syncLogInterval = interval
+		s.lastLoggedBucket = -1
+	}
+}
+
+// WithoutReorgHandling disables all reorg handling. Every
+// fetched block is treated as an append to the chain: its
+// ParentBlockIdentifier is not compared to the last processed
+// block, BlockRemoved is never invoked, and no past blocks are
+// retained (so WithPastBlocks and WithPastBlockLimit have no
+// effect). This saves the memory used to track past blocks and
+// the comparison performed on each block.
+//
+// This is only correct if the chain is truly append-only
+// (i.e. every synced block is final). If a reorg occurs, the
+// orphaned blocks will NOT be removed from the Handler's view.
+// If the Helper returns ErrOrphanHead, syncing will fail.
+func WithoutReorgHandling() Option {
+	return func(s *Syncer) {
+		s.disableReorgs = true
+	}
+}
+
+// WithSyncDirection sets the order in which the syncer
+// processes block indices. When syncing in the Descending
+// direction (useful for backfilling so that recent blocks
+// are available first), each index is fetched exactly once
+// and reorgs are not handled (as if WithoutReorgHandling
+// was provided) because a block's parent is processed after
+// it. Descending syncs stop once endIndex is processed and
+// do not follow the tip, so SyncToTip returns
+// ErrSyncToTipDescending.
+func WithSyncDirection(direction SyncDirection) Option {
+	return func(s *Syncer) {
+		s.direction = direction
+		if direction == Descending {
+			s.disableReorgs = true
+		}
+	}
+}
+
+// WithFetchObserver provides a function that is invoked with
+// every block fetched by the syncer (before any ordering or reorg
+// logic is applied). If the node returned ErrOrphanHead for
+// an index, block is nil and orphan is true. This is useful
+// for sampling or debugging what the node returned for each
+// index (unlike BlockAdded, it may be called out of order and
+// for blocks that are never added).
+//
+// The observer is invoked synchronously in the fetch goroutines,
+// so it should return quickly to avoid slowing down syncing.
+func WithFetchObserver(observer func(index int64, block *types.Block, orphan bool)) Option {
+	return func(s *Syncer) {
+		s.fetchObserver = observer
+	}
+}
+
+// WithAsserter validates every block fetched from the Helper
+// with asserter.Block before it is processed. If a block is
+// invalid, syncing fails with ErrBlockInvalid (the block is
+// not delivered to the Handler).
+//
+// This is not necessary if the Helper is a *fetcher.Fetcher
+// (which already asserts each block it returns) but provides
+// defense-in-depth for other Helper implementations.
+func WithAsserter(asserter *asserter.Asserter) Option {
+	return func(s *Syncer) {
+		s.asserter = asserter
+	}
+}
+
+// WithHandlerRetry retries a Handler invocation (BlockAdded,
+// BlockRemoved, or BlocksAdded) that returns an error up to
+// maxRetries times, sleeping for backoff between each attempt.
+// By default, any Handler error aborts syncing immediately.
+// A Handler can still abort immediately by returning an error
+// that wraps ErrHandlerNonRetriable.
+func WithHandlerRetry(maxRetries int, backoff time.Duration) Option {
+	return func(s *Syncer) {
+		s.handlerRetries = maxRetries
+		s.handlerBackoff = backoff
+	}
+}
+
+// WithFetchRetries retries a call to Helper.Block that returns
+// an error (other than ErrOrphanHead) up to maxRetries times
+// before aborting the sync. Retries are delayed with jittered
+// exponential backoff (see WithFetchBackoff). By default, any
+// error fetching a block aborts syncing immediately.
+func WithFetchRetries(maxRetries int) Option {
+	return func(s *Syncer) {
+		s.fetchRetries = maxRetries
+	}
+}
+
+// WithFetchBackoff overrides the DefaultFetchBackoffBase and
+// DefaultFetchBackoffMax used to delay retries of failed block
+// fetches (see WithFetchRetries). The backoff starts at base
+// and doubles after each attempt (up to max). Each delay is
+// randomly jittered between half and all of the backoff.
+func WithFetchBackoff(base time.Duration, max time.Duration) Option {
+	return func(s *Syncer) {
+		s.fetchBackoffBase = base
+		s.fetchBackoffMax = max
+	}
+}
+
+// WithHeartbeat invokes heartbeat with the next index to sync
+// at most once every interval while the syncer is idle at tip
+// (waiting for new blocks). This allows monitors to distinguish
+// an idle syncer from a hung one. The heartbeat is not invoked
+// while blocks are being synced and stops when the context
+// is canceled.
+func WithHeartbeat(interval time.Duration, heartbeat func(nextIndex int64)) Option {
+	return func(s *Syncer) {
+		s.heartbeatInterval = interval
+		s.heartbeat = heartbeat
+	}
+}
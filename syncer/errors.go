@@ -43,6 +43,54 @@ var (
 	// result is nil.
 	ErrBlockResultNil = errors.New("block result is nil")
 
+	// ErrReorgHandlingDisabled is returned when the
+	// Helper indicates the head should be orphaned
+	// but reorg handling is disabled.
+	ErrReorgHandlingDisabled = errors.New("reorg handling is disabled")
+
+	// ErrHandlerNonRetriable can be returned (or wrapped)
+	// by a Handler to abort syncing without retrying
+	// (see WithHandlerRetry).
+	ErrHandlerNonRetriable = errors.New("handler error is not retriable")
+
+	// ErrHandlerRetriesExhausted is returned when a Handler
+	// continues to error after all retries provided in
+	// WithHandlerRetry.
+	ErrHandlerRetriesExhausted = errors.New("handler retries exhausted")
+
+	// ErrFetchRetriesExhausted is returned when the Helper
+	// continues to error when fetching a block after all
+	// retries provided in WithFetchRetries.
+	ErrFetchRetriesExhausted = errors.New("fetch retries exhausted")
+
+	// ErrNoHelpers is returned by a FailoverHelper
+	// that was not provided any Helpers.
+	ErrNoHelpers = errors.New("no helpers provided")
+
+	// ErrAllHelpersFailed is returned by a FailoverHelper
+	// when a request fails on every Helper.
+	ErrAllHelpersFailed = errors.New("all helpers failed")
+
+	// ErrReorgExceedsWindow is returned when a reorg orphans
+	// all blocks tracked by the syncer (see WithPastBlockLimit)
+	// and the next block still does not connect. The syncer cannot determine where
+	// the reorg reconnects, so it should be re-bootstrapped
+	// (usually from storage).
+	ErrReorgExceedsWindow = errors.New("reorg exceeds past block window")
+
+	// ErrBlockInvalid is returned when a block fetched
+	// from the Helper fails validation (see WithAsserter).
+	ErrBlockInvalid = errors.New("block is invalid")
+
+	// ErrNoHeadBlock is returned by SyncToTip when the
+	// syncer has not processed (or been provided) any block.
+	ErrNoHeadBlock = errors.New("no head block")
+
+	// ErrSyncToTipDescending is returned by SyncToTip when
+	// the syncer syncs in the Descending direction (there
+	// is no tip to sync to).
+	ErrSyncToTipDescending = errors.New("cannot sync to tip in descending direction")
+
 	ErrGetCurrentHeadBlockFailed   = errors.New("unable to get current head")
 	ErrGetNetworkStatusFailed      = errors.New("unable to get network status")
 	ErrFetchBlockFailed            = errors.New("unable to fetch block")
@@ -51,6 +99,8 @@ var (
 	ErrBlocksProcessMultipleFailed = errors.New("unable to process blocks")
 	ErrSetStartIndexFailed         = errors.New("unable to set start index")
 	ErrNextSyncableRangeFailed     = errors.New("unable to get next syncable range")
+	ErrFlushBlocksFailed           = errors.New("unable to flush pending blocks")
+	ErrNextNonOmittedFailed        = errors.New("unable to get next non-omitted index")
 )
 
 // Err takes an error as an argument and returns
@@ -61,6 +111,16 @@ func Err(err error) bool {
 		ErrOutOfOrder,
 		ErrOrphanHead,
 		ErrBlockResultNil,
+		ErrReorgHandlingDisabled,
+		ErrHandlerNonRetriable,
+		ErrHandlerRetriesExhausted,
+		ErrFetchRetriesExhausted,
+		ErrNoHelpers,
+		ErrAllHelpersFailed,
+		ErrReorgExceedsWindow,
+		ErrBlockInvalid,
+		ErrNoHeadBlock,
+		ErrSyncToTipDescending,
 		ErrGetCurrentHeadBlockFailed,
 		ErrGetNetworkStatusFailed,
 		ErrFetchBlockFailed,
@@ -69,6 +129,8 @@ func Err(err error) bool {
 		ErrBlocksProcessMultipleFailed,
 		ErrSetStartIndexFailed,
 		ErrNextSyncableRangeFailed,
+		ErrFlushBlocksFailed,
+		ErrNextNonOmittedFailed,
 	}
 
 	return utils.FindError(syncerErrors, err)
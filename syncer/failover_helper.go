@@ -0,0 +1,158 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/coinbase/rosetta-sdk-go/utils"
+)
+
+// failoverHelper is a Helper that falls back to
+// the next Helper when a request fails.
+type failoverHelper struct {
+	helpers  []Helper
+	cooldown time.Duration
+	clock    utils.Clock
+
+	// failures tracks the last time each
+	// Helper failed a request.
+	failures     []time.Time
+	failuresLock sync.Mutex
+}
+
+// FailoverHelper returns a Helper that attempts each request
+// with the provided Helpers in order, falling back to the next
+// Helper when a request fails. A Helper that fails a request is
+// skipped for cooldown (unless all Helpers are cooling down).
+//
+// ErrOrphanHead is returned immediately (it is not considered
+// a failure of the Helper).
+func FailoverHelper(cooldown time.Duration, helpers ...Helper) Helper {
+	return &failoverHelper{
+		helpers:  helpers,
+		cooldown: cooldown,
+		clock:    utils.RealClock{},
+		failures: make([]time.Time, len(helpers)),
+	}
+}
+
+// candidates returns the indices of all Helpers that
+// are not cooling down (or all Helpers if all
+// are cooling down).
+func (f *failoverHelper) candidates() []int {
+	f.failuresLock.Lock()
+	defer f.failuresLock.Unlock()
+
+	now := f.clock.Now()
+	available := []int{}
+	cooling := []int{}
+	for i, failure := range f.failures {
+		if !failure.IsZero() && now.Before(failure.Add(f.cooldown)) {
+			cooling = append(cooling, i)
+			continue
+		}
+
+		available = append(available, i)
+	}
+
+	return append(available, cooling...)
+}
+
+// setFailed records if the Helper at index
+// failed its most recent request.
+func (f *failoverHelper) setFailed(index int, failed bool) {
+	f.failuresLock.Lock()
+	defer f.failuresLock.Unlock()
+
+	if !failed {
+		f.failures[index] = time.Time{}
+		return
+	}
+
+	f.failures[index] = f.clock.Now()
+}
+
+// attempt invokes request with each candidate Helper
+// until one succeeds or returns ErrOrphanHead.
+func (f *failoverHelper) attempt(
+	ctx context.Context,
+	request func(Helper) error,
+) error {
+	if len(f.helpers) == 0 {
+		return ErrNoHelpers
+	}
+
+	var err error
+	for _, index := range f.candidates() {
+		err = request(f.helpers[index])
+		if err == nil || errors.Is(err, ErrOrphanHead) {
+			f.setFailed(index, false)
+			return err
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		f.setFailed(index, true)
+	}
+
+	return fmt.Errorf("%w: %v", ErrAllHelpersFailed, err)
+}
+
+// NetworkStatus returns the *types.NetworkStatusResponse
+// of the first Helper that succeeds.
+func (f *failoverHelper) NetworkStatus(
+	ctx context.Context,
+	network *types.NetworkIdentifier,
+) (*types.NetworkStatusResponse, error) {
+	var status *types.NetworkStatusResponse
+	err := f.attempt(ctx, func(helper Helper) error {
+		var err error
+		status, err = helper.NetworkStatus(ctx, network)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// Block returns the *types.Block of
+// the first Helper that succeeds.
+func (f *failoverHelper) Block(
+	ctx context.Context,
+	network *types.NetworkIdentifier,
+	block *types.PartialBlockIdentifier,
+) (*types.Block, error) {
+	var result *types.Block
+	err := f.attempt(ctx, func(helper Helper) error {
+		var err error
+		result, err = helper.Block(ctx, network, block)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
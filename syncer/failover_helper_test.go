@@ -0,0 +1,119 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	mocks "github.com/coinbase/rosetta-sdk-go/mocks/syncer"
+	mockUtils "github.com/coinbase/rosetta-sdk-go/mocks/utils"
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+func TestFailoverHelper(t *testing.T) {
+	ctx := context.Background()
+	nodeErr := errors.New("node unavailable")
+	cooldown := 10 * time.Second
+	start := time.Unix(1600000000, 0)
+
+	primary := &mocks.Helper{}
+	secondary := &mocks.Helper{}
+	mockClock := &mockUtils.Clock{}
+	helper := FailoverHelper(cooldown, primary, secondary).(*failoverHelper)
+	helper.clock = mockClock
+
+	index := int64(1)
+	blockIdentifier := &types.PartialBlockIdentifier{Index: &index}
+	status := &types.NetworkStatusResponse{
+		CurrentBlockIdentifier: blockSequence[1].BlockIdentifier,
+		GenesisBlockIdentifier: blockSequence[0].BlockIdentifier,
+	}
+
+	t.Run("primary serves block", func(t *testing.T) {
+		mockClock.On("Now").Return(start).Once()
+		primary.On("Block", ctx, networkIdentifier, blockIdentifier).
+			Return(blockSequence[1], nil).Once()
+
+		block, err := helper.Block(ctx, networkIdentifier, blockIdentifier)
+		assert.NoError(t, err)
+		assert.Equal(t, blockSequence[1], block)
+	})
+
+	t.Run("primary fails and secondary serves block", func(t *testing.T) {
+		mockClock.On("Now").Return(start).Twice()
+		primary.On("Block", ctx, networkIdentifier, blockIdentifier).
+			Return(nil, nodeErr).Once()
+		secondary.On("Block", ctx, networkIdentifier, blockIdentifier).
+			Return(blockSequence[1], nil).Once()
+
+		block, err := helper.Block(ctx, networkIdentifier, blockIdentifier)
+		assert.NoError(t, err)
+		assert.Equal(t, blockSequence[1], block)
+	})
+
+	t.Run("primary is skipped during cooldown", func(t *testing.T) {
+		mockClock.On("Now").Return(start.Add(cooldown / 2)).Once()
+		secondary.On("NetworkStatus", ctx, networkIdentifier).Return(status, nil).Once()
+
+		networkStatus, err := helper.NetworkStatus(ctx, networkIdentifier)
+		assert.NoError(t, err)
+		assert.Equal(t, status, networkStatus)
+	})
+
+	t.Run("orphan head is not a failure", func(t *testing.T) {
+		mockClock.On("Now").Return(start.Add(cooldown)).Once()
+		primary.On("Block", ctx, networkIdentifier, blockIdentifier).
+			Return(nil, ErrOrphanHead).Once()
+
+		block, err := helper.Block(ctx, networkIdentifier, blockIdentifier)
+		assert.True(t, errors.Is(err, ErrOrphanHead))
+		assert.Nil(t, block)
+	})
+
+	t.Run("all helpers fail", func(t *testing.T) {
+		mockClock.On("Now").Return(start.Add(cooldown)).Times(3)
+		primary.On("NetworkStatus", ctx, networkIdentifier).Return(nil, nodeErr).Once()
+		secondary.On("NetworkStatus", ctx, networkIdentifier).Return(nil, nodeErr).Once()
+
+		networkStatus, err := helper.NetworkStatus(ctx, networkIdentifier)
+		assert.True(t, errors.Is(err, ErrAllHelpersFailed))
+		assert.Nil(t, networkStatus)
+	})
+
+	t.Run("all helpers cooling down", func(t *testing.T) {
+		mockClock.On("Now").Return(start.Add(cooldown + 1)).Once()
+		primary.On("Block", ctx, networkIdentifier, blockIdentifier).
+			Return(blockSequence[1], nil).Once()
+
+		block, err := helper.Block(ctx, networkIdentifier, blockIdentifier)
+		assert.NoError(t, err)
+		assert.Equal(t, blockSequence[1], block)
+	})
+
+	t.Run("no helpers", func(t *testing.T) {
+		block, err := FailoverHelper(cooldown).Block(ctx, networkIdentifier, blockIdentifier)
+		assert.True(t, errors.Is(err, ErrNoHelpers))
+		assert.Nil(t, block)
+	})
+
+	primary.AssertExpectations(t)
+	secondary.AssertExpectations(t)
+	mockClock.AssertExpectations(t)
+}
@@ -0,0 +1,146 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+// multiHandler is a Handler that invokes
+// a collection of Handlers in order.
+type multiHandler struct {
+	handlers []Handler
+}
+
+// MultiHandler returns a Handler that invokes each provided
+// Handler in order (for BlockSeen, BlockAdded, and BlockRemoved),
+// returning the first error encountered without invoking
+// any subsequent Handler.
+//
+// The returned Handler also implements BatchHandler and
+// ReorgHandler. BlocksAdded is forwarded to each Handler that
+// implements BatchHandler (other Handlers are invoked with
+// BlockAdded for each block) and ReorgStarted and ReorgFinished
+// are forwarded to each Handler that implements ReorgHandler.
+//
+// If a Handler errors, all Handlers before it have already
+// processed the block. Because the syncer will attempt to
+// process the block again if syncing is restarted, all Handlers
+// should be idempotent.
+func MultiHandler(handlers ...Handler) Handler {
+	return &multiHandler{handlers: handlers}
+}
+
+// BlockSeen invokes BlockSeen on each Handler.
+func (m *multiHandler) BlockSeen(ctx context.Context, block *types.Block) error {
+	for _, handler := range m.handlers {
+		if err := handler.BlockSeen(ctx, block); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// BlockAdded invokes BlockAdded on each Handler.
+func (m *multiHandler) BlockAdded(ctx context.Context, block *types.Block) error {
+	for _, handler := range m.handlers {
+		if err := handler.BlockAdded(ctx, block); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// BlockRemoved invokes BlockRemoved on each Handler.
+func (m *multiHandler) BlockRemoved(
+	ctx context.Context,
+	block *types.BlockIdentifier,
+) error {
+	for _, handler := range m.handlers {
+		if err := handler.BlockRemoved(ctx, block); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// BlocksAdded invokes BlocksAdded on each Handler that
+// implements BatchHandler and BlockAdded for each block
+// on all other Handlers.
+func (m *multiHandler) BlocksAdded(ctx context.Context, blocks []*types.Block) error {
+	for _, handler := range m.handlers {
+		if batchHandler, ok := handler.(BatchHandler); ok {
+			if err := batchHandler.BlocksAdded(ctx, blocks); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		for _, block := range blocks {
+			if err := handler.BlockAdded(ctx, block); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ReorgStarted invokes ReorgStarted on each
+// Handler that implements ReorgHandler.
+func (m *multiHandler) ReorgStarted(
+	ctx context.Context,
+	head *types.BlockIdentifier,
+) error {
+	for _, handler := range m.handlers {
+		reorgHandler, ok := handler.(ReorgHandler)
+		if !ok {
+			continue
+		}
+
+		if err := reorgHandler.ReorgStarted(ctx, head); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReorgFinished invokes ReorgFinished on each
+// Handler that implements ReorgHandler.
+func (m *multiHandler) ReorgFinished(
+	ctx context.Context,
+	lastCommon *types.BlockIdentifier,
+	newHead *types.BlockIdentifier,
+) error {
+	for _, handler := range m.handlers {
+		reorgHandler, ok := handler.(ReorgHandler)
+		if !ok {
+			continue
+		}
+
+		if err := reorgHandler.ReorgFinished(ctx, lastCommon, newHead); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,189 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	mocks "github.com/coinbase/rosetta-sdk-go/mocks/syncer"
+)
+
+func TestMultiHandler(t *testing.T) {
+	handlerErr := errors.New("handler failed")
+
+	var tests = map[string]struct {
+		errs []error
+
+		expectedCalls []int
+		expectedError error
+	}{
+		"no handlers": {
+			expectedCalls: []int{},
+		},
+		"all succeed": {
+			errs:          []error{nil, nil, nil},
+			expectedCalls: []int{0, 1, 2},
+		},
+		"middle handler fails": {
+			errs:          []error{nil, handlerErr, nil},
+			expectedCalls: []int{0, 1},
+			expectedError: handlerErr,
+		},
+		"first handler fails": {
+			errs:          []error{handlerErr, nil},
+			expectedCalls: []int{0},
+			expectedError: handlerErr,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			block := blockSequence[1]
+
+			for _, method := range []string{"BlockSeen", "BlockAdded", "BlockRemoved"} {
+				calls := []int{}
+				handlers := make([]Handler, len(test.errs))
+				mockHandlers := make([]*mocks.Handler, len(test.errs))
+				for i, err := range test.errs {
+					i := i
+					mockHandler := &mocks.Handler{}
+					arg := interface{}(block)
+					if method == "BlockRemoved" {
+						arg = block.BlockIdentifier
+					}
+
+					if i < len(test.expectedCalls) {
+						mockHandler.On(method, ctx, arg).Run(func(args mock.Arguments) {
+							calls = append(calls, i)
+						}).Return(err).Once()
+					}
+
+					handlers[i] = mockHandler
+					mockHandlers[i] = mockHandler
+				}
+
+				handler := MultiHandler(handlers...)
+				var err error
+				switch method {
+				case "BlockSeen":
+					err = handler.BlockSeen(ctx, block)
+				case "BlockAdded":
+					err = handler.BlockAdded(ctx, block)
+				case "BlockRemoved":
+					err = handler.BlockRemoved(ctx, block.BlockIdentifier)
+				}
+
+				assert.Equal(t, test.expectedError, err, method)
+				assert.Equal(t, test.expectedCalls, calls, method)
+				for _, mockHandler := range mockHandlers {
+					mockHandler.AssertExpectations(t)
+				}
+			}
+		})
+	}
+}
+
+func TestMultiHandler_BlocksAdded(t *testing.T) {
+	ctx := context.Background()
+	blocks := blockSequence[1:3]
+	handlerErr := errors.New("handler failed")
+
+	t.Run("batch and non-batch handlers", func(t *testing.T) {
+		mockBatch := &batchHandler{Handler: &mocks.Handler{}, BatchHandler: &mocks.BatchHandler{}}
+		mockBatch.BatchHandler.On("BlocksAdded", ctx, blocks).Return(nil).Once()
+
+		mockHandler := &mocks.Handler{}
+		for _, block := range blocks {
+			mockHandler.On("BlockAdded", ctx, block).Return(nil).Once()
+		}
+
+		handler := MultiHandler(mockBatch, mockHandler)
+		batch, ok := handler.(BatchHandler)
+		assert.True(t, ok)
+		assert.NoError(t, batch.BlocksAdded(ctx, blocks))
+		mockBatch.BatchHandler.AssertExpectations(t)
+		mockBatch.Handler.AssertNotCalled(t, "BlockAdded", mock.Anything, mock.Anything)
+		mockHandler.AssertExpectations(t)
+	})
+
+	t.Run("handler fails", func(t *testing.T) {
+		mockHandler := &mocks.Handler{}
+		mockHandler.On("BlockAdded", ctx, blocks[0]).Return(handlerErr).Once()
+
+		mockBatch := &batchHandler{Handler: &mocks.Handler{}, BatchHandler: &mocks.BatchHandler{}}
+
+		handler := MultiHandler(mockHandler, mockBatch)
+		err := handler.(BatchHandler).BlocksAdded(ctx, blocks)
+		assert.Equal(t, handlerErr, err)
+		mockHandler.AssertExpectations(t)
+		mockBatch.BatchHandler.AssertNotCalled(t, "BlocksAdded", mock.Anything, mock.Anything)
+	})
+}
+
+func TestMultiHandler_Reorg(t *testing.T) {
+	ctx := context.Background()
+	head := blockSequence[2].BlockIdentifier
+	lastCommon := blockSequence[0].BlockIdentifier
+	newHead := blockSequence[1].BlockIdentifier
+	handlerErr := errors.New("handler failed")
+
+	t.Run("reorg and non-reorg handlers", func(t *testing.T) {
+		mockReorg := &reorgHandler{Handler: &mocks.Handler{}, ReorgHandler: &mocks.ReorgHandler{}}
+		mockReorg.ReorgHandler.On("ReorgStarted", ctx, head).Return(nil).Once()
+		mockReorg.ReorgHandler.On("ReorgFinished", ctx, lastCommon, newHead).Return(nil).Once()
+
+		mockHandler := &mocks.Handler{}
+
+		handler := MultiHandler(mockHandler, mockReorg)
+		reorg, ok := handler.(ReorgHandler)
+		assert.True(t, ok)
+		assert.NoError(t, reorg.ReorgStarted(ctx, head))
+		assert.NoError(t, reorg.ReorgFinished(ctx, lastCommon, newHead))
+		mockReorg.ReorgHandler.AssertExpectations(t)
+		mockHandler.AssertExpectations(t)
+	})
+
+	t.Run("handler fails", func(t *testing.T) {
+		mockReorg := &reorgHandler{Handler: &mocks.Handler{}, ReorgHandler: &mocks.ReorgHandler{}}
+		mockReorg.ReorgHandler.On("ReorgStarted", ctx, head).Return(handlerErr).Once()
+		mockReorg.ReorgHandler.On(
+			"ReorgFinished",
+			ctx,
+			lastCommon,
+			newHead,
+		).Return(handlerErr).Once()
+
+		mockReorg2 := &reorgHandler{Handler: &mocks.Handler{}, ReorgHandler: &mocks.ReorgHandler{}}
+
+		reorg := MultiHandler(mockReorg, mockReorg2).(ReorgHandler)
+		assert.Equal(t, handlerErr, reorg.ReorgStarted(ctx, head))
+		assert.Equal(t, handlerErr, reorg.ReorgFinished(ctx, lastCommon, newHead))
+		mockReorg.ReorgHandler.AssertExpectations(t)
+		mockReorg2.ReorgHandler.AssertNotCalled(t, "ReorgStarted", mock.Anything, mock.Anything)
+		mockReorg2.ReorgHandler.AssertNotCalled(
+			t,
+			"ReorgFinished",
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+		)
+	})
+}
@@ -19,10 +19,12 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math/rand"
 	"time"
 
 	"golang.org/x/sync/errgroup"
 
+	"github.com/coinbase/rosetta-sdk-go/asserter"
 	"github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/coinbase/rosetta-sdk-go/utils"
 )
@@ -48,6 +50,9 @@ func New(
 		pastBlocks:       []*types.BlockIdentifier{},
 		pastBlockLimit:   DefaultPastBlockLimit,
 		adjustmentWindow: DefaultAdjustmentWindow,
+		fetchBackoffBase: DefaultFetchBackoffBase,
+		fetchBackoffMax:  DefaultFetchBackoffMax,
+		clock:            utils.RealClock{},
 	}
 
 	// Override defaults with any provided options
@@ -55,6 +60,20 @@ func New(
 		opt(s)
 	}
 
+	if batchHandler, ok := handler.(BatchHandler); ok && s.batchSize > 0 {
+		s.batchHandler = batchHandler
+	}
+
+	if batchHelper, ok := helper.(BatchHelper); ok && s.fetchBatchSize > 1 {
+		s.batchHelper = batchHelper
+	}
+
+	if omittedHelper, ok := helper.(OmittedHelper); ok &&
+		s.batchHelper == nil && s.direction == Ascending {
+		s.omittedHelper = omittedHelper
+		s.omittedGaps = map[int64]int64{}
+	}
+
 	return s
 }
 
@@ -73,14 +92,55 @@ func (s *Syncer) setStart(
 	s.genesisBlock = networkStatus.GenesisBlockIdentifier
 
 	if index != -1 {
-		s.nextIndex = index
+		s.setNextIndex(index)
 		return nil
 	}
 
-	s.nextIndex = networkStatus.GenesisBlockIdentifier.Index
+	if s.direction == Descending {
+		s.setNextIndex(networkStatus.CurrentBlockIdentifier.Index)
+		return nil
+	}
+
+	s.setNextIndex(networkStatus.GenesisBlockIdentifier.Index)
 	return nil
 }
 
+// step returns the change in index between
+// consecutive blocks in the sync direction.
+func (s *Syncer) step() int64 {
+	if s.direction == Descending {
+		return -1
+	}
+
+	return 1
+}
+
+// indexStride returns the change in index between
+// consecutive indices sent to fetchBlocks (a batch
+// of blocks is fetched for each index if a BatchHelper
+// is used).
+func (s *Syncer) indexStride() int64 {
+	if s.batchHelper != nil {
+		return s.fetchBatchSize * s.step()
+	}
+
+	return s.step()
+}
+
+// beyond returns a boolean indicating if index
+// is past endIndex in the sync direction.
+func (s *Syncer) beyond(index int64, endIndex int64) bool {
+	return (index-endIndex)*s.step() > 0
+}
+
+// setNextIndex updates nextIndex while holding
+// concurrencyLock (see SyncProgress).
+func (s *Syncer) setNextIndex(index int64) {
+	s.concurrencyLock.Lock()
+	s.nextIndex = index
+	s.concurrencyLock.Unlock()
+}
+
 // nextSyncableRange returns the next range of indexes to sync
 // based on what the last processed block in storage is and
 // the contents of the network status response.
@@ -88,8 +148,27 @@ func (s *Syncer) nextSyncableRange(
 	ctx context.Context,
 	endIndex int64,
 ) (int64, bool, error) {
-	if s.nextIndex == -1 {
-		return -1, false, ErrGetCurrentHeadBlockFailed
+	networkStatus, rangeEnd, halt, err := s.syncableRange(ctx, endIndex)
+
+	// Update the syncer's known tip
+	if networkStatus != nil {
+		s.tip = networkStatus.CurrentBlockIdentifier
+	}
+
+	return rangeEnd, halt, err
+}
+
+// syncableRange computes the next range of indexes to sync
+// without modifying any syncer state. If the network status
+// was fetched, it is returned so the caller can update the tip.
+func (s *Syncer) syncableRange(
+	ctx context.Context,
+	endIndex int64,
+) (*types.NetworkStatusResponse, int64, bool, error) {
+	// When syncing in the Descending direction, nextIndex
+	// is -1 after the genesis block at index 0 is processed.
+	if s.nextIndex == -1 && s.direction == Ascending {
+		return nil, -1, false, ErrGetCurrentHeadBlockFailed
 	}
 
 	// Always fetch network status to ensure endIndex is not
@@ -99,21 +178,49 @@ func (s *Syncer) nextSyncableRange(
 		s.network,
 	)
 	if err != nil {
-		return -1, false, fmt.Errorf("%w: %v", ErrGetNetworkStatusFailed, err)
+		return nil, -1, false, fmt.Errorf("%w: %v", ErrGetNetworkStatusFailed, err)
 	}
 
-	// Update the syncer's known tip
-	s.tip = networkStatus.CurrentBlockIdentifier
+	if s.direction == Descending {
+		if endIndex == -1 {
+			endIndex = networkStatus.GenesisBlockIdentifier.Index
+		}
 
-	if endIndex == -1 || endIndex > networkStatus.CurrentBlockIdentifier.Index {
+		// Wait for the tip to reach the
+		// first index we will sync.
+		if s.nextIndex > networkStatus.CurrentBlockIdentifier.Index {
+			return networkStatus, -1, true, nil
+		}
+	} else if endIndex == -1 || endIndex > networkStatus.CurrentBlockIdentifier.Index {
 		endIndex = networkStatus.CurrentBlockIdentifier.Index
 	}
 
-	if s.nextIndex > endIndex {
-		return -1, true, nil
+	if s.beyond(s.nextIndex, endIndex) {
+		return networkStatus, -1, true, nil
 	}
 
-	return endIndex, false, nil
+	return networkStatus, endIndex, false, nil
+}
+
+// NextRange returns the range of indexes [start, end] the syncer
+// would process next (capped at endIndex, or at tip if endIndex
+// is -1). If there is nothing to sync, halt is true. NextRange
+// does not modify syncer state, so it is safe to call repeatedly
+// but should not be called concurrently with Sync.
+func (s *Syncer) NextRange(
+	ctx context.Context,
+	endIndex int64,
+) (int64, int64, bool, error) {
+	_, rangeEnd, halt, err := s.syncableRange(ctx, endIndex)
+	if err != nil {
+		return -1, -1, false, fmt.Errorf("%w: %v", ErrNextSyncableRangeFailed, err)
+	}
+
+	if halt {
+		return -1, -1, true, nil
+	}
+
+	return s.nextIndex, rangeEnd, false, nil
 }
 
 func (s *Syncer) attemptOrphan(
@@ -126,11 +233,36 @@ func (s *Syncer) attemptOrphan(
 	return true, lastBlock, nil
 }
 
+// checkAppend ensures a block can be appended when
+// reorg handling is disabled.
+func (s *Syncer) checkAppend(br *blockResult) error {
+	if br.orphanHead {
+		return fmt.Errorf("%w: cannot orphan head at %d", ErrReorgHandlingDisabled, br.index)
+	}
+
+	if br.block.BlockIdentifier.Index != s.nextIndex {
+		return fmt.Errorf(
+			"%w: got block %d instead of %d",
+			ErrOutOfOrder,
+			br.block.BlockIdentifier.Index,
+			s.nextIndex,
+		)
+	}
+
+	return nil
+}
+
 func (s *Syncer) checkRemove(
 	br *blockResult,
 ) (bool, *types.BlockIdentifier, error) {
+	if s.disableReorgs {
+		return false, nil, s.checkAppend(br)
+	}
+
+	// If every tracked block was orphaned, the next block
+	// must connect to the block that preceded them.
 	if len(s.pastBlocks) == 0 {
-		return false, nil, nil
+		return false, nil, s.checkWindowParent(br)
 	}
 
 	lastBlock := s.pastBlocks[len(s.pastBlocks)-1]
@@ -157,6 +289,33 @@ func (s *Syncer) checkRemove(
 	return false, lastBlock, nil
 }
 
+// checkWindowParent returns ErrReorgExceedsWindow if the block
+// in br does not connect to windowParent (when it is known).
+func (s *Syncer) checkWindowParent(br *blockResult) error {
+	if s.windowParent == nil {
+		return nil
+	}
+
+	if br.orphanHead {
+		return fmt.Errorf(
+			"%w: cannot orphan block %d",
+			ErrReorgExceedsWindow,
+			s.windowParent.Index,
+		)
+	}
+
+	if types.Hash(br.block.ParentBlockIdentifier) != types.Hash(s.windowParent) {
+		return fmt.Errorf(
+			"%w: block %d does not connect to block %d",
+			ErrReorgExceedsWindow,
+			br.block.BlockIdentifier.Index,
+			s.windowParent.Index,
+		)
+	}
+
+	return nil
+}
+
 func (s *Syncer) processBlock(
 	ctx context.Context,
 	br *blockResult,
@@ -167,7 +326,12 @@ func (s *Syncer) processBlock(
 	// If the block is omitted, increase
 	// index and return.
 	if br.block == nil && !br.orphanHead {
-		s.nextIndex++
+		if br.omittedUntil > br.index {
+			s.setNextIndex(br.omittedUntil)
+			return nil
+		}
+
+		s.setNextIndex(s.nextIndex + s.step())
 		return nil
 	}
 
@@ -177,32 +341,148 @@ func (s *Syncer) processBlock(
 	}
 
 	if shouldRemove {
-		err = s.handler.BlockRemoved(ctx, lastBlock)
+		// The handler must observe all pending blocks before
+		// any of them can be removed.
+		if err := s.flushBlocks(ctx); err != nil {
+			return err
+		}
+
+		if reorgHandler, ok := s.handler.(ReorgHandler); ok && !s.inReorg {
+			err = s.retryHandler(ctx, func() error {
+				return reorgHandler.ReorgStarted(ctx, lastBlock)
+			})
+			if err != nil {
+				return err
+			}
+		}
+		s.inReorg = true
+
+		err = s.retryHandler(ctx, func() error {
+			return s.handler.BlockRemoved(ctx, lastBlock)
+		})
 		if err != nil {
 			return err
 		}
 		s.pastBlocks = s.pastBlocks[:len(s.pastBlocks)-1]
-		s.nextIndex = lastBlock.Index
+		s.lastBlock = nil
+		if len(s.pastBlocks) > 0 {
+			s.lastBlock = s.pastBlocks[len(s.pastBlocks)-1]
+		}
+		s.setNextIndex(lastBlock.Index)
 		return nil
 	}
 
 	block := br.block
-	err = s.handler.BlockAdded(ctx, block)
+	switch {
+	case s.skipBlock(block):
+		// The genesis block is still tracked (so that its
+		// children can be connected to it) but it is
+		// not delivered to the Handler.
+	case s.batchHandler != nil:
+		s.pendingBlocks = append(s.pendingBlocks, s.filterOperations(block))
+		if len(s.pendingBlocks) >= s.batchSize {
+			err = s.flushBlocks(ctx)
+		}
+	default:
+		filtered := s.filterOperations(block)
+		err = s.retryHandler(ctx, func() error {
+			return s.handler.BlockAdded(ctx, filtered)
+		})
+	}
 	if err != nil {
 		return err
 	}
 
-	s.pastBlocks = append(s.pastBlocks, block.BlockIdentifier)
-	if len(s.pastBlocks) > s.pastBlockLimit {
-		s.pastBlocks = s.pastBlocks[1:]
+	s.updateThroughput(s.clock.Now())
+
+	lastCommon := s.lastBlock
+	if !s.disableReorgs {
+		s.pastBlocks = append(s.pastBlocks, block.BlockIdentifier)
+		if len(s.pastBlocks) > s.pastBlockLimit {
+			s.windowParent = s.pastBlocks[0]
+			s.pastBlocks = s.pastBlocks[1:]
+		}
 	}
-	s.nextIndex = block.BlockIdentifier.Index + 1
+	s.lastBlock = block.BlockIdentifier
+	s.setNextIndex(block.BlockIdentifier.Index + s.step())
+
+	if s.inReorg {
+		s.inReorg = false
+		return s.finishReorg(ctx, lastCommon, block.BlockIdentifier)
+	}
+
+	return nil
+}
+
+// finishReorg invokes ReorgFinished (if the Handler is a
+// ReorgHandler) once all blocks added by the reorg have
+// been delivered to the Handler.
+func (s *Syncer) finishReorg(
+	ctx context.Context,
+	lastCommon *types.BlockIdentifier,
+	newHead *types.BlockIdentifier,
+) error {
+	reorgHandler, ok := s.handler.(ReorgHandler)
+	if !ok {
+		return nil
+	}
+
+	if err := s.flushBlocks(ctx); err != nil {
+		return err
+	}
+
+	return s.retryHandler(ctx, func() error {
+		return reorgHandler.ReorgFinished(ctx, lastCommon, newHead)
+	})
+}
+
+// retryHandler invokes handle until it succeeds or
+// the retries provided in WithHandlerRetry are exhausted. Errors
+// wrapping ErrHandlerNonRetriable are returned immediately.
+func (s *Syncer) retryHandler(ctx context.Context, handle func() error) error {
+	err := handle()
+	for retries := 0; err != nil && retries < s.handlerRetries; retries++ {
+		if errors.Is(err, ErrHandlerNonRetriable) {
+			return err
+		}
+
+		log.Printf("handler failed (retry %d of %d): %s\n", retries+1, s.handlerRetries, err.Error())
+		if err := utils.ContextSleepWithClock(ctx, s.clock, s.handlerBackoff); err != nil {
+			return err
+		}
+
+		err = handle()
+	}
+
+	if err != nil && s.handlerRetries > 0 && !errors.Is(err, ErrHandlerNonRetriable) {
+		return fmt.Errorf("%w: %v", ErrHandlerRetriesExhausted, err)
+	}
+
+	return err
+}
+
+// flushBlocks delivers all pending blocks to the
+// BatchHandler.
+func (s *Syncer) flushBlocks(ctx context.Context) error {
+	if len(s.pendingBlocks) == 0 {
+		return nil
+	}
+
+	blocks := s.pendingBlocks
+	s.pendingBlocks = nil
+	err := s.retryHandler(ctx, func() error {
+		return s.batchHandler.BlocksAdded(ctx, blocks)
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrFlushBlocksFailed, err)
+	}
+
 	return nil
 }
 
 // addBlockIndices appends a range of indices (from
-// startIndex to endIndex, inclusive) to the
-// blockIndices channel. When all indices are added,
+// startIndex to endIndex, inclusive, every indexStride)
+// to the blockIndices channel. When all indices are added,
 // the channel is closed.
 func (s *Syncer) addBlockIndices(
 	ctx context.Context,
@@ -213,20 +493,30 @@ func (s *Syncer) addBlockIndices(
 	defer close(blockIndices)
 
 	i := startIndex
-	for i <= endIndex {
+	for !s.beyond(i, endIndex) {
 		s.concurrencyLock.Lock()
 		currentConcurrency := s.concurrency
 		s.concurrencyLock.Unlock()
 
 		// Don't load if we already have a healthy backlog.
 		if int64(len(blockIndices)) > currentConcurrency {
-			time.Sleep(defaultFetchSleep)
+			if err := utils.ContextSleepWithClock(ctx, s.clock, defaultFetchSleep); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		// Skip indices in a gap of omitted indices
+		// found by a goroutine fetching blocks.
+		if next, ok := s.omittedGap(i); ok {
+			i = next
 			continue
 		}
 
 		select {
 		case blockIndices <- i:
-			i++
+			i += s.indexStride()
 		case <-ctx.Done():
 			return ctx.Err()
 		}
@@ -244,19 +534,161 @@ func (s *Syncer) addBlockIndices(
 	return nil
 }
 
+// resolveOmitted is invoked when the block at index is omitted
+// (nil). If the Helper is an OmittedHelper, it returns the next
+// index > index that is not omitted (capped at endIndex+1) and
+// records the gap in omittedGaps so that the remaining indices
+// in the gap are skipped without fetching them. Otherwise, it
+// returns index.
+func (s *Syncer) resolveOmitted(
+	ctx context.Context,
+	index int64,
+	endIndex int64,
+) (int64, error) {
+	if s.omittedHelper == nil {
+		return index, nil
+	}
+
+	// omittedGapsLock is held while calling NextNonOmitted so
+	// that goroutines fetching other indices in the same gap
+	// (concurrently) find the recorded gap instead of calling
+	// NextNonOmitted again.
+	s.omittedGapsLock.Lock()
+	defer s.omittedGapsLock.Unlock()
+
+	if next, ok := s.omittedGapLocked(index); ok {
+		return next, nil
+	}
+
+	next, err := s.omittedHelper.NextNonOmitted(ctx, s.network, index)
+	if err != nil {
+		return -1, fmt.Errorf("%w %d: %v", ErrNextNonOmittedFailed, index, err)
+	}
+
+	if next <= index {
+		return index, nil
+	}
+
+	if next > endIndex+1 {
+		next = endIndex + 1
+	}
+
+	s.omittedGaps[index] = next
+	return next, nil
+}
+
+// omittedGap returns the next non-omitted index if index
+// is in a recorded gap of omitted indices.
+func (s *Syncer) omittedGap(index int64) (int64, bool) {
+	if s.omittedHelper == nil {
+		return -1, false
+	}
+
+	s.omittedGapsLock.Lock()
+	defer s.omittedGapsLock.Unlock()
+
+	return s.omittedGapLocked(index)
+}
+
+// omittedGapLocked is like omittedGap but
+// omittedGapsLock must be held.
+func (s *Syncer) omittedGapLocked(index int64) (int64, bool) {
+	for start, next := range s.omittedGaps {
+		if index >= start && index < next {
+			return next, true
+		}
+	}
+
+	return -1, false
+}
+
 func (s *Syncer) fetchBlockResult(
 	ctx context.Context,
 	network *types.NetworkIdentifier,
 	index int64,
 ) (*blockResult, error) {
-	block, err := s.helper.Block(
-		ctx,
-		network,
-		&types.PartialBlockIdentifier{
-			Index: &index,
-		},
-	)
+	blockIdentifier := &types.PartialBlockIdentifier{Index: &index}
+	block, size, err := s.fetchBlock(ctx, network, blockIdentifier)
+	for retries := 0; s.shouldRetryFetch(ctx, err) && retries < s.fetchRetries; retries++ {
+		backoff := s.fetchBackoff(retries)
+		log.Printf(
+			"unable to fetch block %d (retry %d of %d in %s): %s\n",
+			index,
+			retries+1,
+			s.fetchRetries,
+			backoff,
+			err.Error(),
+		)
+		if err := utils.ContextSleepWithClock(ctx, s.clock, backoff); err != nil {
+			return nil, err
+		}
+
+		block, size, err = s.fetchBlock(ctx, network, blockIdentifier)
+	}
+
+	if s.fetchRetries > 0 && s.shouldRetryFetch(ctx, err) {
+		err = fmt.Errorf("%w: %v", ErrFetchRetriesExhausted, err)
+	}
+
+	br, err := s.newBlockResult(ctx, index, block, err)
+	if err != nil {
+		return nil, err
+	}
+
+	br.size = size
+	return br, nil
+}
+
+// fetchBlock fetches a block from the Helper and returns
+// its size, if the Helper is a SizedHelper (otherwise
+// the size is 0).
+func (s *Syncer) fetchBlock(
+	ctx context.Context,
+	network *types.NetworkIdentifier,
+	blockIdentifier *types.PartialBlockIdentifier,
+) (*types.Block, int, error) {
+	if sizedHelper, ok := s.helper.(SizedHelper); ok {
+		return sizedHelper.SizedBlock(ctx, network, blockIdentifier)
+	}
+
+	block, err := s.helper.Block(ctx, network, blockIdentifier)
+	return block, 0, err
+}
+
+// shouldRetryFetch returns a boolean indicating if
+// a block fetch that returned err should be retried.
+func (s *Syncer) shouldRetryFetch(ctx context.Context, err error) bool {
+	return err != nil && !errors.Is(err, ErrOrphanHead) && ctx.Err() == nil
+}
+
+// fetchBackoff returns the jittered exponential backoff
+// before the retry of a block fetch after retries
+// previous retries.
+func (s *Syncer) fetchBackoff(retries int) time.Duration {
+	backoff := s.fetchBackoffBase
+	for i := 0; i < retries && backoff < s.fetchBackoffMax; i++ {
+		backoff *= 2
+	}
+	if backoff > s.fetchBackoffMax {
+		backoff = s.fetchBackoffMax
+	}
+
+	// Sleep for a random duration in [backoff/2, backoff]
+	// so that concurrent retries are spread out.
+	half := backoff / 2
+	jitter := rand.Int63n(int64(backoff-half) + 1) // #nosec G404
+
+	return half + time.Duration(jitter)
+}
 
+// newBlockResult creates a *blockResult from the response
+// of a Helper for index and notifies any observers.
+func (s *Syncer) newBlockResult(
+	ctx context.Context,
+	index int64,
+	block *types.Block,
+	err error,
+) (*blockResult, error) {
 	br := &blockResult{index: index}
 	switch {
 	case errors.Is(err, ErrOrphanHead):
@@ -267,6 +699,17 @@ func (s *Syncer) fetchBlockResult(
 		br.block = block
 	}
 
+	// Omitted blocks (nil without an error) are not validated
+	if s.asserter != nil && br.block != nil {
+		if err := s.asserter.Block(br.block); err != nil {
+			return nil, fmt.Errorf("%w %d: %v", ErrBlockInvalid, index, err)
+		}
+	}
+
+	if s.fetchObserver != nil {
+		s.fetchObserver(index, br.block, br.orphanHead)
+	}
+
 	if err := s.handleSeenBlock(ctx, br); err != nil {
 		return nil, err
 	}
@@ -274,6 +717,76 @@ func (s *Syncer) fetchBlockResult(
 	return br, nil
 }
 
+// fetchBlockResults fetches the block at index or, if a
+// BatchHelper is used, all blocks in the batch starting at
+// index (see WithBatchSize). If BlocksRange returns an error,
+// each block in the batch is fetched individually.
+func (s *Syncer) fetchBlockResults(
+	ctx context.Context,
+	network *types.NetworkIdentifier,
+	index int64,
+	endIndex int64,
+) ([]*blockResult, error) {
+	if s.batchHelper == nil {
+		if next, ok := s.omittedGap(index); ok {
+			return []*blockResult{{index: index, omittedUntil: next}}, nil
+		}
+
+		br, err := s.fetchBlockResult(ctx, network, index)
+		if err != nil {
+			return nil, err
+		}
+
+		// Skip any remaining omitted indices
+		// following an omitted block.
+		if br.block == nil && !br.orphanHead {
+			next, err := s.resolveOmitted(ctx, index, endIndex)
+			if err != nil {
+				return nil, err
+			}
+
+			if next > index {
+				br.omittedUntil = next
+			}
+		}
+
+		return []*blockResult{br}, nil
+	}
+
+	start, end := index, index+(s.fetchBatchSize-1)*s.step()
+	if s.beyond(end, endIndex) {
+		end = endIndex
+	}
+	if start > end {
+		start, end = end, start
+	}
+
+	blocks, batchErr := s.batchHelper.BlocksRange(ctx, network, start, end)
+	if batchErr == nil && int64(len(blocks)) != end-start+1 {
+		batchErr = fmt.Errorf("got %d blocks for %d-%d", len(blocks), start, end)
+	}
+
+	results := make([]*blockResult, 0, end-start+1)
+	for i := start; i <= end; i++ {
+		var (
+			br  *blockResult
+			err error
+		)
+		if batchErr != nil {
+			br, err = s.fetchBlockResult(ctx, network, i)
+		} else {
+			br, err = s.newBlockResult(ctx, i, blocks[i-start], nil)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, br)
+	}
+
+	return results, nil
+}
+
 // safeExit ensures we lower the concurrency in a lock while
 // exiting. This prevents us from accidentally increasing concurrency
 // when we are shutting down.
@@ -294,21 +807,28 @@ func (s *Syncer) fetchBlocks(
 	network *types.NetworkIdentifier,
 	blockIndices chan int64,
 	results chan *blockResult,
+	endIndex int64,
 ) error {
 	for b := range blockIndices {
-		br, err := s.fetchBlockResult(
+		brs, err := s.fetchBlockResults(
 			ctx,
 			network,
 			b,
+			endIndex,
 		)
+		if errors.Is(err, ErrBlockInvalid) {
+			return s.safeExit(err)
+		}
 		if err != nil {
 			return s.safeExit(fmt.Errorf("%w %d: %v", ErrFetchBlockFailed, b, err))
 		}
 
-		select {
-		case results <- br:
-		case <-ctx.Done():
-			return s.safeExit(ctx.Err())
+		for _, br := range brs {
+			select {
+			case results <- br:
+			case <-ctx.Done():
+				return s.safeExit(ctx.Err())
+			}
 		}
 
 		// Exit if concurrency is greater than
@@ -328,6 +848,31 @@ func (s *Syncer) fetchBlocks(
 	return s.safeExit(nil)
 }
 
+// filterOperations returns a shallow copy of block that
+// only contains operations matching the operationFilter. If
+// no operationFilter is set, the block is returned as-is.
+func (s *Syncer) filterOperations(block *types.Block) *types.Block {
+	if s.operationFilter == nil || len(block.Transactions) == 0 {
+		return block
+	}
+
+	filteredBlock := *block
+	filteredBlock.Transactions = make([]*types.Transaction, len(block.Transactions))
+	for i, tx := range block.Transactions {
+		filteredTx := *tx
+		filteredTx.Operations = nil
+		for _, op := range tx.Operations {
+			if s.operationFilter(op) {
+				filteredTx.Operations = append(filteredTx.Operations, op)
+			}
+		}
+
+		filteredBlock.Transactions[i] = &filteredTx
+	}
+
+	return &filteredBlock
+}
+
 // processBlocks is invoked whenever a new block is fetched. It attempts
 // to process as many blocks as possible.
 func (s *Syncer) processBlocks(
@@ -340,7 +885,7 @@ func (s *Syncer) processBlocks(
 	// if they don't exist in the cache.
 	reorgStart := int64(-1)
 
-	for s.nextIndex <= endIndex {
+	for !s.beyond(s.nextIndex, endIndex) {
 		br, exists := cache[s.nextIndex]
 		if !exists {
 			// Wait for more blocks if we aren't
@@ -369,10 +914,16 @@ func (s *Syncer) processBlocks(
 
 		lastProcessed := s.nextIndex
 		if err := s.processBlock(ctx, br); err != nil {
+			// ErrReorgExceedsWindow is returned as-is so that
+			// callers of Sync can detect it and re-bootstrap.
+			if errors.Is(err, ErrReorgExceedsWindow) {
+				return err
+			}
+
 			return fmt.Errorf("%w: %v", ErrBlockProcessFailed, err)
 		}
 
-		if s.nextIndex < lastProcessed && reorgStart == -1 {
+		if s.direction == Ascending && s.nextIndex < lastProcessed && reorgStart == -1 {
 			reorgStart = lastProcessed
 		}
 	}
@@ -389,17 +940,72 @@ type blockResult struct {
 	index      int64
 	block      *types.Block
 	orphanHead bool
+
+	// size is provided by a SizedHelper
+	// (0 if it is not known).
+	size int
+
+	// omittedUntil is the next non-omitted index
+	// if index starts a gap of omitted indices
+	// (see OmittedHelper).
+	omittedUntil int64
 }
 
-func (s *Syncer) adjustWorkers() bool {
-	// find max block size
+// resultSize returns the size of a *blockResult provided
+// by a SizedHelper or computes it with utils.SizeOf.
+func resultSize(br *blockResult) int {
+	if br.size > 0 {
+		return br.size
+	}
+
+	return utils.SizeOf(br)
+}
+
+// maxBlockCacheSize returns the largest recent block
+// size, padded by sizeMultiplier. concurrencyLock must
+// be held when calling maxBlockCacheSize.
+func (s *Syncer) maxBlockCacheSize() float64 {
 	maxSize := 0
 	for _, b := range s.recentBlockSizes {
 		if b > maxSize {
 			maxSize = b
 		}
 	}
-	max := float64(maxSize) * s.sizeMultiplier
+
+	return float64(maxSize) * s.sizeMultiplier
+}
+
+// fetchCacheSize returns the estimated size of the blocks held
+// in memory by each goroutine fetching blocks. When a BatchHelper
+// is used, each goroutine holds up to fetchBatchSize blocks before
+// sending any of them. concurrencyLock must be held when calling
+// fetchCacheSize.
+func (s *Syncer) fetchCacheSize() float64 {
+	max := s.maxBlockCacheSize()
+	if s.batchHelper != nil {
+		return max * float64(s.fetchBatchSize)
+	}
+
+	return max
+}
+
+// belowMaxGoroutines returns a boolean indicating if both
+// concurrency and goalConcurrency can be increased without
+// exceeding maxGoroutines. concurrency may be below
+// goalConcurrency (when a goroutine exits early) or above it
+// (before goroutines exit after a reduction), so both are checked.
+// s.concurrencyLock must be held.
+func (s *Syncer) belowMaxGoroutines() bool {
+	if s.maxGoroutines == 0 {
+		return true
+	}
+
+	return s.concurrency < s.maxGoroutines && s.goalConcurrency < s.maxGoroutines
+}
+
+func (s *Syncer) adjustWorkers() bool {
+	// find max size held by each goroutine
+	max := s.fetchCacheSize()
 
 	// Check if we have entered shutdown
 	// and return false if we have.
@@ -407,13 +1013,14 @@ func (s *Syncer) adjustWorkers() bool {
 		return false
 	}
 
-	// multiply average block size by concurrency
+	// multiply max goroutine cache size by concurrency
 	estimatedMaxCache := max * float64(s.concurrency)
 
 	// If < cacheSize, increase concurrency by 1 up to MaxConcurrency
 	shouldCreate := false
 	if estimatedMaxCache+max < float64(s.cacheSize) &&
 		s.concurrency < s.maxConcurrency &&
+		s.belowMaxGoroutines() &&
 		s.lastAdjustment > s.adjustmentWindow {
 		s.goalConcurrency++
 		s.concurrency++
@@ -456,6 +1063,13 @@ func (s *Syncer) adjustWorkers() bool {
 	return shouldCreate
 }
 
+// skipBlock returns a boolean indicating if a block
+// should not be delivered to the Handler.
+func (s *Syncer) skipBlock(block *types.Block) bool {
+	return s.skipGenesis &&
+		types.Hash(block.BlockIdentifier) == types.Hash(s.genesisBlock)
+}
+
 func (s *Syncer) handleSeenBlock(
 	ctx context.Context,
 	result *blockResult,
@@ -463,7 +1077,7 @@ func (s *Syncer) handleSeenBlock(
 	// If the helper returns ErrOrphanHead
 	// for a block fetch, result.block will
 	// be nil.
-	if result.block == nil {
+	if result.block == nil || s.skipBlock(result.block) {
 		return nil
 	}
 
@@ -480,17 +1094,27 @@ func (s *Syncer) sequenceBlocks( // nolint:golint
 ) error {
 	cache := make(map[int64]*blockResult)
 	for result := range fetchedBlocks {
+		// Indices in a gap of omitted indices may be fetched
+		// before the gap is found. Once the gap is skipped,
+		// their results are no longer needed.
+		if result.omittedUntil > result.index && result.index < s.nextIndex {
+			continue
+		}
+
 		cache[result.index] = result
 
 		if err := s.processBlocks(ctx, cache, endIndex); err != nil {
+			if errors.Is(err, ErrReorgExceedsWindow) {
+				return err
+			}
+
 			return fmt.Errorf("%w: %v", ErrBlocksProcessMultipleFailed, err)
 		}
 
 		// Determine if concurrency should be adjusted.
-		s.recentBlockSizes = append(s.recentBlockSizes, utils.SizeOf(result))
-		s.lastAdjustment++
-
 		s.concurrencyLock.Lock()
+		s.recentBlockSizes = append(s.recentBlockSizes, resultSize(result))
+		s.lastAdjustment++
 		shouldCreate := s.adjustWorkers()
 		if !shouldCreate {
 			s.concurrencyLock.Unlock()
@@ -510,10 +1134,14 @@ func (s *Syncer) sequenceBlocks( // nolint:golint
 					s.network,
 					blockIndices,
 					fetchedBlocks,
+					endIndex,
 				)
 			})
 		} else {
+			// Undo the increase made by adjustWorkers so
+			// that goalConcurrency does not drift upwards.
 			s.concurrency--
+			s.goalConcurrency--
 		}
 		s.doneLoadingLock.Unlock()
 
@@ -535,25 +1163,49 @@ func (s *Syncer) syncRange(
 	blockIndices := make(chan int64)
 	fetchedBlocks := make(chan *blockResult)
 
+	if s.omittedHelper != nil {
+		s.omittedGapsLock.Lock()
+		s.omittedGaps = map[int64]int64{}
+		s.omittedGapsLock.Unlock()
+	}
+
 	// Ensure default concurrency is less than max concurrency.
 	startingConcurrency := DefaultConcurrency
 	if s.maxConcurrency < startingConcurrency {
 		startingConcurrency = s.maxConcurrency
 	}
 
+	// Each goroutine holds a batch of blocks when a BatchHelper
+	// is used, so we start with proportionally fewer goroutines.
+	if s.batchHelper != nil {
+		startingConcurrency /= s.fetchBatchSize
+		if startingConcurrency < MinConcurrency {
+			startingConcurrency = MinConcurrency
+		}
+	}
+
 	// Don't create more goroutines than there are blocks
-	// to sync.
-	blocksToSync := endIndex - s.nextIndex + 1
-	if blocksToSync < startingConcurrency {
-		startingConcurrency = blocksToSync
+	// (or batches of blocks) to sync.
+	fetchesToSync := (endIndex-s.nextIndex)*s.step() + 1
+	if s.batchHelper != nil {
+		fetchesToSync = (fetchesToSync + s.fetchBatchSize - 1) / s.fetchBatchSize
+	}
+	if fetchesToSync < startingConcurrency {
+		startingConcurrency = fetchesToSync
+	}
+
+	if s.maxGoroutines > 0 && s.maxGoroutines < startingConcurrency {
+		startingConcurrency = s.maxGoroutines
 	}
 
 	// Reset sync variables
+	s.concurrencyLock.Lock()
 	s.recentBlockSizes = []int{}
 	s.lastAdjustment = 0
 	s.doneLoading = false
 	s.concurrency = startingConcurrency
 	s.goalConcurrency = s.concurrency
+	s.concurrencyLock.Unlock()
 
 	// We create a separate derivative context here instead of
 	// replacing the provided ctx because the context returned
@@ -570,7 +1222,7 @@ func (s *Syncer) syncRange(
 
 	for j := int64(0); j < s.concurrency; j++ {
 		g.Go(func() error {
-			return s.fetchBlocks(pipelineCtx, s.network, blockIndices, fetchedBlocks)
+			return s.fetchBlocks(pipelineCtx, s.network, blockIndices, fetchedBlocks, endIndex)
 		})
 	}
 
@@ -610,17 +1262,140 @@ func (s *Syncer) Tip() *types.BlockIdentifier {
 	return s.tip
 }
 
+// SyncProgress returns a snapshot of the progress
+// of the syncer.
+//
+// It is safe to call SyncProgress concurrently with Sync.
+func (s *Syncer) SyncProgress() *SyncProgress {
+	s.concurrencyLock.Lock()
+	defer s.concurrencyLock.Unlock()
+
+	return &SyncProgress{
+		NextIndex:                 s.nextIndex,
+		Concurrency:               s.concurrency,
+		GoalConcurrency:           s.goalConcurrency,
+		CurrentCacheEstimateBytes: int(s.fetchCacheSize() * float64(s.concurrency)),
+	}
+}
+
+// updateThroughput records that a block was processed
+// at now.
+func (s *Syncer) updateThroughput(now time.Time) {
+	s.throughputLock.Lock()
+	defer s.throughputLock.Unlock()
+
+	if s.lastProcessed.IsZero() {
+		s.lastProcessed = now
+		return
+	}
+
+	interval := now.Sub(s.lastProcessed).Seconds()
+	s.lastProcessed = now
+	if s.averageInterval == 0 {
+		s.averageInterval = interval
+		return
+	}
+
+	s.averageInterval = throughputSmoothing*interval +
+		(1-throughputSmoothing)*s.averageInterval
+}
+
+// throughputAt returns the throughput observed at now.
+func (s *Syncer) throughputAt(now time.Time) float64 {
+	s.throughputLock.Lock()
+	defer s.throughputLock.Unlock()
+
+	if s.averageInterval == 0 {
+		return 0
+	}
+
+	// If we have been waiting longer than the average interval
+	// for the next block, we treat the pending interval as if it
+	// has completed so that throughput decays while stalled.
+	averageInterval := s.averageInterval
+	pendingInterval := now.Sub(s.lastProcessed).Seconds()
+	if pendingInterval > averageInterval {
+		averageInterval = throughputSmoothing*pendingInterval +
+			(1-throughputSmoothing)*averageInterval
+	}
+
+	return 1 / averageInterval
+}
+
+// Throughput returns an exponential moving average of the
+// number of blocks processed per second. If fewer than 2
+// blocks have been added, it returns 0.
+//
+// It is safe to call Throughput concurrently with Sync.
+func (s *Syncer) Throughput() float64 {
+	return s.throughputAt(s.clock.Now())
+}
+
+// shouldLogRange returns a boolean indicating if a range
+// ending at rangeEnd should be logged. If a log interval is set
+// (see WithQuietSync), a range is only logged if it reaches
+// a multiple of the interval not already covered by a
+// previously logged range.
+func (s *Syncer) shouldLogRange(rangeEnd int64) bool {
+	if s.syncLogInterval <= 0 {
+		return true
+	}
+
+	bucket := rangeEnd / s.syncLogInterval
+	if bucket <= s.lastLoggedBucket {
+		return false
+	}
+
+	s.lastLoggedBucket = bucket
+	return true
+}
+
+// idleHeartbeat invokes the heartbeat (if configured)
+// when heartbeatInterval has elapsed since the last
+// heartbeat (or since the syncer became idle).
+func (s *Syncer) idleHeartbeat() {
+	if s.heartbeat == nil {
+		return
+	}
+
+	now := s.clock.Now()
+	if s.lastHeartbeat.IsZero() {
+		s.lastHeartbeat = now
+		return
+	}
+
+	if now.Sub(s.lastHeartbeat) < s.heartbeatInterval {
+		return
+	}
+
+	s.lastHeartbeat = now
+	s.heartbeat(s.nextIndex)
+}
+
 // Sync cycles endlessly until there is an error
 // or the requested range is synced. When the requested
 // range is synced, context is canceled.
+//
+// When syncing in the Descending direction (see
+// WithSyncDirection), a startIndex of -1 starts at tip
+// and an endIndex of -1 ends at genesis.
 func (s *Syncer) Sync(
 	ctx context.Context,
 	startIndex int64,
 	endIndex int64,
 ) error {
+	if err := asserter.NetworkIdentifier(s.network); err != nil {
+		return fmt.Errorf("%w: invalid network identifier", err)
+	}
+
 	if err := s.setStart(ctx, startIndex); err != nil {
 		return fmt.Errorf("%w: %v", ErrSetStartIndexFailed, err)
 	}
+	startIndex = s.nextIndex
+
+	if s.direction == Descending && endIndex == -1 {
+		endIndex = s.genesisBlock.Index
+	}
 
 	for {
 		rangeEnd, halt, err := s.nextSyncableRange(
@@ -632,35 +1407,109 @@ func (s *Syncer) Sync(
 		}
 
 		if halt {
-			if s.nextIndex > endIndex && endIndex != -1 {
+			if s.beyond(s.nextIndex, endIndex) && endIndex != -1 {
 				break
 			}
 
-			time.Sleep(defaultSyncSleep)
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			s.idleHeartbeat()
+			if err := utils.ContextSleepWithClock(ctx, s.clock, defaultSyncSleep); err != nil {
+				return err
+			}
+
 			continue
 		}
 
-		if s.nextIndex != rangeEnd {
-			log.Printf("Syncing %d-%d\n", s.nextIndex, rangeEnd)
-		} else {
-			log.Printf("Syncing %d\n", s.nextIndex)
+		// Heartbeats restart whenever we become idle again
+		s.lastHeartbeat = time.Time{}
+
+		if s.shouldLogRange(rangeEnd) {
+			if s.nextIndex != rangeEnd {
+				log.Printf("Syncing %d-%d\n", s.nextIndex, rangeEnd)
+			} else {
+				log.Printf("Syncing %d\n", s.nextIndex)
+			}
 		}
 
 		err = s.syncRange(ctx, rangeEnd)
+		if ctx.Err() != nil {
+			// ctx is already canceled, so we deliver any blocks
+			// we have already processed with a new context.
+			if err := s.flushBlocks(context.Background()); err != nil {
+				return err
+			}
+
+			return ctx.Err()
+		}
+
 		if err != nil {
 			return fmt.Errorf("%w: unable to sync to %d", err, rangeEnd)
 		}
 
-		if ctx.Err() != nil {
-			return ctx.Err()
+		if err := s.flushBlocks(ctx); err != nil {
+			return err
 		}
 	}
 
-	if startIndex == -1 {
-		startIndex = s.genesisBlock.Index
-	}
-
 	s.cancel()
 	log.Printf("Finished syncing %d-%d\n", startIndex, endIndex)
 	return nil
 }
+
+// headBlock returns the last block processed by the syncer
+// (or the last block provided with WithPastBlocks), if any.
+func (s *Syncer) headBlock() *types.BlockIdentifier {
+	if s.lastBlock != nil {
+		return s.lastBlock
+	}
+
+	if len(s.pastBlocks) > 0 {
+		return s.pastBlocks[len(s.pastBlocks)-1]
+	}
+
+	return nil
+}
+
+// SyncToTip syncs to the current tip of the network (fetched once
+// when SyncToTip is invoked) and then stops. Blocks added to the
+// network after SyncToTip is invoked are not synced. This is useful
+// for periodic jobs that process all blocks available when they start.
+//
+// SyncToTip resumes after the last block processed by the syncer
+// (or the last block provided with WithPastBlocks). If there is
+// no such block, it starts at genesis.
+//
+// The head block once the tip is reached is returned. If there
+// is no head block, ErrNoHeadBlock is returned.
+//
+// SyncToTip returns ErrSyncToTipDescending if the syncer syncs
+// in the Descending direction (use Sync instead).
+func (s *Syncer) SyncToTip(ctx context.Context) (*types.BlockIdentifier, error) {
+	if s.direction == Descending {
+		return nil, ErrSyncToTipDescending
+	}
+
+	networkStatus, err := s.helper.NetworkStatus(ctx, s.network)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGetNetworkStatusFailed, err)
+	}
+
+	startIndex := int64(-1)
+	if head := s.headBlock(); head != nil {
+		startIndex = head.Index + 1
+	}
+
+	if err := s.Sync(ctx, startIndex, networkStatus.CurrentBlockIdentifier.Index); err != nil {
+		return nil, err
+	}
+
+	head := s.headBlock()
+	if head == nil {
+		return nil, ErrNoHeadBlock
+	}
+
+	return head, nil
+}
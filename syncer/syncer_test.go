@@ -18,14 +18,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"github.com/coinbase/rosetta-sdk-go/asserter"
 	mocks "github.com/coinbase/rosetta-sdk-go/mocks/syncer"
+	mockUtils "github.com/coinbase/rosetta-sdk-go/mocks/utils"
 	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/coinbase/rosetta-sdk-go/utils"
 )
 
 var (
@@ -508,17 +514,17 @@ func TestSync_NoReorg(t *testing.T) {
 	mockHandler.AssertExpectations(t)
 }
 
-func TestSync_SpecificStart(t *testing.T) {
+func TestSyncProgress(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	mockHelper := &mocks.Helper{}
 	mockHandler := &mocks.Handler{}
-	syncer := New(networkIdentifier, mockHelper, mockHandler, cancel)
+	syncer := New(networkIdentifier, mockHelper, mockHandler, cancel, WithMaxConcurrency(3))
 
 	mockHelper.On("NetworkStatus", ctx, networkIdentifier).Return(&types.NetworkStatusResponse{
 		CurrentBlockIdentifier: &types.BlockIdentifier{
-			Hash:  "block 1300",
-			Index: 1300,
+			Hash:  "block 400",
+			Index: 400,
 		},
 		GenesisBlockIdentifier: &types.BlockIdentifier{
 			Hash:  "block 0",
@@ -526,7 +532,7 @@ func TestSync_SpecificStart(t *testing.T) {
 		},
 	}, nil)
 
-	blocks := createBlocks(100, 1200, "")
+	blocks := createBlocks(0, 400, "")
 	for _, b := range blocks {
 		mockHelper.On(
 			"Block",
@@ -536,9 +542,7 @@ func TestSync_SpecificStart(t *testing.T) {
 		).Return(
 			b,
 			nil,
-		).Run(func(args mock.Arguments) {
-			assertNotCanceled(t, args)
-		}).Once()
+		).Once()
 		mockHandler.On(
 			"BlockSeen",
 			mock.AnythingOfType("*context.cancelCtx"),
@@ -552,63 +556,210 @@ func TestSync_SpecificStart(t *testing.T) {
 			b,
 		).Return(
 			nil,
-		).Run(func(args mock.Arguments) {
-			assertNotCanceled(t, args)
-			if b.BlockIdentifier.Index == 1100 {
-				assert.True(t, syncer.concurrency > DefaultConcurrency)
-			}
-		}).Once()
+		).Once()
 	}
 
-	err := syncer.Sync(ctx, 100, 1200)
+	// Poll progress while syncing
+	done := make(chan struct{})
+	polled := make(chan []*SyncProgress)
+	go func() {
+		progress := []*SyncProgress{}
+		for {
+			select {
+			case <-done:
+				polled <- progress
+				return
+			default:
+				progress = append(progress, syncer.SyncProgress())
+			}
+		}
+	}()
+
+	err := syncer.Sync(ctx, -1, 400)
+	close(done)
+	progress := <-polled
 	assert.NoError(t, err)
-	assert.Equal(t, int64(0), syncer.concurrency)
-	mockHelper.AssertNumberOfCalls(t, "NetworkStatus", 3)
+	assert.NotEmpty(t, progress)
+
+	for i, p := range progress {
+		assert.LessOrEqual(t, p.Concurrency, int64(3))
+		assert.LessOrEqual(t, p.GoalConcurrency, int64(3))
+		assert.GreaterOrEqual(t, p.CurrentCacheEstimateBytes, 0)
+		if i > 0 {
+			assert.GreaterOrEqual(t, p.NextIndex, progress[i-1].NextIndex)
+		}
+	}
+
+	final := syncer.SyncProgress()
+	assert.Equal(t, int64(401), final.NextIndex)
+	assert.Equal(t, int64(0), final.Concurrency)
+	assert.Equal(t, 0, final.CurrentCacheEstimateBytes)
 	mockHelper.AssertExpectations(t)
 	mockHandler.AssertExpectations(t)
 }
 
-func TestSync_Cancel(t *testing.T) {
+func TestSync_Descending(t *testing.T) {
+	var tests = map[string]struct {
+		startIndex int64
+		endIndex   int64
+	}{
+		"defaults": {
+			startIndex: -1,
+			endIndex:   -1,
+		},
+		"explicit range": {
+			startIndex: 500,
+			endIndex:   0,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+
+			mockHelper := &mocks.Helper{}
+			mockHandler := &mocks.Handler{}
+			syncer := New(
+				networkIdentifier,
+				mockHelper,
+				mockHandler,
+				cancel,
+				WithSyncDirection(Descending),
+			)
+
+			mockHelper.On("NetworkStatus", ctx, networkIdentifier).Return(&types.NetworkStatusResponse{
+				CurrentBlockIdentifier: &types.BlockIdentifier{
+					Hash:  "block 500",
+					Index: 500,
+				},
+				GenesisBlockIdentifier: &types.BlockIdentifier{
+					Hash:  "block 0",
+					Index: 0,
+				},
+			}, nil)
+
+			added := []int64{}
+			blocks := createBlocks(0, 500, "")
+			for _, b := range blocks {
+				mockHelper.On(
+					"Block",
+					mock.AnythingOfType("*context.cancelCtx"),
+					networkIdentifier,
+					&types.PartialBlockIdentifier{Index: &b.BlockIdentifier.Index},
+				).Return(
+					b,
+					nil,
+				).Once()
+				mockHandler.On(
+					"BlockSeen",
+					mock.AnythingOfType("*context.cancelCtx"),
+					b,
+				).Return(
+					nil,
+				).Once()
+				mockHandler.On(
+					"BlockAdded",
+					mock.AnythingOfType("*context.cancelCtx"),
+					b,
+				).Return(
+					nil,
+				).Run(func(args mock.Arguments) {
+					added = append(added, args.Get(1).(*types.Block).BlockIdentifier.Index)
+				}).Once()
+			}
+
+			err := syncer.Sync(ctx, test.startIndex, test.endIndex)
+			assert.NoError(t, err)
+			assert.Len(t, added, len(blocks))
+			for i, index := range added {
+				assert.Equal(t, int64(500-i), index)
+			}
+			assert.Equal(t, int64(-1), syncer.nextIndex)
+			assert.Len(t, syncer.pastBlocks, 0)
+			mockHelper.AssertExpectations(t)
+			mockHandler.AssertExpectations(t)
+		})
+	}
+}
+
+type batchHelper struct {
+	*mocks.Helper
+	*mocks.BatchHelper
+}
+
+func TestSync_BatchHelper(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	mockHelper := &mocks.Helper{}
+	mockBatchHelper := &mocks.BatchHelper{}
 	mockHandler := &mocks.Handler{}
-	syncer := New(networkIdentifier, mockHelper, mockHandler, cancel)
+	syncer := New(
+		networkIdentifier,
+		&batchHelper{Helper: mockHelper, BatchHelper: mockBatchHelper},
+		mockHandler,
+		cancel,
+		WithBatchSize(10),
+	)
 
-	// Force syncer to only get part of the way through the full range
 	mockHelper.On("NetworkStatus", ctx, networkIdentifier).Return(&types.NetworkStatusResponse{
 		CurrentBlockIdentifier: &types.BlockIdentifier{
-			Hash:  "block 200",
-			Index: 200,
+			Hash:  "block 104",
+			Index: 104,
 		},
 		GenesisBlockIdentifier: &types.BlockIdentifier{
 			Hash:  "block 0",
 			Index: 0,
 		},
-	}, nil).Twice()
+	}, nil)
 
-	mockHelper.On("NetworkStatus", ctx, networkIdentifier).Return(&types.NetworkStatusResponse{
-		CurrentBlockIdentifier: &types.BlockIdentifier{
-			Hash:  "block 1300",
-			Index: 1300,
-		},
-		GenesisBlockIdentifier: &types.BlockIdentifier{
-			Hash:  "block 0",
-			Index: 0,
-		},
-	}, nil).Twice()
+	blocks := createBlocks(0, 104, "")
+	for start := int64(0); start <= 104; start += 10 {
+		end := start + 9
+		if end > 104 {
+			end = 104
+		}
 
-	blocks := createBlocks(0, 1200, "")
-	for _, b := range blocks {
-		mockHelper.On(
-			"Block",
+		// Blocks in a failed batch are fetched individually
+		if start == 30 {
+			mockBatchHelper.On(
+				"BlocksRange",
+				mock.AnythingOfType("*context.cancelCtx"),
+				networkIdentifier,
+				start,
+				end,
+			).Return(
+				nil,
+				errors.New("batch failed"),
+			).Once()
+
+			for _, b := range blocks[start : end+1] {
+				mockHelper.On(
+					"Block",
+					mock.AnythingOfType("*context.cancelCtx"),
+					networkIdentifier,
+					&types.PartialBlockIdentifier{Index: &b.BlockIdentifier.Index},
+				).Return(
+					b,
+					nil,
+				).Once()
+			}
+
+			continue
+		}
+
+		mockBatchHelper.On(
+			"BlocksRange",
 			mock.AnythingOfType("*context.cancelCtx"),
 			networkIdentifier,
-			&types.PartialBlockIdentifier{Index: &b.BlockIdentifier.Index},
+			start,
+			end,
 		).Return(
-			b,
+			blocks[start:end+1],
 			nil,
 		).Once()
+	}
+
+	for _, b := range blocks {
 		mockHandler.On(
 			"BlockSeen",
 			mock.AnythingOfType("*context.cancelCtx"),
@@ -625,133 +776,157 @@ func TestSync_Cancel(t *testing.T) {
 		).Once()
 	}
 
-	go func() {
-		time.Sleep(1 * time.Second)
-		cancel()
-	}()
-
-	err := syncer.Sync(ctx, -1, 1200)
-	assert.True(t, errors.Is(err, context.Canceled))
-	assert.Equal(t, int64(0), syncer.concurrency)
+	err := syncer.Sync(ctx, -1, 104)
+	assert.NoError(t, err)
+	mockBatchHelper.AssertNumberOfCalls(t, "BlocksRange", 11)
+	mockHelper.AssertNumberOfCalls(t, "Block", 10)
+	mockHelper.AssertExpectations(t)
+	mockBatchHelper.AssertExpectations(t)
+	mockHandler.AssertExpectations(t)
 }
 
-func TestSync_Reorg(t *testing.T) {
+func TestSync_BatchHelperCacheSize(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 
+	blocks := createBlocks(0, 399, "")
+	maxSize := 0
+	for i, b := range blocks {
+		size := utils.SizeOf(&blockResult{index: int64(i), block: b})
+		if size > maxSize {
+			maxSize = size
+		}
+	}
+
+	// The cache can hold 2.5 batches of blocks, so
+	// at most 2 goroutines should fetch batches.
+	cacheSize := int(float64(maxSize) * DefaultSizeMultiplier * 10 * 2.5)
+
 	mockHelper := &mocks.Helper{}
+	mockBatchHelper := &mocks.BatchHelper{}
 	mockHandler := &mocks.Handler{}
-	syncer := New(networkIdentifier, mockHelper, mockHandler, cancel)
+	syncer := New(
+		networkIdentifier,
+		&batchHelper{Helper: mockHelper, BatchHelper: mockBatchHelper},
+		mockHandler,
+		cancel,
+		WithBatchSize(10),
+		WithCacheSize(cacheSize),
+		WithAdjustmentWindow(1),
+	)
 
 	mockHelper.On("NetworkStatus", ctx, networkIdentifier).Return(&types.NetworkStatusResponse{
-		CurrentBlockIdentifier: &types.BlockIdentifier{
-			Hash:  "block 1300",
-			Index: 1300,
-		},
-		GenesisBlockIdentifier: &types.BlockIdentifier{
-			Hash:  "block 0",
-			Index: 0,
-		},
-	}, nil).Run(func(args mock.Arguments) {
-		err := args.Get(0).(context.Context)
-		assert.NoError(t, err.Err())
-	})
+		CurrentBlockIdentifier: blocks[399].BlockIdentifier,
+		GenesisBlockIdentifier: blocks[0].BlockIdentifier,
+	}, nil)
 
-	blocks := createBlocks(0, 800, "")
-	for _, b := range blocks { // [0, 800]
-		mockHelper.On(
-			"Block",
+	for start := int64(0); start < 400; start += 10 {
+		mockBatchHelper.On(
+			"BlocksRange",
 			mock.AnythingOfType("*context.cancelCtx"),
 			networkIdentifier,
-			&types.PartialBlockIdentifier{Index: &b.BlockIdentifier.Index},
+			start,
+			start+9,
 		).Return(
-			b,
+			blocks[start:start+10],
 			nil,
-		).Run(func(args mock.Arguments) {
-			assertNotCanceled(t, args)
-		}).Once()
+		).Once()
+	}
+
+	var maxGoal int64
+	for _, b := range blocks {
 		mockHandler.On(
 			"BlockSeen",
 			mock.AnythingOfType("*context.cancelCtx"),
 			b,
-		).Run(func(args mock.Arguments) {
-			assertNotCanceled(t, args)
-		}).Return(
+		).Return(
 			nil,
 		).Once()
 		mockHandler.On(
 			"BlockAdded",
 			mock.AnythingOfType("*context.cancelCtx"),
 			b,
-		).Run(func(args mock.Arguments) {
-			assertNotCanceled(t, args)
-		}).Return(
+		).Return(
 			nil,
-		).Once()
+		).Run(func(args mock.Arguments) {
+			progress := syncer.SyncProgress()
+			assert.LessOrEqual(t, progress.Concurrency, int64(2))
+			assert.LessOrEqual(t, progress.GoalConcurrency, int64(2))
+			assert.LessOrEqual(t, progress.CurrentCacheEstimateBytes, cacheSize)
+			if progress.GoalConcurrency > maxGoal {
+				maxGoal = progress.GoalConcurrency
+			}
+		}).Once()
 	}
 
-	// Create reorg
-	newBlocks := createBlocks(790, 1200, "other")
-	mockHelper.On(
-		"Block",
-		mock.AnythingOfType("*context.cancelCtx"),
+	err := syncer.Sync(ctx, -1, 399)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), maxGoal)
+	mockHelper.AssertExpectations(t)
+	mockBatchHelper.AssertExpectations(t)
+	mockHandler.AssertExpectations(t)
+}
+
+type omittedHelper struct {
+	*mocks.Helper
+	*mocks.OmittedHelper
+}
+
+func TestSync_OmittedHelper(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	mockHelper := &mocks.Helper{}
+	mockOmittedHelper := &mocks.OmittedHelper{}
+	mockHandler := &mocks.Handler{}
+	syncer := New(
 		networkIdentifier,
-		&types.PartialBlockIdentifier{Index: &newBlocks[11].BlockIdentifier.Index},
-	).Return(
-		newBlocks[11],
-		nil,
-	).Run(func(args mock.Arguments) {
-		err := args.Get(0).(context.Context)
-		assert.NoError(t, err.Err())
-	}).Once() // [801]
+		&omittedHelper{Helper: mockHelper, OmittedHelper: mockOmittedHelper},
+		mockHandler,
+		cancel,
+		WithMaxConcurrency(1),
+	)
 
-	// Set parent of reorg start to be last good block
-	newBlocks[0].ParentBlockIdentifier = blocks[789].BlockIdentifier
+	mockHelper.On("NetworkStatus", ctx, networkIdentifier).Return(&types.NetworkStatusResponse{
+		CurrentBlockIdentifier: &types.BlockIdentifier{
+			Hash:  "block 1100",
+			Index: 1100,
+		},
+		GenesisBlockIdentifier: &types.BlockIdentifier{
+			Hash:  "block 0",
+			Index: 0,
+		},
+	}, nil)
 
-	// Orphan last 10 blocks
-	for i := 790; i <= 800; i++ { // [790, 800]
-		thisBlock := newBlocks[i-790]
-		mockHelper.On(
-			"Block",
-			mock.AnythingOfType("*context.cancelCtx"),
-			networkIdentifier,
-			&types.PartialBlockIdentifier{Index: &thisBlock.BlockIdentifier.Index},
-		).Return(
-			thisBlock,
-			nil,
-		).Run(func(args mock.Arguments) {
-			assertNotCanceled(t, args)
-		}).Once()
-		mockHandler.On(
-			"BlockRemoved",
-			mock.AnythingOfType("*context.cancelCtx"),
-			blocks[i].BlockIdentifier,
-		).Return(
-			nil,
-		).Run(func(args mock.Arguments) {
-			assertNotCanceled(t, args)
-		}).Once()
-	}
+	// Indices 100-1099 are omitted
+	blocks := append(createBlocks(0, 99, ""), createBlocks(1100, 1100, "")...)
+	blocks[100].ParentBlockIdentifier = blocks[99].BlockIdentifier
 
-	mockHandler.On(
-		"BlockSeen",
+	mockOmittedHelper.On(
+		"NextNonOmitted",
 		mock.AnythingOfType("*context.cancelCtx"),
-		newBlocks[0],
+		networkIdentifier,
+		int64(100),
 	).Return(
+		int64(1100),
 		nil,
-	).Once() // only fetch this block once
-	mockHandler.On(
-		"BlockAdded",
+	).Once()
+
+	// Only the first index in the gap is fetched.
+	var omittedFetches int64
+	mockHelper.On(
+		"Block",
 		mock.AnythingOfType("*context.cancelCtx"),
-		newBlocks[0],
-	).Run(func(args mock.Arguments) {
-		err := args.Get(0).(context.Context)
-		assert.NoError(t, err.Err())
-	}).Return(
+		networkIdentifier,
+		mock.MatchedBy(func(identifier *types.PartialBlockIdentifier) bool {
+			return *identifier.Index >= 100 && *identifier.Index < 1100
+		}),
+	).Return(
 		nil,
-	).Once() // only fetch this block once
+		nil,
+	).Run(func(args mock.Arguments) {
+		atomic.AddInt64(&omittedFetches, 1)
+	})
 
-	// New blocks added
-	for _, b := range newBlocks[1:] { // [790, 1200]
+	for _, b := range blocks {
 		mockHelper.On(
 			"Block",
 			mock.AnythingOfType("*context.cancelCtx"),
@@ -760,132 +935,73 @@ func TestSync_Reorg(t *testing.T) {
 		).Return(
 			b,
 			nil,
-		).Run(func(args mock.Arguments) {
-			assertNotCanceled(t, args)
-		}).Once()
-
-		seenTimes := 2
-		if b.BlockIdentifier.Index > 801 {
-			seenTimes = 1
-		}
+		).Once()
 		mockHandler.On(
 			"BlockSeen",
 			mock.AnythingOfType("*context.cancelCtx"),
 			b,
 		).Return(
 			nil,
-		).Run(func(args mock.Arguments) {
-			assertNotCanceled(t, args)
-		}).Times(seenTimes)
+		).Once()
 		mockHandler.On(
 			"BlockAdded",
 			mock.AnythingOfType("*context.cancelCtx"),
 			b,
 		).Return(
 			nil,
-		).Run(func(args mock.Arguments) {
-			assertNotCanceled(t, args)
-			if b.BlockIdentifier.Index == 1100 {
-				assert.True(t, syncer.concurrency > DefaultConcurrency)
-			}
-		}).Once()
+		).Once()
 	}
 
-	// Expected Calls to Block
-	// [0, 789] = 1
-	// [790] = 2
-	// [791, 800] = 3
-	// [801] = 2
-	// [802,1200] = 1
-
-	err := syncer.Sync(ctx, -1, 1200)
+	err := syncer.Sync(ctx, -1, 1100)
 	assert.NoError(t, err)
-	assert.Equal(t, int64(0), syncer.concurrency)
-	mockHelper.AssertNumberOfCalls(t, "NetworkStatus", 3)
+	assert.Equal(t, int64(1101), syncer.nextIndex)
+	mockOmittedHelper.AssertNumberOfCalls(t, "NextNonOmitted", 1)
+	assert.Equal(t, int64(1), atomic.LoadInt64(&omittedFetches))
 	mockHelper.AssertExpectations(t)
+	mockOmittedHelper.AssertExpectations(t)
 	mockHandler.AssertExpectations(t)
 }
 
-func TestSync_ManualReorg(t *testing.T) {
+func TestSync_FetchRetries(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	mockHelper := &mocks.Helper{}
 	mockHandler := &mocks.Handler{}
-	syncer := New(networkIdentifier, mockHelper, mockHandler, cancel)
+	syncer := New(
+		networkIdentifier,
+		mockHelper,
+		mockHandler,
+		cancel,
+		WithFetchRetries(3),
+		WithFetchBackoff(time.Millisecond, 5*time.Millisecond),
+	)
 
 	mockHelper.On("NetworkStatus", ctx, networkIdentifier).Return(&types.NetworkStatusResponse{
 		CurrentBlockIdentifier: &types.BlockIdentifier{
-			Hash:  "block 1300",
-			Index: 1300,
+			Hash:  "block 10",
+			Index: 10,
 		},
 		GenesisBlockIdentifier: &types.BlockIdentifier{
 			Hash:  "block 0",
 			Index: 0,
 		},
-	}, nil).Run(func(args mock.Arguments) {
-		err := args.Get(0).(context.Context)
-		assert.NoError(t, err.Err())
-	})
-
-	blocks := createBlocks(0, 800, "")
-	for _, b := range blocks { // [0, 800]
-		mockHelper.On(
-			"Block",
-			mock.AnythingOfType("*context.cancelCtx"),
-			networkIdentifier,
-			&types.PartialBlockIdentifier{Index: &b.BlockIdentifier.Index},
-		).Return(
-			b,
-			nil,
-		).Run(func(args mock.Arguments) {
-			assertNotCanceled(t, args)
-		}).Once()
-		mockHandler.On(
-			"BlockSeen",
-			mock.AnythingOfType("*context.cancelCtx"),
-			b,
-		).Run(func(args mock.Arguments) {
-			assertNotCanceled(t, args)
-		}).Return(
-			nil,
-		).Once()
-		mockHandler.On(
-			"BlockAdded",
-			mock.AnythingOfType("*context.cancelCtx"),
-			b,
-		).Run(func(args mock.Arguments) {
-			assertNotCanceled(t, args)
-		}).Return(
-			nil,
-		).Once()
-	}
+	}, nil)
 
-	// Create reorg
-	index801 := int64(801)
-	mockHelper.On(
-		"Block",
-		mock.AnythingOfType("*context.cancelCtx"),
-		networkIdentifier,
-		&types.PartialBlockIdentifier{Index: &index801},
-	).Return(
-		nil,
-		ErrOrphanHead,
-	).Run(func(args mock.Arguments) {
-		err := args.Get(0).(context.Context)
-		assert.NoError(t, err.Err())
-	}).Once() // [801]
-	mockHandler.On(
-		"BlockRemoved",
-		mock.AnythingOfType("*context.cancelCtx"),
-		blocks[len(blocks)-1].BlockIdentifier,
-	).Return(
-		nil,
-	).Run(func(args mock.Arguments) {
-		assertNotCanceled(t, args)
-	}).Once()
+	blocks := createBlocks(0, 10, "")
+	for _, b := range blocks {
+		// Fetching block 5 fails twice before succeeding
+		if b.BlockIdentifier.Index == 5 {
+			mockHelper.On(
+				"Block",
+				mock.AnythingOfType("*context.cancelCtx"),
+				networkIdentifier,
+				&types.PartialBlockIdentifier{Index: &b.BlockIdentifier.Index},
+			).Return(
+				nil,
+				errors.New("connection reset"),
+			).Twice()
+		}
 
-	newBlocks := createBlocks(800, 1200, "")
-	for _, b := range newBlocks { // [800, 1200]
 		mockHelper.On(
 			"Block",
 			mock.AnythingOfType("*context.cancelCtx"),
@@ -894,9 +1010,7 @@ func TestSync_ManualReorg(t *testing.T) {
 		).Return(
 			b,
 			nil,
-		).Run(func(args mock.Arguments) {
-			assertNotCanceled(t, args)
-		}).Once()
+		).Once()
 		mockHandler.On(
 			"BlockSeen",
 			mock.AnythingOfType("*context.cancelCtx"),
@@ -908,30 +1022,228 @@ func TestSync_ManualReorg(t *testing.T) {
 			"BlockAdded",
 			mock.AnythingOfType("*context.cancelCtx"),
 			b,
-		).Run(func(args mock.Arguments) {
-			assertNotCanceled(t, args)
-			if b.BlockIdentifier.Index == 1100 {
-				assert.True(t, syncer.concurrency > DefaultConcurrency)
-			}
-		}).Return(
+		).Return(
 			nil,
 		).Once()
 	}
 
-	// Expected Calls to Block
-	// [0, 799] = 1
-	// [800, 801] = 2
-	// [802,1200] = 1
-
-	err := syncer.Sync(ctx, -1, 1200)
+	err := syncer.Sync(ctx, -1, 10)
 	assert.NoError(t, err)
-	assert.Equal(t, int64(0), syncer.concurrency)
-	mockHelper.AssertNumberOfCalls(t, "NetworkStatus", 3)
+	mockHelper.AssertNumberOfCalls(t, "Block", len(blocks)+2)
 	mockHelper.AssertExpectations(t)
 	mockHandler.AssertExpectations(t)
 }
 
-func TestSync_Dynamic(t *testing.T) {
+func TestFetchBlockResultRetries(t *testing.T) {
+	var tests = map[string]struct {
+		err         error
+		cancelAfter time.Duration
+		backoff     time.Duration
+
+		expectedCalls int
+		expectedError error
+	}{
+		"retries exhausted": {
+			err:           errors.New("connection reset"),
+			backoff:       time.Millisecond,
+			expectedCalls: 3,
+			expectedError: ErrFetchRetriesExhausted,
+		},
+		"orphan head": {
+			err:           ErrOrphanHead,
+			backoff:       time.Millisecond,
+			expectedCalls: 1,
+		},
+		"canceled during backoff": {
+			err:           errors.New("connection reset"),
+			cancelAfter:   10 * time.Millisecond,
+			backoff:       time.Hour,
+			expectedCalls: 1,
+			expectedError: context.Canceled,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockHelper := &mocks.Helper{}
+			syncer := New(
+				networkIdentifier,
+				mockHelper,
+				&mocks.Handler{},
+				nil,
+				WithFetchRetries(2),
+				WithFetchBackoff(test.backoff, test.backoff),
+			)
+
+			index := int64(5)
+			mockHelper.On(
+				"Block",
+				ctx,
+				networkIdentifier,
+				&types.PartialBlockIdentifier{Index: &index},
+			).Return(
+				nil,
+				test.err,
+			)
+
+			if test.cancelAfter > 0 {
+				time.AfterFunc(test.cancelAfter, cancel)
+			}
+
+			br, err := syncer.fetchBlockResult(ctx, networkIdentifier, index)
+			if test.expectedError != nil {
+				assert.Nil(t, br)
+				assert.True(t, errors.Is(err, test.expectedError))
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, br.orphanHead)
+			}
+			mockHelper.AssertNumberOfCalls(t, "Block", test.expectedCalls)
+		})
+	}
+}
+
+type sizedHelper struct {
+	*mocks.Helper
+	*mocks.SizedHelper
+}
+
+func TestFetchBlockResultSize(t *testing.T) {
+	var tests = map[string]struct {
+		size int
+
+		expectedSize int
+	}{
+		"size hint": {
+			size:         1 << 20,
+			expectedSize: 1 << 20,
+		},
+		"no size hint": {
+			size: 0,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			mockHelper := &mocks.Helper{}
+			mockSizedHelper := &mocks.SizedHelper{}
+			mockHandler := &mocks.Handler{}
+			syncer := New(
+				networkIdentifier,
+				&sizedHelper{Helper: mockHelper, SizedHelper: mockSizedHelper},
+				mockHandler,
+				nil,
+			)
+
+			block := createBlocks(5, 5, "")[0]
+			mockHandler.On("BlockSeen", ctx, block).Return(nil).Once()
+			index := block.BlockIdentifier.Index
+			mockSizedHelper.On(
+				"SizedBlock",
+				ctx,
+				networkIdentifier,
+				&types.PartialBlockIdentifier{Index: &index},
+			).Return(
+				block,
+				test.size,
+				nil,
+			).Once()
+
+			br, err := syncer.fetchBlockResult(ctx, networkIdentifier, index)
+			assert.NoError(t, err)
+			assert.Equal(t, block, br.block)
+
+			expectedSize := test.expectedSize
+			if expectedSize == 0 {
+				expectedSize = utils.SizeOf(br)
+			}
+			assert.Equal(t, expectedSize, resultSize(br))
+			mockHelper.AssertNotCalled(t, "Block")
+			mockSizedHelper.AssertExpectations(t)
+			mockHandler.AssertExpectations(t)
+		})
+	}
+}
+
+// BenchmarkResultSize compares the cost of computing the size
+// of a ~5MB block with utils.SizeOf to using a size hint
+// provided by a SizedHelper.
+func BenchmarkResultSize(b *testing.B) {
+	block := createBlocks(1, 1, "")[0]
+	for i := 0; i < 4000; i++ {
+		block.Transactions = append(block.Transactions, &types.Transaction{
+			TransactionIdentifier: &types.TransactionIdentifier{
+				Hash: fmt.Sprintf("tx %d", i),
+			},
+			Operations: []*types.Operation{
+				{
+					OperationIdentifier: &types.OperationIdentifier{Index: 0},
+					Type:                "Transfer",
+					Status:              types.String("Success"),
+					Account: &types.AccountIdentifier{
+						Address: fmt.Sprintf("account %d", i),
+					},
+					Amount: &types.Amount{
+						Value:    "-1000",
+						Currency: &types.Currency{Symbol: "BTC", Decimals: 8},
+					},
+					Metadata: map[string]interface{}{
+						"data": strings.Repeat("a", 1000),
+					},
+				},
+			},
+		})
+	}
+
+	unsized := &blockResult{index: 1, block: block}
+	size := utils.SizeOf(unsized)
+	sized := &blockResult{index: 1, block: block, size: size}
+	b.Logf("block size: %d bytes", size)
+
+	b.Run("SizeOf", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			resultSize(unsized)
+		}
+	})
+
+	b.Run("SizeHint", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			resultSize(sized)
+		}
+	})
+}
+
+func TestFetchBackoff(t *testing.T) {
+	syncer := New(
+		networkIdentifier,
+		nil,
+		nil,
+		nil,
+		WithFetchBackoff(100*time.Millisecond, time.Second),
+	)
+
+	for retries, maxBackoff := range []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+		time.Second,
+	} {
+		for i := 0; i < 100; i++ {
+			backoff := syncer.fetchBackoff(retries)
+			assert.GreaterOrEqual(t, backoff, maxBackoff/2)
+			assert.LessOrEqual(t, backoff, maxBackoff)
+		}
+	}
+}
+
+func TestSync_MaxGoroutines(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	mockHelper := &mocks.Helper{}
@@ -941,81 +1253,59 @@ func TestSync_Dynamic(t *testing.T) {
 		mockHelper,
 		mockHandler,
 		cancel,
-		WithCacheSize(1<<20), // 1 MB
+		WithMaxGoroutines(2),
 	)
 
-	// Force syncer to only get part of the way through the full range
 	mockHelper.On("NetworkStatus", ctx, networkIdentifier).Return(&types.NetworkStatusResponse{
 		CurrentBlockIdentifier: &types.BlockIdentifier{
-			Hash:  "block 1",
-			Index: 1,
+			Hash:  "block 300",
+			Index: 300,
 		},
 		GenesisBlockIdentifier: &types.BlockIdentifier{
 			Hash:  "block 0",
 			Index: 0,
 		},
-	}, nil).Twice()
+	}, nil)
 
-	mockHelper.On("NetworkStatus", ctx, networkIdentifier).Return(&types.NetworkStatusResponse{
-		CurrentBlockIdentifier: &types.BlockIdentifier{
-			Hash:  "block 1300",
-			Index: 1300,
-		},
-		GenesisBlockIdentifier: &types.BlockIdentifier{
-			Hash:  "block 0",
-			Index: 0,
-		},
-	}, nil).Twice()
+	// Track the number of concurrent calls to Block
+	var (
+		fetchLock    sync.Mutex
+		fetching     int
+		maxFetching  int
+		maxGoal      int64
+		blocks       = createBlocks(0, 300, "")
+		trackFetches = func(args mock.Arguments) {
+			fetchLock.Lock()
+			fetching++
+			if fetching > maxFetching {
+				maxFetching = fetching
+			}
+			fetchLock.Unlock()
 
-	blocks := createBlocks(0, 200, "")
+			time.Sleep(time.Millisecond)
 
-	// Load blocks with a ton of transactions
-	for _, block := range blocks {
-		txs := []*types.Transaction{}
-		for i := 0; i < 10000; i++ {
-			txs = append(txs, &types.Transaction{
-				TransactionIdentifier: &types.TransactionIdentifier{
-					Hash: fmt.Sprintf("block %d tx %d", block.BlockIdentifier.Index, i),
-				},
-			})
+			fetchLock.Lock()
+			fetching--
+			fetchLock.Unlock()
 		}
-
-		block.Transactions = txs
-	}
-
-	// Create a block gap
-	blocks[100] = nil
-	blocks[101].ParentBlockIdentifier = blocks[99].BlockIdentifier
-	for i, b := range blocks {
-		index := int64(i)
+	)
+	for _, b := range blocks {
 		mockHelper.On(
 			"Block",
 			mock.AnythingOfType("*context.cancelCtx"),
 			networkIdentifier,
-			&types.PartialBlockIdentifier{Index: &index},
+			&types.PartialBlockIdentifier{Index: &b.BlockIdentifier.Index},
 		).Return(
 			b,
 			nil,
-		).Run(func(args mock.Arguments) {
-			if index == 100 {
-				assert.Equal(t, int64(1), syncer.concurrency)
-			}
-			assertNotCanceled(t, args)
-		}).Once()
-
-		if b == nil {
-			continue
-		}
-
+		).Run(trackFetches).Once()
 		mockHandler.On(
 			"BlockSeen",
 			mock.AnythingOfType("*context.cancelCtx"),
 			b,
 		).Return(
 			nil,
-		).Run(func(args mock.Arguments) {
-			assertNotCanceled(t, args)
-		}).Once()
+		).Once()
 		mockHandler.On(
 			"BlockAdded",
 			mock.AnythingOfType("*context.cancelCtx"),
@@ -1023,42 +1313,29 @@ func TestSync_Dynamic(t *testing.T) {
 		).Return(
 			nil,
 		).Run(func(args mock.Arguments) {
-			assertNotCanceled(t, args)
+			progress := syncer.SyncProgress()
+			assert.LessOrEqual(t, progress.Concurrency, int64(2))
+			assert.LessOrEqual(t, progress.GoalConcurrency, int64(2))
+			if progress.GoalConcurrency > maxGoal {
+				maxGoal = progress.GoalConcurrency
+			}
 		}).Once()
 	}
 
-	err := syncer.Sync(ctx, -1, 200)
+	err := syncer.Sync(ctx, -1, 300)
 	assert.NoError(t, err)
-	assert.Equal(t, int64(0), syncer.concurrency)
+	assert.LessOrEqual(t, maxFetching, 2)
+	assert.Equal(t, int64(2), maxGoal)
 	mockHelper.AssertExpectations(t)
 	mockHandler.AssertExpectations(t)
 }
 
-func TestSync_DynamicOverhead(t *testing.T) {
+func TestSync_SpecificStart(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	mockHelper := &mocks.Helper{}
 	mockHandler := &mocks.Handler{}
-	syncer := New(
-		networkIdentifier,
-		mockHelper,
-		mockHandler,
-		cancel,
-		WithCacheSize(1<<20),       // 1 MB
-		WithSizeMultiplier(100000), // greatly increase synthetic size
-	)
-
-	// Force syncer to only get part of the way through the full range
-	mockHelper.On("NetworkStatus", ctx, networkIdentifier).Return(&types.NetworkStatusResponse{
-		CurrentBlockIdentifier: &types.BlockIdentifier{
-			Hash:  "block 1",
-			Index: 1,
-		},
-		GenesisBlockIdentifier: &types.BlockIdentifier{
-			Hash:  "block 0",
-			Index: 0,
-		},
-	}, nil).Twice()
+	syncer := New(networkIdentifier, mockHelper, mockHandler, cancel)
 
 	mockHelper.On("NetworkStatus", ctx, networkIdentifier).Return(&types.NetworkStatusResponse{
 		CurrentBlockIdentifier: &types.BlockIdentifier{
@@ -1069,43 +1346,28 @@ func TestSync_DynamicOverhead(t *testing.T) {
 			Hash:  "block 0",
 			Index: 0,
 		},
-	}, nil).Twice()
-
-	blocks := createBlocks(0, 200, "")
+	}, nil)
 
-	// Create a block gap
-	blocks[100] = nil
-	blocks[101].ParentBlockIdentifier = blocks[99].BlockIdentifier
-	for i, b := range blocks {
-		index := int64(i)
+	blocks := createBlocks(100, 1200, "")
+	for _, b := range blocks {
 		mockHelper.On(
 			"Block",
 			mock.AnythingOfType("*context.cancelCtx"),
 			networkIdentifier,
-			&types.PartialBlockIdentifier{Index: &index},
+			&types.PartialBlockIdentifier{Index: &b.BlockIdentifier.Index},
 		).Return(
 			b,
 			nil,
 		).Run(func(args mock.Arguments) {
-			if index == 100 {
-				assert.Equal(t, int64(1), syncer.concurrency)
-			}
 			assertNotCanceled(t, args)
 		}).Once()
-
-		if b == nil {
-			continue
-		}
-
 		mockHandler.On(
 			"BlockSeen",
 			mock.AnythingOfType("*context.cancelCtx"),
 			b,
 		).Return(
 			nil,
-		).Run(func(args mock.Arguments) {
-			assertNotCanceled(t, args)
-		}).Once()
+		).Once()
 		mockHandler.On(
 			"BlockAdded",
 			mock.AnythingOfType("*context.cancelCtx"),
@@ -1114,12 +1376,1652 @@ func TestSync_DynamicOverhead(t *testing.T) {
 			nil,
 		).Run(func(args mock.Arguments) {
 			assertNotCanceled(t, args)
+			if b.BlockIdentifier.Index == 1100 {
+				assert.True(t, syncer.concurrency > DefaultConcurrency)
+			}
 		}).Once()
 	}
 
-	err := syncer.Sync(ctx, -1, 200)
+	err := syncer.Sync(ctx, 100, 1200)
 	assert.NoError(t, err)
 	assert.Equal(t, int64(0), syncer.concurrency)
+	mockHelper.AssertNumberOfCalls(t, "NetworkStatus", 3)
 	mockHelper.AssertExpectations(t)
 	mockHandler.AssertExpectations(t)
 }
+
+func TestSync_InvalidNetwork(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockHelper := &mocks.Helper{}
+	mockHandler := &mocks.Handler{}
+	syncer := New(
+		&types.NetworkIdentifier{Blockchain: "blah"},
+		mockHelper,
+		mockHandler,
+		cancel,
+	)
+
+	err := syncer.Sync(ctx, -1, 200)
+	assert.True(t, errors.Is(err, asserter.ErrNetworkIdentifierNetworkMissing))
+
+	mockHelper.AssertExpectations(t)
+	mockHandler.AssertExpectations(t)
+}
+
+func TestSync_Cancel(t *testing.T) {
+	t.Run("while syncing", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		mockHelper := &mocks.Helper{}
+		mockHandler := &mocks.Handler{}
+		syncer := New(networkIdentifier, mockHelper, mockHandler, cancel)
+
+		// Force syncer to only get part of the way through the full range
+		mockHelper.On("NetworkStatus", ctx, networkIdentifier).Return(&types.NetworkStatusResponse{
+			CurrentBlockIdentifier: &types.BlockIdentifier{
+				Hash:  "block 200",
+				Index: 200,
+			},
+			GenesisBlockIdentifier: &types.BlockIdentifier{
+				Hash:  "block 0",
+				Index: 0,
+			},
+		}, nil).Twice()
+
+		mockHelper.On("NetworkStatus", ctx, networkIdentifier).Return(&types.NetworkStatusResponse{
+			CurrentBlockIdentifier: &types.BlockIdentifier{
+				Hash:  "block 1300",
+				Index: 1300,
+			},
+			GenesisBlockIdentifier: &types.BlockIdentifier{
+				Hash:  "block 0",
+				Index: 0,
+			},
+		}, nil).Twice()
+
+		blocks := createBlocks(0, 1200, "")
+		for _, b := range blocks {
+			mockHelper.On(
+				"Block",
+				mock.AnythingOfType("*context.cancelCtx"),
+				networkIdentifier,
+				&types.PartialBlockIdentifier{Index: &b.BlockIdentifier.Index},
+			).Return(
+				b,
+				nil,
+			).Once()
+			mockHandler.On(
+				"BlockSeen",
+				mock.AnythingOfType("*context.cancelCtx"),
+				b,
+			).Return(
+				nil,
+			).Once()
+			mockHandler.On(
+				"BlockAdded",
+				mock.AnythingOfType("*context.cancelCtx"),
+				b,
+			).Return(
+				nil,
+			).Once()
+		}
+
+		go func() {
+			time.Sleep(1 * time.Second)
+			cancel()
+		}()
+
+		err := syncer.Sync(ctx, -1, 1200)
+		assert.True(t, errors.Is(err, context.Canceled))
+		assert.Equal(t, int64(0), syncer.concurrency)
+	})
+
+	t.Run("at tip", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		mockHelper := &mocks.Helper{}
+		mockHandler := &mocks.Handler{}
+		syncer := New(networkIdentifier, mockHelper, mockHandler, cancel)
+
+		blocks := createBlocks(0, 0, "")
+		mockHelper.On("NetworkStatus", ctx, networkIdentifier).Return(&types.NetworkStatusResponse{
+			CurrentBlockIdentifier: blocks[0].BlockIdentifier,
+			GenesisBlockIdentifier: blocks[0].BlockIdentifier,
+		}, nil)
+		mockHelper.On(
+			"Block",
+			mock.AnythingOfType("*context.cancelCtx"),
+			networkIdentifier,
+			&types.PartialBlockIdentifier{Index: &blocks[0].BlockIdentifier.Index},
+		).Return(
+			blocks[0],
+			nil,
+		).Once()
+		mockHandler.On(
+			"BlockSeen",
+			mock.AnythingOfType("*context.cancelCtx"),
+			blocks[0],
+		).Return(
+			nil,
+		).Once()
+
+		// Cancel once the syncer is idle at tip
+		mockHandler.On(
+			"BlockAdded",
+			mock.AnythingOfType("*context.cancelCtx"),
+			blocks[0],
+		).Run(func(args mock.Arguments) {
+			go func() {
+				time.Sleep(100 * time.Millisecond)
+				cancel()
+			}()
+		}).Return(
+			nil,
+		).Once()
+
+		start := time.Now()
+		err := syncer.Sync(ctx, -1, -1)
+		assert.True(t, errors.Is(err, context.Canceled))
+		assert.Less(t, int64(time.Since(start)), int64(defaultSyncSleep))
+		mockHelper.AssertExpectations(t)
+		mockHandler.AssertExpectations(t)
+	})
+}
+
+func TestSync_Reorg(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	mockHelper := &mocks.Helper{}
+	mockHandler := &mocks.Handler{}
+	mockReorgHandler := &mocks.ReorgHandler{}
+	syncer := New(
+		networkIdentifier,
+		mockHelper,
+		&reorgHandler{Handler: mockHandler, ReorgHandler: mockReorgHandler},
+		cancel,
+	)
+
+	mockHelper.On("NetworkStatus", ctx, networkIdentifier).Return(&types.NetworkStatusResponse{
+		CurrentBlockIdentifier: &types.BlockIdentifier{
+			Hash:  "block 1300",
+			Index: 1300,
+		},
+		GenesisBlockIdentifier: &types.BlockIdentifier{
+			Hash:  "block 0",
+			Index: 0,
+		},
+	}, nil).Run(func(args mock.Arguments) {
+		err := args.Get(0).(context.Context)
+		assert.NoError(t, err.Err())
+	})
+
+	blocks := createBlocks(0, 800, "")
+	for _, b := range blocks { // [0, 800]
+		mockHelper.On(
+			"Block",
+			mock.AnythingOfType("*context.cancelCtx"),
+			networkIdentifier,
+			&types.PartialBlockIdentifier{Index: &b.BlockIdentifier.Index},
+		).Return(
+			b,
+			nil,
+		).Run(func(args mock.Arguments) {
+			assertNotCanceled(t, args)
+		}).Once()
+		mockHandler.On(
+			"BlockSeen",
+			mock.AnythingOfType("*context.cancelCtx"),
+			b,
+		).Run(func(args mock.Arguments) {
+			assertNotCanceled(t, args)
+		}).Return(
+			nil,
+		).Once()
+		mockHandler.On(
+			"BlockAdded",
+			mock.AnythingOfType("*context.cancelCtx"),
+			b,
+		).Run(func(args mock.Arguments) {
+			assertNotCanceled(t, args)
+		}).Return(
+			nil,
+		).Once()
+	}
+
+	// Create reorg
+	newBlocks := createBlocks(790, 1200, "other")
+	mockHelper.On(
+		"Block",
+		mock.AnythingOfType("*context.cancelCtx"),
+		networkIdentifier,
+		&types.PartialBlockIdentifier{Index: &newBlocks[11].BlockIdentifier.Index},
+	).Return(
+		newBlocks[11],
+		nil,
+	).Run(func(args mock.Arguments) {
+		err := args.Get(0).(context.Context)
+		assert.NoError(t, err.Err())
+	}).Once() // [801]
+
+	// Set parent of reorg start to be last good block
+	newBlocks[0].ParentBlockIdentifier = blocks[789].BlockIdentifier
+
+	// Record the order of reorg-related calls
+	calls := []string{}
+	mockReorgHandler.On(
+		"ReorgStarted",
+		mock.AnythingOfType("*context.cancelCtx"),
+		blocks[800].BlockIdentifier,
+	).Return(
+		nil,
+	).Run(func(args mock.Arguments) {
+		calls = append(calls, "started")
+	}).Once()
+	mockReorgHandler.On(
+		"ReorgFinished",
+		mock.AnythingOfType("*context.cancelCtx"),
+		blocks[789].BlockIdentifier,
+		newBlocks[0].BlockIdentifier,
+	).Return(
+		nil,
+	).Run(func(args mock.Arguments) {
+		calls = append(calls, "finished")
+	}).Once()
+
+	// Orphan last 10 blocks
+	for i := 790; i <= 800; i++ { // [790, 800]
+		thisBlock := newBlocks[i-790]
+		mockHelper.On(
+			"Block",
+			mock.AnythingOfType("*context.cancelCtx"),
+			networkIdentifier,
+			&types.PartialBlockIdentifier{Index: &thisBlock.BlockIdentifier.Index},
+		).Return(
+			thisBlock,
+			nil,
+		).Run(func(args mock.Arguments) {
+			assertNotCanceled(t, args)
+		}).Once()
+		mockHandler.On(
+			"BlockRemoved",
+			mock.AnythingOfType("*context.cancelCtx"),
+			blocks[i].BlockIdentifier,
+		).Return(
+			nil,
+		).Run(func(args mock.Arguments) {
+			assertNotCanceled(t, args)
+			calls = append(calls, "removed")
+		}).Once()
+	}
+
+	mockHandler.On(
+		"BlockSeen",
+		mock.AnythingOfType("*context.cancelCtx"),
+		newBlocks[0],
+	).Return(
+		nil,
+	).Once() // only fetch this block once
+	mockHandler.On(
+		"BlockAdded",
+		mock.AnythingOfType("*context.cancelCtx"),
+		newBlocks[0],
+	).Run(func(args mock.Arguments) {
+		err := args.Get(0).(context.Context)
+		assert.NoError(t, err.Err())
+		calls = append(calls, "added")
+	}).Return(
+		nil,
+	).Once() // only fetch this block once
+
+	// New blocks added
+	for _, b := range newBlocks[1:] { // [790, 1200]
+		mockHelper.On(
+			"Block",
+			mock.AnythingOfType("*context.cancelCtx"),
+			networkIdentifier,
+			&types.PartialBlockIdentifier{Index: &b.BlockIdentifier.Index},
+		).Return(
+			b,
+			nil,
+		).Run(func(args mock.Arguments) {
+			assertNotCanceled(t, args)
+		}).Once()
+
+		seenTimes := 2
+		if b.BlockIdentifier.Index > 801 {
+			seenTimes = 1
+		}
+		mockHandler.On(
+			"BlockSeen",
+			mock.AnythingOfType("*context.cancelCtx"),
+			b,
+		).Return(
+			nil,
+		).Run(func(args mock.Arguments) {
+			assertNotCanceled(t, args)
+		}).Times(seenTimes)
+		mockHandler.On(
+			"BlockAdded",
+			mock.AnythingOfType("*context.cancelCtx"),
+			b,
+		).Return(
+			nil,
+		).Run(func(args mock.Arguments) {
+			assertNotCanceled(t, args)
+			if b.BlockIdentifier.Index == 1100 {
+				assert.True(t, syncer.concurrency > DefaultConcurrency)
+			}
+		}).Once()
+	}
+
+	// Expected Calls to Block
+	// [0, 789] = 1
+	// [790] = 2
+	// [791, 800] = 3
+	// [801] = 2
+	// [802,1200] = 1
+
+	err := syncer.Sync(ctx, -1, 1200)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), syncer.concurrency)
+	mockHelper.AssertNumberOfCalls(t, "NetworkStatus", 3)
+	mockHelper.AssertExpectations(t)
+	mockHandler.AssertExpectations(t)
+	mockReorgHandler.AssertExpectations(t)
+
+	expectedCalls := []string{"started"}
+	for i := 790; i <= 800; i++ {
+		expectedCalls = append(expectedCalls, "removed")
+	}
+	expectedCalls = append(expectedCalls, "added", "finished")
+	assert.Equal(t, expectedCalls, calls)
+}
+
+func TestSync_ManualReorg(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	mockHelper := &mocks.Helper{}
+	mockHandler := &mocks.Handler{}
+	syncer := New(networkIdentifier, mockHelper, mockHandler, cancel)
+
+	mockHelper.On("NetworkStatus", ctx, networkIdentifier).Return(&types.NetworkStatusResponse{
+		CurrentBlockIdentifier: &types.BlockIdentifier{
+			Hash:  "block 1300",
+			Index: 1300,
+		},
+		GenesisBlockIdentifier: &types.BlockIdentifier{
+			Hash:  "block 0",
+			Index: 0,
+		},
+	}, nil).Run(func(args mock.Arguments) {
+		err := args.Get(0).(context.Context)
+		assert.NoError(t, err.Err())
+	})
+
+	blocks := createBlocks(0, 800, "")
+	for _, b := range blocks { // [0, 800]
+		mockHelper.On(
+			"Block",
+			mock.AnythingOfType("*context.cancelCtx"),
+			networkIdentifier,
+			&types.PartialBlockIdentifier{Index: &b.BlockIdentifier.Index},
+		).Return(
+			b,
+			nil,
+		).Run(func(args mock.Arguments) {
+			assertNotCanceled(t, args)
+		}).Once()
+		mockHandler.On(
+			"BlockSeen",
+			mock.AnythingOfType("*context.cancelCtx"),
+			b,
+		).Run(func(args mock.Arguments) {
+			assertNotCanceled(t, args)
+		}).Return(
+			nil,
+		).Once()
+		mockHandler.On(
+			"BlockAdded",
+			mock.AnythingOfType("*context.cancelCtx"),
+			b,
+		).Run(func(args mock.Arguments) {
+			assertNotCanceled(t, args)
+		}).Return(
+			nil,
+		).Once()
+	}
+
+	// Create reorg
+	index801 := int64(801)
+	mockHelper.On(
+		"Block",
+		mock.AnythingOfType("*context.cancelCtx"),
+		networkIdentifier,
+		&types.PartialBlockIdentifier{Index: &index801},
+	).Return(
+		nil,
+		ErrOrphanHead,
+	).Run(func(args mock.Arguments) {
+		err := args.Get(0).(context.Context)
+		assert.NoError(t, err.Err())
+	}).Once() // [801]
+	mockHandler.On(
+		"BlockRemoved",
+		mock.AnythingOfType("*context.cancelCtx"),
+		blocks[len(blocks)-1].BlockIdentifier,
+	).Return(
+		nil,
+	).Run(func(args mock.Arguments) {
+		assertNotCanceled(t, args)
+	}).Once()
+
+	newBlocks := createBlocks(800, 1200, "")
+	for _, b := range newBlocks { // [800, 1200]
+		mockHelper.On(
+			"Block",
+			mock.AnythingOfType("*context.cancelCtx"),
+			networkIdentifier,
+			&types.PartialBlockIdentifier{Index: &b.BlockIdentifier.Index},
+		).Return(
+			b,
+			nil,
+		).Run(func(args mock.Arguments) {
+			assertNotCanceled(t, args)
+		}).Once()
+		mockHandler.On(
+			"BlockSeen",
+			mock.AnythingOfType("*context.cancelCtx"),
+			b,
+		).Return(
+			nil,
+		).Once()
+		mockHandler.On(
+			"BlockAdded",
+			mock.AnythingOfType("*context.cancelCtx"),
+			b,
+		).Run(func(args mock.Arguments) {
+			assertNotCanceled(t, args)
+			if b.BlockIdentifier.Index == 1100 {
+				assert.True(t, syncer.concurrency > DefaultConcurrency)
+			}
+		}).Return(
+			nil,
+		).Once()
+	}
+
+	// Expected Calls to Block
+	// [0, 799] = 1
+	// [800, 801] = 2
+	// [802,1200] = 1
+
+	err := syncer.Sync(ctx, -1, 1200)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), syncer.concurrency)
+	mockHelper.AssertNumberOfCalls(t, "NetworkStatus", 3)
+	mockHelper.AssertExpectations(t)
+	mockHandler.AssertExpectations(t)
+}
+
+func TestSync_ReorgExceedsWindow(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	mockHelper := &mocks.Helper{}
+	mockHandler := &mocks.Handler{}
+	syncer := New(
+		networkIdentifier,
+		mockHelper,
+		mockHandler,
+		cancel,
+		WithPastBlockLimit(3),
+	)
+
+	blocks := createBlocks(0, 9, "")
+	mockHelper.On("NetworkStatus", ctx, networkIdentifier).Return(&types.NetworkStatusResponse{
+		CurrentBlockIdentifier: &types.BlockIdentifier{
+			Hash:  "block r10",
+			Index: 10,
+		},
+		GenesisBlockIdentifier: blocks[0].BlockIdentifier,
+	}, nil)
+	mockHandler.On(
+		"BlockSeen",
+		mock.AnythingOfType("*context.cancelCtx"),
+		mock.Anything,
+	).Return(
+		nil,
+	)
+
+	for _, b := range blocks { // [0, 9]
+		index := b.BlockIdentifier.Index
+		mockHelper.On(
+			"Block",
+			mock.AnythingOfType("*context.cancelCtx"),
+			networkIdentifier,
+			&types.PartialBlockIdentifier{Index: &index},
+		).Return(
+			b,
+			nil,
+		).Once()
+		mockHandler.On(
+			"BlockAdded",
+			mock.AnythingOfType("*context.cancelCtx"),
+			b,
+		).Return(
+			nil,
+		).Once()
+	}
+
+	// Reorg of blocks [6, 9] is one block deeper
+	// than the past block window ([7, 9]).
+	reorgBlocks := createBlocks(6, 10, "r")
+	reorgBlocks[0].ParentBlockIdentifier = blocks[5].BlockIdentifier
+	for _, b := range reorgBlocks[1:] { // [7, 10]
+		index := b.BlockIdentifier.Index
+		mockHelper.On(
+			"Block",
+			mock.AnythingOfType("*context.cancelCtx"),
+			networkIdentifier,
+			&types.PartialBlockIdentifier{Index: &index},
+		).Return(
+			b,
+			nil,
+		).Once()
+	}
+	for _, b := range blocks[7:] {
+		mockHandler.On(
+			"BlockRemoved",
+			mock.AnythingOfType("*context.cancelCtx"),
+			b.BlockIdentifier,
+		).Return(
+			nil,
+		).Once()
+	}
+
+	err := syncer.Sync(ctx, -1, 10)
+	assert.True(t, errors.Is(err, ErrReorgExceedsWindow))
+	assert.Contains(t, err.Error(), "block 7 does not connect to block 6")
+	assert.Empty(t, syncer.pastBlocks)
+	mockHelper.AssertExpectations(t)
+	mockHandler.AssertExpectations(t)
+}
+
+func TestSync_ReorgStartup(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// The syncer only knows about block 5 at start-up,
+	// which is orphaned by a reorg 1 block deep.
+	blocks := createBlocks(0, 5, "")
+	reorgBlocks := createBlocks(4, 6, "r")
+	reorgBlocks[1].ParentBlockIdentifier = blocks[4].BlockIdentifier
+
+	mockHelper := &mocks.Helper{}
+	mockHandler := &mocks.Handler{}
+	syncer := New(
+		networkIdentifier,
+		mockHelper,
+		mockHandler,
+		cancel,
+		WithPastBlocks([]*types.BlockIdentifier{blocks[5].BlockIdentifier}),
+	)
+
+	mockHelper.On("NetworkStatus", ctx, networkIdentifier).Return(&types.NetworkStatusResponse{
+		CurrentBlockIdentifier: reorgBlocks[2].BlockIdentifier,
+		GenesisBlockIdentifier: blocks[0].BlockIdentifier,
+	}, nil)
+	mockHandler.On(
+		"BlockSeen",
+		mock.AnythingOfType("*context.cancelCtx"),
+		mock.Anything,
+	).Return(
+		nil,
+	)
+	for _, b := range reorgBlocks[1:] { // [5, 6]
+		index := b.BlockIdentifier.Index
+		mockHelper.On(
+			"Block",
+			mock.AnythingOfType("*context.cancelCtx"),
+			networkIdentifier,
+			&types.PartialBlockIdentifier{Index: &index},
+		).Return(
+			b,
+			nil,
+		)
+		mockHandler.On(
+			"BlockAdded",
+			mock.AnythingOfType("*context.cancelCtx"),
+			b,
+		).Return(
+			nil,
+		).Once()
+	}
+	mockHandler.On(
+		"BlockRemoved",
+		mock.AnythingOfType("*context.cancelCtx"),
+		blocks[5].BlockIdentifier,
+	).Return(
+		nil,
+	).Once()
+
+	err := syncer.Sync(ctx, 6, 6)
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		[]*types.BlockIdentifier{
+			reorgBlocks[1].BlockIdentifier,
+			reorgBlocks[2].BlockIdentifier,
+		},
+		syncer.pastBlocks,
+	)
+	mockHelper.AssertExpectations(t)
+	mockHandler.AssertExpectations(t)
+}
+
+func TestSync_Dynamic(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	mockHelper := &mocks.Helper{}
+	mockHandler := &mocks.Handler{}
+	syncer := New(
+		networkIdentifier,
+		mockHelper,
+		mockHandler,
+		cancel,
+		WithCacheSize(1<<20), // 1 MB
+	)
+
+	// Force syncer to only get part of the way through the full range
+	mockHelper.On("NetworkStatus", ctx, networkIdentifier).Return(&types.NetworkStatusResponse{
+		CurrentBlockIdentifier: &types.BlockIdentifier{
+			Hash:  "block 1",
+			Index: 1,
+		},
+		GenesisBlockIdentifier: &types.BlockIdentifier{
+			Hash:  "block 0",
+			Index: 0,
+		},
+	}, nil).Twice()
+
+	mockHelper.On("NetworkStatus", ctx, networkIdentifier).Return(&types.NetworkStatusResponse{
+		CurrentBlockIdentifier: &types.BlockIdentifier{
+			Hash:  "block 1300",
+			Index: 1300,
+		},
+		GenesisBlockIdentifier: &types.BlockIdentifier{
+			Hash:  "block 0",
+			Index: 0,
+		},
+	}, nil).Twice()
+
+	blocks := createBlocks(0, 200, "")
+
+	// Load blocks with a ton of transactions
+	for _, block := range blocks {
+		txs := []*types.Transaction{}
+		for i := 0; i < 10000; i++ {
+			txs = append(txs, &types.Transaction{
+				TransactionIdentifier: &types.TransactionIdentifier{
+					Hash: fmt.Sprintf("block %d tx %d", block.BlockIdentifier.Index, i),
+				},
+			})
+		}
+
+		block.Transactions = txs
+	}
+
+	// Create a block gap
+	blocks[100] = nil
+	blocks[101].ParentBlockIdentifier = blocks[99].BlockIdentifier
+	for i, b := range blocks {
+		index := int64(i)
+		mockHelper.On(
+			"Block",
+			mock.AnythingOfType("*context.cancelCtx"),
+			networkIdentifier,
+			&types.PartialBlockIdentifier{Index: &index},
+		).Return(
+			b,
+			nil,
+		).Run(func(args mock.Arguments) {
+			if index == 100 {
+				assert.Equal(t, int64(1), syncer.concurrency)
+			}
+			assertNotCanceled(t, args)
+		}).Once()
+
+		if b == nil {
+			continue
+		}
+
+		mockHandler.On(
+			"BlockSeen",
+			mock.AnythingOfType("*context.cancelCtx"),
+			b,
+		).Return(
+			nil,
+		).Run(func(args mock.Arguments) {
+			assertNotCanceled(t, args)
+		}).Once()
+		mockHandler.On(
+			"BlockAdded",
+			mock.AnythingOfType("*context.cancelCtx"),
+			b,
+		).Return(
+			nil,
+		).Run(func(args mock.Arguments) {
+			assertNotCanceled(t, args)
+		}).Once()
+	}
+
+	err := syncer.Sync(ctx, -1, 200)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), syncer.concurrency)
+	mockHelper.AssertExpectations(t)
+	mockHandler.AssertExpectations(t)
+}
+
+func TestSync_DynamicOverhead(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	mockHelper := &mocks.Helper{}
+	mockHandler := &mocks.Handler{}
+	syncer := New(
+		networkIdentifier,
+		mockHelper,
+		mockHandler,
+		cancel,
+		WithCacheSize(1<<20),       // 1 MB
+		WithSizeMultiplier(100000), // greatly increase synthetic size
+	)
+
+	// Force syncer to only get part of the way through the full range
+	mockHelper.On("NetworkStatus", ctx, networkIdentifier).Return(&types.NetworkStatusResponse{
+		CurrentBlockIdentifier: &types.BlockIdentifier{
+			Hash:  "block 1",
+			Index: 1,
+		},
+		GenesisBlockIdentifier: &types.BlockIdentifier{
+			Hash:  "block 0",
+			Index: 0,
+		},
+	}, nil).Twice()
+
+	mockHelper.On("NetworkStatus", ctx, networkIdentifier).Return(&types.NetworkStatusResponse{
+		CurrentBlockIdentifier: &types.BlockIdentifier{
+			Hash:  "block 1300",
+			Index: 1300,
+		},
+		GenesisBlockIdentifier: &types.BlockIdentifier{
+			Hash:  "block 0",
+			Index: 0,
+		},
+	}, nil).Twice()
+
+	blocks := createBlocks(0, 200, "")
+
+	// Create a block gap
+	blocks[100] = nil
+	blocks[101].ParentBlockIdentifier = blocks[99].BlockIdentifier
+	for i, b := range blocks {
+		index := int64(i)
+		mockHelper.On(
+			"Block",
+			mock.AnythingOfType("*context.cancelCtx"),
+			networkIdentifier,
+			&types.PartialBlockIdentifier{Index: &index},
+		).Return(
+			b,
+			nil,
+		).Run(func(args mock.Arguments) {
+			if index == 100 {
+				assert.Equal(t, int64(1), syncer.concurrency)
+			}
+			assertNotCanceled(t, args)
+		}).Once()
+
+		if b == nil {
+			continue
+		}
+
+		mockHandler.On(
+			"BlockSeen",
+			mock.AnythingOfType("*context.cancelCtx"),
+			b,
+		).Return(
+			nil,
+		).Run(func(args mock.Arguments) {
+			assertNotCanceled(t, args)
+		}).Once()
+		mockHandler.On(
+			"BlockAdded",
+			mock.AnythingOfType("*context.cancelCtx"),
+			b,
+		).Return(
+			nil,
+		).Run(func(args mock.Arguments) {
+			assertNotCanceled(t, args)
+		}).Once()
+	}
+
+	err := syncer.Sync(ctx, -1, 200)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), syncer.concurrency)
+	mockHelper.AssertExpectations(t)
+	mockHandler.AssertExpectations(t)
+}
+
+func TestThroughput(t *testing.T) {
+	syncer := New(networkIdentifier, &mocks.Helper{}, &mocks.Handler{}, nil)
+	start := time.Now()
+	assert.Equal(t, float64(0), syncer.throughputAt(start))
+
+	// Process a block every 10ms (100 blocks/sec)
+	now := start
+	for i := 0; i < 50; i++ {
+		syncer.updateThroughput(now)
+		now = now.Add(10 * time.Millisecond)
+	}
+	last := now.Add(-10 * time.Millisecond)
+	assert.InDelta(t, 100, syncer.throughputAt(last), 1)
+
+	// Slow down to a block every 50ms (20 blocks/sec)
+	for i := 0; i < 100; i++ {
+		syncer.updateThroughput(now)
+		now = now.Add(50 * time.Millisecond)
+	}
+	last = now.Add(-50 * time.Millisecond)
+	assert.InDelta(t, 20, syncer.throughputAt(last), 1)
+
+	// Throughput decays while stalled
+	assert.Less(t, syncer.throughputAt(last.Add(10*time.Second)), float64(10))
+
+	// Throughput is safe to read concurrently
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			syncer.updateThroughput(time.Now())
+			_ = syncer.Throughput()
+		}()
+	}
+	wg.Wait()
+}
+
+func TestThroughputWithClock(t *testing.T) {
+	mockClock := &mockUtils.Clock{}
+	syncer := New(
+		networkIdentifier,
+		&mocks.Helper{},
+		&mocks.Handler{},
+		nil,
+		WithClock(mockClock),
+	)
+
+	start := time.Unix(1600000000, 0)
+	syncer.updateThroughput(start)
+	syncer.updateThroughput(start.Add(1 * time.Second))
+
+	// The pending 9s interval is smoothed into the 1s average:
+	// 0.1 * 9 + 0.9 * 1 = 1.8s per block.
+	mockClock.On("Now").Return(start.Add(10 * time.Second)).Once()
+	assert.InDelta(t, 1/1.8, syncer.Throughput(), 0.0001)
+	mockClock.AssertExpectations(t)
+}
+
+func TestNextRange(t *testing.T) {
+	ctx := context.Background()
+	mockHelper := &mocks.Helper{}
+	syncer := New(networkIdentifier, mockHelper, &mocks.Handler{}, nil)
+	syncer.nextIndex = 5
+
+	mockHelper.On("NetworkStatus", ctx, networkIdentifier).Return(&types.NetworkStatusResponse{
+		CurrentBlockIdentifier: &types.BlockIdentifier{
+			Hash:  "block 10",
+			Index: 10,
+		},
+		GenesisBlockIdentifier: &types.BlockIdentifier{
+			Hash:  "block 0",
+			Index: 0,
+		},
+	}, nil).Times(4)
+
+	var tests = map[string]struct {
+		nextIndex int64
+		endIndex  int64
+
+		start int64
+		end   int64
+		halt  bool
+	}{
+		"sync to tip": {
+			nextIndex: 5,
+			endIndex:  -1,
+			start:     5,
+			end:       10,
+		},
+		"end index before tip": {
+			nextIndex: 5,
+			endIndex:  7,
+			start:     5,
+			end:       7,
+		},
+		"end index after tip": {
+			nextIndex: 5,
+			endIndex:  20,
+			start:     5,
+			end:       10,
+		},
+		"at tip": {
+			nextIndex: 11,
+			endIndex:  -1,
+			start:     -1,
+			end:       -1,
+			halt:      true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			syncer.nextIndex = test.nextIndex
+			start, end, halt, err := syncer.NextRange(ctx, test.endIndex)
+			assert.NoError(t, err)
+			assert.Equal(t, test.start, start)
+			assert.Equal(t, test.end, end)
+			assert.Equal(t, test.halt, halt)
+
+			// NextRange must not modify syncer state.
+			assert.Equal(t, test.nextIndex, syncer.nextIndex)
+			assert.Nil(t, syncer.tip)
+		})
+	}
+
+	t.Run("network status error", func(t *testing.T) {
+		syncer.nextIndex = 5
+		mockHelper.On("NetworkStatus", ctx, networkIdentifier).Return(
+			nil,
+			errors.New("unavailable"),
+		).Once()
+
+		start, end, halt, err := syncer.NextRange(ctx, -1)
+		assert.True(t, errors.Is(err, ErrNextSyncableRangeFailed))
+		assert.Equal(t, int64(-1), start)
+		assert.Equal(t, int64(-1), end)
+		assert.False(t, halt)
+	})
+
+	mockHelper.AssertExpectations(t)
+}
+
+func TestOperationFilter(t *testing.T) {
+	ctx := context.Background()
+
+	mockHelper := &mocks.Helper{}
+	mockHandler := &mocks.Handler{}
+	syncer := New(
+		networkIdentifier,
+		mockHelper,
+		mockHandler,
+		nil,
+		WithOperationFilter(func(op *types.Operation) bool {
+			return *op.Status == "Success"
+		}),
+	)
+	syncer.genesisBlock = blockSequence[0].BlockIdentifier
+
+	mockHandler.On("BlockAdded", ctx, blockSequence[0]).Return(nil).Once()
+	assert.NoError(t, syncer.processBlock(ctx, &blockResult{block: blockSequence[0]}))
+
+	filteredBlock := &types.Block{
+		BlockIdentifier:       blockSequence[1].BlockIdentifier,
+		ParentBlockIdentifier: blockSequence[1].ParentBlockIdentifier,
+		Transactions: []*types.Transaction{
+			{
+				TransactionIdentifier: recipientTransaction.TransactionIdentifier,
+				Operations: []*types.Operation{
+					recipientOperation,
+				},
+			},
+		},
+	}
+	mockHandler.On("BlockAdded", ctx, filteredBlock).Return(nil).Once()
+	assert.NoError(t, syncer.processBlock(ctx, &blockResult{block: blockSequence[1]}))
+	assert.Equal(t, int64(2), syncer.nextIndex)
+	assert.Equal(t, blockSequence[1].BlockIdentifier, lastBlockIdentifier(syncer))
+
+	// The fetched block must not be modified.
+	assert.Len(t, blockSequence[1].Transactions[0].Operations, 2)
+
+	mockHelper.AssertExpectations(t)
+	mockHandler.AssertExpectations(t)
+}
+
+type batchHandler struct {
+	*mocks.Handler
+	*mocks.BatchHandler
+}
+
+type reorgHandler struct {
+	*mocks.Handler
+	*mocks.ReorgHandler
+}
+
+func TestBatchHandler(t *testing.T) {
+	ctx := context.Background()
+
+	mockHelper := &mocks.Helper{}
+	mockHandler := &mocks.Handler{}
+	mockBatchHandler := &mocks.BatchHandler{}
+	syncer := New(
+		networkIdentifier,
+		mockHelper,
+		&batchHandler{Handler: mockHandler, BatchHandler: mockBatchHandler},
+		nil,
+		WithBatchHandler(3),
+	)
+	syncer.genesisBlock = blockSequence[0].BlockIdentifier
+
+	// Blocks are not delivered until the batch is full
+	// or a block must be removed.
+	calls := []string{}
+	for _, block := range blockSequence[:2] {
+		assert.NoError(t, syncer.processBlock(ctx, &blockResult{block: block}))
+	}
+	assert.Len(t, syncer.pendingBlocks, 2)
+
+	mockBatchHandler.On(
+		"BlocksAdded",
+		ctx,
+		blockSequence[:2],
+	).Return(nil).Run(func(args mock.Arguments) {
+		calls = append(calls, "added")
+	}).Once()
+	mockHandler.On(
+		"BlockRemoved",
+		ctx,
+		blockSequence[1].BlockIdentifier,
+	).Return(nil).Run(func(args mock.Arguments) {
+		calls = append(calls, "removed")
+	}).Once()
+	assert.NoError(t, syncer.processBlock(ctx, &blockResult{block: blockSequence[2]}))
+	assert.Equal(t, []string{"added", "removed"}, calls)
+	assert.Len(t, syncer.pendingBlocks, 0)
+
+	// A full batch is delivered immediately
+	mockBatchHandler.On(
+		"BlocksAdded",
+		ctx,
+		[]*types.Block{blockSequence[3], blockSequence[2], blockSequence[4]},
+	).Return(nil).Once()
+	for _, block := range []*types.Block{blockSequence[3], blockSequence[2], blockSequence[4]} {
+		assert.NoError(t, syncer.processBlock(ctx, &blockResult{block: block}))
+	}
+	assert.Len(t, syncer.pendingBlocks, 0)
+	assert.Equal(t, int64(4), syncer.nextIndex)
+
+	// Flushing with no pending blocks is a no-op
+	assert.NoError(t, syncer.flushBlocks(ctx))
+
+	mockHelper.AssertExpectations(t)
+	mockHandler.AssertExpectations(t)
+	mockBatchHandler.AssertExpectations(t)
+}
+
+func TestWithoutReorgHandling(t *testing.T) {
+	ctx := context.Background()
+
+	mockHelper := &mocks.Helper{}
+	mockHandler := &mocks.Handler{}
+	syncer := New(
+		networkIdentifier,
+		mockHelper,
+		mockHandler,
+		nil,
+		WithoutReorgHandling(),
+	)
+	syncer.genesisBlock = blockSequence[0].BlockIdentifier
+
+	// blockSequence[2] does not build on blockSequence[1]
+	// but is appended anyway.
+	for _, block := range []*types.Block{
+		blockSequence[0],
+		blockSequence[1],
+		blockSequence[2],
+		blockSequence[4],
+	} {
+		mockHandler.On("BlockAdded", ctx, block).Return(nil).Once()
+		assert.NoError(t, syncer.processBlock(ctx, &blockResult{block: block}))
+	}
+	assert.Equal(t, int64(4), syncer.nextIndex)
+	assert.Len(t, syncer.pastBlocks, 0)
+
+	// Blocks must still be processed in order
+	err := syncer.processBlock(ctx, &blockResult{block: blockSequence[5]})
+	assert.True(t, errors.Is(err, ErrOutOfOrder))
+
+	// Orphaning the head is not possible
+	err = syncer.processBlock(ctx, &blockResult{index: 4, orphanHead: true})
+	assert.True(t, errors.Is(err, ErrReorgHandlingDisabled))
+	assert.Equal(t, int64(4), syncer.nextIndex)
+
+	mockHelper.AssertExpectations(t)
+	mockHandler.AssertExpectations(t)
+}
+
+func TestQuietSync(t *testing.T) {
+	syncer := New(networkIdentifier, nil, nil, nil)
+	for _, rangeEnd := range []int64{0, 1, 1, 2} {
+		assert.True(t, syncer.shouldLogRange(rangeEnd))
+	}
+
+	syncer = New(networkIdentifier, nil, nil, nil, WithQuietSync(1000))
+	var tests = []struct {
+		rangeEnd int64
+		logged   bool
+	}{
+		{rangeEnd: 0, logged: true},
+		{rangeEnd: 500, logged: false},
+		{rangeEnd: 999, logged: false},
+		{rangeEnd: 1003, logged: true},
+		{rangeEnd: 1500, logged: false},
+		{rangeEnd: 3500, logged: true},
+		{rangeEnd: 2000, logged: false}, // reorg
+		{rangeEnd: 4000, logged: true},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.logged, syncer.shouldLogRange(test.rangeEnd), test.rangeEnd)
+	}
+}
+
+func TestSkipGenesis(t *testing.T) {
+	ctx := context.Background()
+
+	mockHelper := &mocks.Helper{}
+	mockHandler := &mocks.Handler{}
+	syncer := New(
+		networkIdentifier,
+		mockHelper,
+		mockHandler,
+		nil,
+		WithSkipGenesis(true),
+	)
+	syncer.genesisBlock = blockSequence[0].BlockIdentifier
+
+	// The genesis block is not delivered
+	assert.NoError(t, syncer.handleSeenBlock(ctx, &blockResult{block: blockSequence[0]}))
+	assert.NoError(t, syncer.processBlock(ctx, &blockResult{block: blockSequence[0]}))
+	assert.Equal(t, int64(1), syncer.nextIndex)
+	assert.Equal(t, blockSequence[0].BlockIdentifier, lastBlockIdentifier(syncer))
+
+	// Children of the genesis block are delivered
+	mockHandler.On("BlockSeen", ctx, blockSequence[1]).Return(nil).Once()
+	mockHandler.On("BlockAdded", ctx, blockSequence[1]).Return(nil).Once()
+	assert.NoError(t, syncer.handleSeenBlock(ctx, &blockResult{block: blockSequence[1]}))
+	assert.NoError(t, syncer.processBlock(ctx, &blockResult{block: blockSequence[1]}))
+	assert.Equal(t, int64(2), syncer.nextIndex)
+
+	mockHelper.AssertExpectations(t)
+	mockHandler.AssertExpectations(t)
+}
+
+func TestFetchObserver(t *testing.T) {
+	ctx := context.Background()
+
+	type observation struct {
+		index  int64
+		block  *types.Block
+		orphan bool
+	}
+	observations := []*observation{}
+
+	mockHelper := &mocks.Helper{}
+	mockHandler := &mocks.Handler{}
+	syncer := New(
+		networkIdentifier,
+		mockHelper,
+		mockHandler,
+		nil,
+		WithFetchObserver(func(index int64, block *types.Block, orphan bool) {
+			observations = append(observations, &observation{index, block, orphan})
+		}),
+	)
+	syncer.genesisBlock = blockSequence[0].BlockIdentifier
+
+	index1 := int64(1)
+	mockHelper.On(
+		"Block",
+		ctx,
+		networkIdentifier,
+		&types.PartialBlockIdentifier{Index: &index1},
+	).Return(blockSequence[1], nil).Once()
+	mockHandler.On("BlockSeen", ctx, blockSequence[1]).Return(nil).Once()
+
+	index2 := int64(2)
+	mockHelper.On(
+		"Block",
+		ctx,
+		networkIdentifier,
+		&types.PartialBlockIdentifier{Index: &index2},
+	).Return(nil, ErrOrphanHead).Once()
+
+	index3 := int64(3)
+	mockHelper.On(
+		"Block",
+		ctx,
+		networkIdentifier,
+		&types.PartialBlockIdentifier{Index: &index3},
+	).Return(nil, errors.New("fetch failed")).Once()
+
+	br, err := syncer.fetchBlockResult(ctx, networkIdentifier, index1)
+	assert.NoError(t, err)
+	assert.Equal(t, blockSequence[1], br.block)
+
+	br, err = syncer.fetchBlockResult(ctx, networkIdentifier, index2)
+	assert.NoError(t, err)
+	assert.True(t, br.orphanHead)
+
+	// Failed fetches are not observed
+	br, err = syncer.fetchBlockResult(ctx, networkIdentifier, index3)
+	assert.Error(t, err)
+	assert.Nil(t, br)
+
+	assert.Equal(t, []*observation{
+		{index: 1, block: blockSequence[1]},
+		{index: 2, orphan: true},
+	}, observations)
+
+	mockHelper.AssertExpectations(t)
+	mockHandler.AssertExpectations(t)
+}
+
+func TestWithAsserter(t *testing.T) {
+	ctx := context.Background()
+
+	a, err := asserter.NewClientWithOptions(
+		networkIdentifier,
+		blockSequence[0].BlockIdentifier,
+		[]string{"Transfer"},
+		[]*types.OperationStatus{{Status: "Success", Successful: true}},
+		[]*types.Error{},
+		nil,
+		&asserter.Validations{Enabled: false},
+	)
+	assert.NoError(t, err)
+
+	mockHelper := &mocks.Helper{}
+	mockHandler := &mocks.Handler{}
+	syncer := New(
+		networkIdentifier,
+		mockHelper,
+		mockHandler,
+		nil,
+		WithAsserter(a),
+	)
+	syncer.genesisBlock = blockSequence[0].BlockIdentifier
+
+	valid := &types.Block{
+		BlockIdentifier:       blockSequence[1].BlockIdentifier,
+		ParentBlockIdentifier: blockSequence[1].ParentBlockIdentifier,
+		Timestamp:             asserter.MinUnixEpoch + 1,
+	}
+	index1 := int64(1)
+	mockHelper.On(
+		"Block",
+		ctx,
+		networkIdentifier,
+		&types.PartialBlockIdentifier{Index: &index1},
+	).Return(valid, nil).Once()
+	mockHandler.On("BlockSeen", ctx, valid).Return(nil).Once()
+
+	// Block hash is the same as its parent
+	malformed := &types.Block{
+		BlockIdentifier: &types.BlockIdentifier{
+			Hash:  "1",
+			Index: 2,
+		},
+		ParentBlockIdentifier: valid.BlockIdentifier,
+		Timestamp:             asserter.MinUnixEpoch + 1,
+	}
+	index2 := int64(2)
+	mockHelper.On(
+		"Block",
+		ctx,
+		networkIdentifier,
+		&types.PartialBlockIdentifier{Index: &index2},
+	).Return(malformed, nil).Once()
+
+	// Omitted blocks are not validated
+	index3 := int64(3)
+	mockHelper.On(
+		"Block",
+		ctx,
+		networkIdentifier,
+		&types.PartialBlockIdentifier{Index: &index3},
+	).Return(nil, nil).Once()
+
+	br, err := syncer.fetchBlockResult(ctx, networkIdentifier, index1)
+	assert.NoError(t, err)
+	assert.Equal(t, valid, br.block)
+
+	br, err = syncer.fetchBlockResult(ctx, networkIdentifier, index2)
+	assert.True(t, errors.Is(err, ErrBlockInvalid))
+	assert.Nil(t, br)
+
+	br, err = syncer.fetchBlockResult(ctx, networkIdentifier, index3)
+	assert.NoError(t, err)
+	assert.Nil(t, br.block)
+
+	mockHelper.AssertExpectations(t)
+	mockHandler.AssertExpectations(t)
+}
+
+func TestHandlerRetry(t *testing.T) {
+	handlerErr := errors.New("downstream unavailable")
+	backoff := 5 * time.Second
+
+	var tests = map[string]struct {
+		failures []error
+
+		expectedCalls  int
+		expectedSleeps int
+		expectedError  error
+	}{
+		"no error": {
+			expectedCalls: 1,
+		},
+		"retry then succeed": {
+			failures:       []error{handlerErr, handlerErr},
+			expectedCalls:  3,
+			expectedSleeps: 2,
+		},
+		"retries exhausted": {
+			failures:       []error{handlerErr, handlerErr, handlerErr, handlerErr},
+			expectedCalls:  4,
+			expectedSleeps: 3,
+			expectedError:  ErrHandlerRetriesExhausted,
+		},
+		"non-retriable": {
+			failures:      []error{fmt.Errorf("%w: bad block", ErrHandlerNonRetriable)},
+			expectedCalls: 1,
+			expectedError: ErrHandlerNonRetriable,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			mockHelper := &mocks.Helper{}
+			mockHandler := &mocks.Handler{}
+			mockClock := &mockUtils.Clock{}
+			syncer := New(
+				networkIdentifier,
+				mockHelper,
+				mockHandler,
+				nil,
+				WithClock(mockClock),
+				WithHandlerRetry(3, backoff),
+			)
+			syncer.genesisBlock = blockSequence[0].BlockIdentifier
+			syncer.nextIndex = 1
+			syncer.pastBlocks = []*types.BlockIdentifier{blockSequence[0].BlockIdentifier}
+
+			for _, err := range test.failures {
+				mockHandler.On("BlockAdded", ctx, blockSequence[1]).Return(err).Once()
+			}
+			if len(test.failures) < test.expectedCalls {
+				mockHandler.On("BlockAdded", ctx, blockSequence[1]).Return(nil).Once()
+			}
+			if test.expectedSleeps > 0 {
+				mockClock.On("Sleep", backoff).Return().Times(test.expectedSleeps)
+			}
+			mockClock.On("Now").Return(time.Unix(1600000000, 0)).Maybe()
+
+			err := syncer.processBlock(ctx, &blockResult{block: blockSequence[1]})
+			if test.expectedError != nil {
+				assert.True(t, errors.Is(err, test.expectedError))
+				assert.Equal(t, int64(1), syncer.nextIndex)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(2), syncer.nextIndex)
+			}
+
+			mockHandler.AssertNumberOfCalls(t, "BlockAdded", test.expectedCalls)
+			mockHelper.AssertExpectations(t)
+			mockHandler.AssertExpectations(t)
+			mockClock.AssertExpectations(t)
+		})
+	}
+
+	t.Run("context canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		mockHandler := &mocks.Handler{}
+		mockClock := &mockUtils.Clock{}
+		syncer := New(
+			networkIdentifier,
+			&mocks.Helper{},
+			mockHandler,
+			nil,
+			WithClock(mockClock),
+			WithHandlerRetry(3, backoff),
+		)
+		syncer.genesisBlock = blockSequence[0].BlockIdentifier
+		syncer.nextIndex = 1
+		syncer.pastBlocks = []*types.BlockIdentifier{blockSequence[0].BlockIdentifier}
+
+		// The context is canceled while waiting to retry (the
+		// backoff does not complete until the test exits).
+		release := make(chan struct{})
+		defer close(release)
+
+		mockHandler.On("BlockAdded", ctx, blockSequence[1]).Return(handlerErr).Once()
+		mockClock.On("Sleep", backoff).Run(func(args mock.Arguments) {
+			<-release
+		}).Return().Once()
+		time.AfterFunc(10*time.Millisecond, cancel)
+
+		err := syncer.processBlock(ctx, &blockResult{block: blockSequence[1]})
+		assert.True(t, errors.Is(err, context.Canceled))
+		mockHandler.AssertNumberOfCalls(t, "BlockAdded", 1)
+
+		mockHandler.AssertExpectations(t)
+		mockClock.AssertExpectations(t)
+	})
+}
+
+func TestSyncToTip(t *testing.T) {
+	blocks := createBlocks(0, 20, "")
+
+	t.Run("resume from past blocks", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		mockHelper := &mocks.Helper{}
+		mockHandler := &mocks.Handler{}
+		syncer := New(
+			networkIdentifier,
+			mockHelper,
+			mockHandler,
+			cancel,
+			WithPastBlocks([]*types.BlockIdentifier{blocks[4].BlockIdentifier}),
+		)
+
+		// The tip is determined when SyncToTip is invoked
+		mockHelper.On("NetworkStatus", ctx, networkIdentifier).Return(&types.NetworkStatusResponse{
+			CurrentBlockIdentifier: blocks[10].BlockIdentifier,
+			GenesisBlockIdentifier: blocks[0].BlockIdentifier,
+		}, nil).Once()
+
+		// Later statuses report further tip growth
+		mockHelper.On("NetworkStatus", ctx, networkIdentifier).Return(&types.NetworkStatusResponse{
+			CurrentBlockIdentifier: blocks[20].BlockIdentifier,
+			GenesisBlockIdentifier: blocks[0].BlockIdentifier,
+		}, nil)
+
+		for _, b := range blocks[5:11] {
+			index := b.BlockIdentifier.Index
+			mockHelper.On(
+				"Block",
+				mock.AnythingOfType("*context.cancelCtx"),
+				networkIdentifier,
+				&types.PartialBlockIdentifier{Index: &index},
+			).Return(
+				b,
+				nil,
+			).Once()
+			mockHandler.On(
+				"BlockSeen",
+				mock.AnythingOfType("*context.cancelCtx"),
+				b,
+			).Return(
+				nil,
+			).Once()
+			mockHandler.On(
+				"BlockAdded",
+				mock.AnythingOfType("*context.cancelCtx"),
+				b,
+			).Return(
+				nil,
+			).Once()
+		}
+
+		head, err := syncer.SyncToTip(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, blocks[10].BlockIdentifier, head)
+		mockHelper.AssertExpectations(t)
+		mockHandler.AssertExpectations(t)
+	})
+
+	t.Run("at tip", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		mockHelper := &mocks.Helper{}
+		mockHandler := &mocks.Handler{}
+		syncer := New(
+			networkIdentifier,
+			mockHelper,
+			mockHandler,
+			cancel,
+			WithPastBlocks([]*types.BlockIdentifier{blocks[10].BlockIdentifier}),
+		)
+
+		mockHelper.On("NetworkStatus", ctx, networkIdentifier).Return(&types.NetworkStatusResponse{
+			CurrentBlockIdentifier: blocks[10].BlockIdentifier,
+			GenesisBlockIdentifier: blocks[0].BlockIdentifier,
+		}, nil)
+
+		head, err := syncer.SyncToTip(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, blocks[10].BlockIdentifier, head)
+		mockHelper.AssertNotCalled(t, "Block", mock.Anything, mock.Anything, mock.Anything)
+		mockHandler.AssertExpectations(t)
+	})
+
+	t.Run("descending", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		mockHelper := &mocks.Helper{}
+		mockHandler := &mocks.Handler{}
+		syncer := New(
+			networkIdentifier,
+			mockHelper,
+			mockHandler,
+			cancel,
+			WithSyncDirection(Descending),
+		)
+
+		head, err := syncer.SyncToTip(ctx)
+		assert.Nil(t, head)
+		assert.True(t, errors.Is(err, ErrSyncToTipDescending))
+		mockHelper.AssertNotCalled(t, "NetworkStatus", mock.Anything, mock.Anything)
+		mockHelper.AssertNotCalled(t, "Block", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestHeartbeat(t *testing.T) {
+	var tests = map[string]struct {
+		startIndex int64
+		tip        int64
+
+		expectedNextIndex int64
+	}{
+		"idle at tip": {
+			startIndex:        5,
+			tip:               4,
+			expectedNextIndex: 5,
+		},
+		"idle after syncing": {
+			startIndex:        0,
+			tip:               2,
+			expectedNextIndex: 3,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockHelper := &mocks.Helper{}
+			mockHandler := &mocks.Handler{}
+			mockClock := &mockUtils.Clock{}
+
+			now := time.Unix(1600000000, 0)
+			mockClock.On("Now").Return(func() time.Time { return now })
+			mockClock.On("Sleep", defaultSyncSleep).Run(func(args mock.Arguments) {
+				now = now.Add(defaultSyncSleep)
+			}).Return()
+
+			heartbeats := []int64{}
+			syncer := New(
+				networkIdentifier,
+				mockHelper,
+				mockHandler,
+				cancel,
+				WithClock(mockClock),
+				WithHeartbeat(5*time.Second, func(nextIndex int64) {
+					heartbeats = append(heartbeats, nextIndex)
+					if len(heartbeats) == 3 {
+						cancel()
+					}
+				}),
+			)
+
+			blocks := createBlocks(0, test.tip, "")
+			mockHelper.On("NetworkStatus", ctx, networkIdentifier).Return(&types.NetworkStatusResponse{
+				CurrentBlockIdentifier: blocks[test.tip].BlockIdentifier,
+				GenesisBlockIdentifier: blocks[0].BlockIdentifier,
+			}, nil)
+			for _, b := range blocks[test.startIndex:] {
+				index := b.BlockIdentifier.Index
+				mockHelper.On(
+					"Block",
+					mock.Anything,
+					networkIdentifier,
+					&types.PartialBlockIdentifier{Index: &index},
+				).Return(b, nil).Once()
+				mockHandler.On("BlockSeen", mock.Anything, b).Return(nil).Once()
+				mockHandler.On("BlockAdded", mock.Anything, b).Return(nil).Once()
+			}
+
+			err := syncer.Sync(ctx, test.startIndex, -1)
+			assert.True(t, errors.Is(err, context.Canceled))
+
+			// The heartbeat interval starts when the syncer
+			// becomes idle, so heartbeats fire after 6s, 12s,
+			// and 18s of sleeping (the syncer does not sleep
+			// again once canceled).
+			expected := []int64{
+				test.expectedNextIndex,
+				test.expectedNextIndex,
+				test.expectedNextIndex,
+			}
+			assert.Equal(t, expected, heartbeats)
+			mockClock.AssertNumberOfCalls(t, "Sleep", 9)
+			mockHelper.AssertExpectations(t)
+			mockHandler.AssertExpectations(t)
+		})
+	}
+}
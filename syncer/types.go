@@ -19,14 +19,16 @@ import (
 	"sync"
 	"time"
 
+	"github.com/coinbase/rosetta-sdk-go/asserter"
 	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/coinbase/rosetta-sdk-go/utils"
 )
 
 const (
 	// DefaultPastBlockLimit is the maximum number of previously
 	// processed block headers we keep in the syncer to handle
-	// reorgs correctly. If there is a reorg greater than
-	// DefaultPastBlockLimit, it will not be handled correctly.
+	// reorgs correctly. If there is a reorg deeper than
+	// DefaultPastBlockLimit, Sync returns ErrReorgExceedsWindow.
 	DefaultPastBlockLimit = 100
 
 	// DefaultConcurrency is the default number of
@@ -71,12 +73,42 @@ const (
 	// when we are at tip but want to keep syncing.
 	defaultSyncSleep = 2 * time.Second
 
+	// throughputSmoothing is the weight given to the most
+	// recent interval between processed blocks when computing
+	// the exponential moving average used for Throughput.
+	throughputSmoothing = 0.1
+
+	// DefaultFetchBackoffBase is the backoff before the
+	// first retry of a failed block fetch (see WithFetchRetries).
+	DefaultFetchBackoffBase = 100 * time.Millisecond
+
+	// DefaultFetchBackoffMax is the maximum backoff between
+	// retries of a failed block fetch (see WithFetchRetries).
+	DefaultFetchBackoffMax = 10 * time.Second
+
 	// defaultFetchSleep is the amount of time to sleep
 	// when we are loading more blocks to fetch but we
 	// already have a backlog >= to concurrency.
 	defaultFetchSleep = 500 * time.Millisecond
 )
 
+// SyncDirection is the order in which
+// the syncer processes block indices.
+type SyncDirection int
+
+const (
+	// Ascending processes blocks from startIndex
+	// (genesis by default) up to endIndex (tip by
+	// default). This is the default SyncDirection.
+	Ascending SyncDirection = iota
+
+	// Descending processes blocks from startIndex
+	// (tip by default) down to endIndex (genesis by
+	// default). Reorgs are not detected when syncing
+	// in this direction (see WithSyncDirection).
+	Descending
+)
+
 // Handler is called at various times during the sync cycle
 // to handle different events. It is common to write logs or
 // perform reconciliation in the sync processor.
@@ -101,6 +133,38 @@ type Handler interface {
 	) error
 }
 
+// BatchHandler may optionally be implemented by a Handler
+// to receive added blocks in batches (see WithBatchHandler).
+// When batching is enabled, BlocksAdded is invoked instead
+// of BlockAdded with contiguous blocks in the order they
+// were added.
+type BatchHandler interface {
+	BlocksAdded(
+		ctx context.Context,
+		blocks []*types.Block,
+	) error
+}
+
+// ReorgHandler may optionally be implemented by a Handler
+// to determine which calls to BlockRemoved and BlockAdded
+// belong to the same reorg. ReorgStarted is invoked before the
+// first BlockRemoved of a reorg and ReorgFinished is invoked
+// after the first block that reconnects the chain is added
+// (the last block common to both chains cannot be determined
+// until then).
+type ReorgHandler interface {
+	ReorgStarted(
+		ctx context.Context,
+		head *types.BlockIdentifier,
+	) error
+
+	ReorgFinished(
+		ctx context.Context,
+		lastCommon *types.BlockIdentifier,
+		newHead *types.BlockIdentifier,
+	) error
+}
+
 // Helper is called at various times during the sync cycle
 // to get information about a blockchain network. It is
 // common to implement this helper using the Fetcher package.
@@ -117,6 +181,77 @@ type Helper interface {
 	) (*types.Block, error)
 }
 
+// SyncProgress is a snapshot of the progress
+// of a Syncer (see Syncer.SyncProgress).
+type SyncProgress struct {
+	// NextIndex is the index of the next
+	// block to be processed.
+	NextIndex int64
+
+	// Concurrency is the number of goroutines
+	// currently fetching blocks and GoalConcurrency
+	// is the number the syncer is adjusting towards.
+	Concurrency     int64
+	GoalConcurrency int64
+
+	// CurrentCacheEstimateBytes is the projected size of
+	// fetched blocks held in memory at Concurrency (the
+	// same estimate used to adjust concurrency). When a
+	// BatchHelper is used, each goroutine is assumed to
+	// hold a full batch of blocks.
+	CurrentCacheEstimateBytes int
+}
+
+// BatchHelper may optionally be implemented by a Helper
+// to fetch multiple blocks in a single request (see
+// WithBatchSize). BlocksRange must return a block (or nil,
+// if the block is omitted) for each index in [start, end],
+// in ascending order.
+type BatchHelper interface {
+	BlocksRange(
+		ctx context.Context,
+		network *types.NetworkIdentifier,
+		start int64,
+		end int64,
+	) ([]*types.Block, error)
+}
+
+// SizedHelper may optionally be implemented by a Helper
+// to provide the size (in bytes) of each block it returns
+// (often known from the response) so that the syncer does
+// not need to compute it with utils.SizeOf when adjusting
+// concurrency. If SizedBlock returns a size <= 0, the size
+// is computed with utils.SizeOf.
+type SizedHelper interface {
+	SizedBlock(
+		context.Context,
+		*types.NetworkIdentifier,
+		*types.PartialBlockIdentifier,
+	) (*types.Block, int, error)
+}
+
+// OmittedHelper may optionally be implemented by a Helper
+// to skip indices the network omits (which the Helper would
+// otherwise return as nil blocks) without fetching each of
+// them. NextNonOmitted must return the smallest index >=
+// fromIndex that is not omitted (fromIndex if it is not
+// omitted).
+//
+// NextNonOmitted is only invoked after Block returns an
+// omitted block and the rest of the gap it returns is not
+// fetched, so it is typically invoked once per gap (or once
+// per goroutine that fetched an index in the gap before
+// the gap was found). It is not used when syncing in the
+// Descending direction or when blocks are fetched with a
+// BatchHelper.
+type OmittedHelper interface {
+	NextNonOmitted(
+		ctx context.Context,
+		network *types.NetworkIdentifier,
+		fromIndex int64,
+	) (int64, error)
+}
+
 // Syncer coordinates blockchain syncing without relying on
 // a storage interface. Instead, it calls a provided Handler
 // whenever a block is added or removed. This provides the client
@@ -133,6 +268,7 @@ type Syncer struct {
 	genesisBlock *types.BlockIdentifier
 	tip          *types.BlockIdentifier
 	nextIndex    int64
+	lastBlock    *types.BlockIdentifier
 
 	// To ensure reorgs are handled correctly, the syncer must be able
 	// to observe blocks it has previously processed. Without this, the
@@ -144,6 +280,27 @@ type Syncer struct {
 	pastBlocks     []*types.BlockIdentifier
 	pastBlockLimit int
 
+	// windowParent is the last block evicted from pastBlocks
+	// (the parent of pastBlocks[0]), if any. If a reorg orphans
+	// all pastBlocks, the next block must connect to it.
+	windowParent *types.BlockIdentifier
+
+	// skipGenesis prevents the genesis block from
+	// being delivered to the Handler.
+	skipGenesis bool
+
+	// inReorg is true after a block is removed until
+	// the next block is added (see ReorgHandler).
+	inReorg bool
+
+	// direction is the order in which
+	// block indices are processed.
+	direction SyncDirection
+
+	// disableReorgs skips all reorg detection and
+	// pastBlocks maintenance (see WithoutReorgHandling).
+	disableReorgs bool
+
 	// Automatically manage concurrency based on the
 	// provided max cache size. The algorithm used here
 	// is a slow rise (to increase concurrency) and fast
@@ -158,6 +315,77 @@ type Syncer struct {
 	adjustmentWindow int64
 	concurrencyLock  sync.Mutex
 
+	// If maxGoroutines is set, neither concurrency nor
+	// goalConcurrency is increased above maxGoroutines.
+	maxGoroutines int64
+
+	// Track the exponential moving average of the interval
+	// between processed blocks to compute throughput.
+	averageInterval float64
+	lastProcessed   time.Time
+	throughputLock  sync.Mutex
+
+	// operationFilter is applied to all operations in
+	// a block before it is passed to the Handler.
+	operationFilter func(*types.Operation) bool
+
+	// If handlerRetries is set, failed Handler
+	// invocations are retried after handlerBackoff.
+	handlerRetries int
+	handlerBackoff time.Duration
+
+	// If fetchRetries is set, failed Helper.Block
+	// invocations are retried with exponential backoff
+	// (from fetchBackoffBase to fetchBackoffMax).
+	fetchRetries     int
+	fetchBackoffBase time.Duration
+	fetchBackoffMax  time.Duration
+
+	// If asserter is set, every fetched block is
+	// validated before it is processed.
+	asserter *asserter.Asserter
+
+	// If the Helper implements BatchHelper and
+	// fetchBatchSize is set, blocks are fetched
+	// in ranges of up to fetchBatchSize blocks.
+	batchHelper    BatchHelper
+	fetchBatchSize int64
+
+	// If the Helper implements OmittedHelper, gaps of
+	// omitted indices found when fetching an omitted block
+	// are recorded in omittedGaps (start -> next non-omitted
+	// index) so that the rest of each gap is skipped without
+	// fetching. omittedGaps is reset for each synced range.
+	omittedHelper   OmittedHelper
+	omittedGaps     map[int64]int64
+	omittedGapsLock sync.Mutex
+
+	// fetchObserver is invoked with every block
+	// fetched before it is processed.
+	fetchObserver func(index int64, block *types.Block, orphan bool)
+
+	// If heartbeat is set, it is invoked at most once every
+	// heartbeatInterval while the syncer is idle at tip.
+	heartbeat         func(nextIndex int64)
+	heartbeatInterval time.Duration
+	lastHeartbeat     time.Time
+
+	// If the Handler implements BatchHandler and batchSize
+	// is set, added blocks are accumulated in pendingBlocks
+	// and delivered to batchHandler together.
+	batchHandler  BatchHandler
+	batchSize     int
+	pendingBlocks []*types.Block
+
+	// If syncLogInterval is set, "Syncing" lines are
+	// only logged once per interval of blocks.
+	syncLogInterval  int64
+	lastLoggedBucket int64
+
+	// clock is used for all time lookups and sleeps
+	// so that timing can be controlled in tests.
+	clock utils.Clock
+
 	// doneLoading is used to coordinate adding goroutines
 	// when close to the end of syncing a range.
 	doneLoading     bool
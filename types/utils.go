@@ -19,12 +19,111 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"math/big"
+	"sort"
+	"strings"
 
 	"github.com/mitchellh/mapstructure"
 )
 
+var (
+	// ErrPartialBlockIdentifierIndexInvalid is returned when a
+	// *PartialBlockIdentifier has a populated index that is negative.
+	ErrPartialBlockIdentifierIndexInvalid = errors.New("partial block identifier index is negative")
+
+	// ErrPartialBlockIdentifierHashEmpty is returned when a
+	// *PartialBlockIdentifier has a populated hash that is empty.
+	ErrPartialBlockIdentifierHashEmpty = errors.New("partial block identifier hash is empty")
+
+	// ErrAmountCurrencyNil is returned when the magnitude
+	// of an *Amount without a *Currency is requested.
+	ErrAmountCurrencyNil = errors.New("amount currency cannot be nil")
+
+	// ErrCurrencyDecimalsInvalid is returned when a
+	// *Currency has decimals that are negative.
+	ErrCurrencyDecimalsInvalid = errors.New("currency decimals cannot be negative")
+
+	// ErrSigningPayloadNotCovered is returned when a
+	// *SigningPayload does not have a corresponding
+	// *Signature from its address.
+	ErrSigningPayloadNotCovered = errors.New("signing payload is not covered by a signature")
+
+	// ErrSigningPayloadAccountNil is returned when a
+	// *SigningPayload does not have an *AccountIdentifier.
+	ErrSigningPayloadAccountNil = errors.New("signing payload account identifier cannot be nil")
+)
+
+// BlockLookupMode describes how a block is looked
+// up with a *PartialBlockIdentifier.
+type BlockLookupMode string
+
+const (
+	// LookupHead means the current head block should be
+	// returned (neither index nor hash are populated).
+	LookupHead BlockLookupMode = "head"
+
+	// LookupByIndex means the block should be looked up
+	// by index.
+	LookupByIndex BlockLookupMode = "by-index"
+
+	// LookupByHash means the block should be looked up
+	// by hash.
+	LookupByHash BlockLookupMode = "by-hash"
+
+	// LookupByIndexAndHash means the block should be looked
+	// up by hash and must have the provided index.
+	LookupByIndexAndHash BlockLookupMode = "by-index-and-hash"
+)
+
+// ValidatePartialBlockIdentifier returns an error if a
+// *PartialBlockIdentifier has a populated index that is
+// negative or a populated hash that is empty. A nil
+// *PartialBlockIdentifier is valid (it refers to the head block).
+func ValidatePartialBlockIdentifier(blockIdentifier *PartialBlockIdentifier) error {
+	if blockIdentifier == nil {
+		return nil
+	}
+
+	if blockIdentifier.Index != nil && *blockIdentifier.Index < 0 {
+		return fmt.Errorf(
+			"%w: %d",
+			ErrPartialBlockIdentifierIndexInvalid,
+			*blockIdentifier.Index,
+		)
+	}
+
+	if blockIdentifier.Hash != nil && len(*blockIdentifier.Hash) == 0 {
+		return ErrPartialBlockIdentifierHashEmpty
+	}
+
+	return nil
+}
+
+// PartialBlockIdentifierLookupMode returns the BlockLookupMode
+// represented by a *PartialBlockIdentifier. This does not
+// validate the *PartialBlockIdentifier (use
+// ValidatePartialBlockIdentifier).
+func PartialBlockIdentifierLookupMode(
+	blockIdentifier *PartialBlockIdentifier,
+) BlockLookupMode {
+	if blockIdentifier == nil {
+		return LookupHead
+	}
+
+	switch {
+	case blockIdentifier.Index != nil && blockIdentifier.Hash != nil:
+		return LookupByIndexAndHash
+	case blockIdentifier.Hash != nil:
+		return LookupByHash
+	case blockIdentifier.Index != nil:
+		return LookupByIndex
+	default:
+		return LookupHead
+	}
+}
+
 // ConstructPartialBlockIdentifier constructs a *PartialBlockIdentifier
 // from a *BlockIdentifier.
 //
@@ -103,6 +202,39 @@ func AmountValue(amount *Amount) (*big.Int, error) {
 	return BigInt(amount.Value)
 }
 
+// AmountMagnitude returns the value of an *Amount scaled
+// by the decimals of its *Currency (Value / 10^Decimals).
+// This is useful for displaying an *Amount or checking that
+// it is in a reasonable range and should not be used to
+// perform arithmetic on amounts (use AmountValue instead).
+func AmountMagnitude(amount *Amount) (*big.Float, error) {
+	value, err := AmountValue(amount)
+	if err != nil {
+		return nil, err
+	}
+
+	if amount.Currency == nil {
+		return nil, ErrAmountCurrencyNil
+	}
+
+	decimals := amount.Currency.Decimals
+	if decimals < 0 {
+		return nil, fmt.Errorf("%w: %d", ErrCurrencyDecimalsInvalid, decimals)
+	}
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil) // nolint:gomnd
+
+	// Use enough precision to represent both the integer
+	// digits and the decimal digits (~log2(10) bits each)
+	// of the scaled value.
+	precision := uint(value.BitLen()) + uint(decimals)*4 + 64 // nolint:gomnd
+
+	return new(big.Float).SetPrec(precision).Quo(
+		new(big.Float).SetInt(value),
+		new(big.Float).SetInt(scale),
+	), nil
+}
+
 // AddValues adds string amounts using
 // big.Int.
 func AddValues(
@@ -218,6 +350,54 @@ func AccountString(account *AccountIdentifier) string {
 	)
 }
 
+// AccountIdentifierHash returns a deterministic hash of an
+// *AccountIdentifier that should be used as the canonical key
+// for an account (including its SubAccountIdentifier). The
+// SubAccountIdentifier address and metadata are incorporated,
+// so accounts that only differ by subaccount will not collide,
+// while subaccount metadata is hashed regardless of key order.
+//
+// The result is identical to Hash(account) so that keys
+// persisted with previous versions remain valid.
+func AccountIdentifierHash(account *AccountIdentifier) string {
+	return Hash(account)
+}
+
+// AccountsInBlock returns the unique *AccountIdentifiers
+// (deduplicated using AccountIdentifierHash) of all successful
+// operations in a *Block, in the order they first appear.
+//
+// An operation is successful if its status is marked Successful
+// in statuses (usually the OperationStatuses returned in
+// NetworkOptionsResponse.Allow). Operations with a missing or
+// unknown status are not considered successful.
+func AccountsInBlock(block *Block, statuses []*OperationStatus) []*AccountIdentifier {
+	successful := map[string]bool{}
+	for _, status := range statuses {
+		successful[status.Status] = status.Successful
+	}
+
+	seen := map[string]struct{}{}
+	accounts := []*AccountIdentifier{}
+	for _, tx := range block.Transactions {
+		for _, op := range tx.Operations {
+			if op.Account == nil || op.Status == nil || !successful[*op.Status] {
+				continue
+			}
+
+			key := AccountIdentifierHash(op.Account)
+			if _, ok := seen[key]; ok {
+				continue
+			}
+
+			seen[key] = struct{}{}
+			accounts = append(accounts, op.Account)
+		}
+	}
+
+	return accounts
+}
+
 // CurrencyString returns a human-readable representation
 // of a *Currency.
 func CurrencyString(currency *Currency) string {
@@ -233,6 +413,27 @@ func CurrencyString(currency *Currency) string {
 	)
 }
 
+// CurrenciesEqual returns a boolean indicating if two
+// *Currency are equal. Currencies are equal if they have
+// the same Symbol, Decimals, and Metadata (regardless of
+// the order in which Metadata keys were populated). Nil and
+// empty Metadata are considered equal.
+func CurrenciesEqual(a *Currency, b *Currency) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	if a.Symbol != b.Symbol || a.Decimals != b.Decimals {
+		return false
+	}
+
+	if len(a.Metadata) == 0 && len(b.Metadata) == 0 {
+		return true
+	}
+
+	return Hash(a.Metadata) == Hash(b.Metadata)
+}
+
 // PrettyPrintStruct marshals a struct to JSON and returns
 // it as a string.
 func PrettyPrintStruct(val interface{}) string {
@@ -251,14 +452,18 @@ func PrettyPrintStruct(val interface{}) string {
 // PrintStruct marshals a struct to JSON and returns
 // it as a string without newlines.
 func PrintStruct(val interface{}) string {
-	str, err := json.Marshal(
-		val,
-	)
-	if err != nil {
+	var builder strings.Builder
+	if err := WriteStruct(&builder, val); err != nil {
 		log.Fatal(err)
 	}
 
-	return string(str)
+	return strings.TrimSuffix(builder.String(), "\n")
+}
+
+// WriteStruct marshals a struct to JSON and writes it
+// to w (followed by a newline).
+func WriteStruct(w io.Writer, val interface{}) error {
+	return json.NewEncoder(w).Encode(val)
 }
 
 // MarshalMap attempts to marshal an interface into a map[string]interface{}.
@@ -307,7 +512,7 @@ func ExtractAmount(
 	currency *Currency,
 ) *Amount {
 	for _, b := range balances {
-		if Hash(b.Currency) != Hash(currency) {
+		if !CurrenciesEqual(b.Currency, currency) {
 			continue
 		}
 
@@ -318,6 +523,300 @@ func ExtractAmount(
 	return &Amount{Value: "0", Currency: currency}
 }
 
+// SumOperationAmounts returns the sum of the amounts of all
+// operations for which filter returns true (a nil filter matches
+// all operations). Matching operations without an amount are
+// ignored. All summed amounts must have the same currency.
+//
+// If no matching operation has an amount, nil is returned
+// (the currency of the sum is unknown).
+func SumOperationAmounts(
+	ops []*Operation,
+	filter func(*Operation) bool,
+) (*Amount, error) {
+	var (
+		sum      *big.Int
+		currency *Currency
+	)
+	for i, op := range ops {
+		if op.Amount == nil || (filter != nil && !filter(op)) {
+			continue
+		}
+
+		value, err := AmountValue(op.Amount)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"%w: operation at position %d has an invalid amount",
+				err,
+				i,
+			)
+		}
+
+		if sum == nil {
+			sum = value
+			currency = op.Amount.Currency
+			continue
+		}
+
+		if !CurrenciesEqual(currency, op.Amount.Currency) {
+			return nil, fmt.Errorf(
+				"operation at position %d has currency %s but expected %s",
+				i,
+				PrintStruct(op.Amount.Currency),
+				PrintStruct(currency),
+			)
+		}
+
+		sum.Add(sum, value)
+	}
+
+	if sum == nil {
+		return nil, nil
+	}
+
+	return &Amount{
+		Value:    sum.String(),
+		Currency: currency,
+	}, nil
+}
+
+// GroupRelatedOperations returns the operations in a transaction
+// clustered into connected components of the RelatedOperations
+// graph (relatedness is treated as transitive and undirected).
+// Groups are returned in ascending order of the lowest operation
+// index in each group and operations within each group are sorted
+// by index.
+//
+// As in the asserter, operations must be sorted by index (starting
+// at 0) and may only be related to operations with a lower index.
+func GroupRelatedOperations(txn *Transaction) ([][]*Operation, error) {
+	ops := txn.Operations
+	parents := make([]int, len(ops))
+	for i := range parents {
+		parents[i] = i
+	}
+
+	var find func(int) int
+	find = func(i int) int {
+		if parents[i] != i {
+			parents[i] = find(parents[i])
+		}
+
+		return parents[i]
+	}
+
+	for i, op := range ops {
+		if op.OperationIdentifier == nil || op.OperationIdentifier.Index != int64(i) {
+			return nil, fmt.Errorf("operation at position %d does not have index %d", i, i)
+		}
+
+		seen := map[int64]struct{}{}
+		for _, related := range op.RelatedOperations {
+			if related.Index < 0 || related.Index >= op.OperationIdentifier.Index {
+				return nil, fmt.Errorf(
+					"related operation index %d is invalid for operation index %d",
+					related.Index,
+					op.OperationIdentifier.Index,
+				)
+			}
+
+			if _, ok := seen[related.Index]; ok {
+				return nil, fmt.Errorf(
+					"related operation index %d is duplicated for operation index %d",
+					related.Index,
+					op.OperationIdentifier.Index,
+				)
+			}
+			seen[related.Index] = struct{}{}
+
+			// The root of each group is always its lowest index
+			// so that groups can be ordered deterministically.
+			root, relatedRoot := find(i), find(int(related.Index))
+			if root < relatedRoot {
+				parents[relatedRoot] = root
+			} else {
+				parents[root] = relatedRoot
+			}
+		}
+	}
+
+	groups := [][]*Operation{}
+	groupIndexes := map[int]int{}
+	for i, op := range ops {
+		root := find(i)
+		groupIndex, ok := groupIndexes[root]
+		if !ok {
+			groupIndex = len(groups)
+			groupIndexes[root] = groupIndex
+			groups = append(groups, []*Operation{})
+		}
+
+		groups[groupIndex] = append(groups[groupIndex], op)
+	}
+
+	return groups, nil
+}
+
+// SimpleTransferOperations returns the two operations of a simple
+// transfer of amount from one account to another: a debit of from
+// at index 0 and a credit of to at index 1 (related to the debit).
+// The absolute value of amount is used, so the debit is always
+// negative and the credit is always positive.
+//
+// No status is populated, so the operations are suitable for
+// construction. If amount is not a valid integer, it is used
+// as-is and will be rejected by the asserter.
+func SimpleTransferOperations(
+	from *AccountIdentifier,
+	to *AccountIdentifier,
+	amount string,
+	currency *Currency,
+	opType string,
+) []*Operation {
+	debit, credit := amount, amount
+	if value, err := BigInt(amount); err == nil {
+		value.Abs(value)
+		credit = value.String()
+		debit = new(big.Int).Neg(value).String()
+	}
+
+	return []*Operation{
+		{
+			OperationIdentifier: &OperationIdentifier{
+				Index: 0,
+			},
+			Type:    opType,
+			Account: from,
+			Amount: &Amount{
+				Value:    debit,
+				Currency: currency,
+			},
+		},
+		{
+			OperationIdentifier: &OperationIdentifier{
+				Index: 1,
+			},
+			RelatedOperations: []*OperationIdentifier{
+				{
+					Index: 0,
+				},
+			},
+			Type:    opType,
+			Account: to,
+			Amount: &Amount{
+				Value:    credit,
+				Currency: currency,
+			},
+		},
+	}
+}
+
+// operationIndex returns the index of an operation
+// (or -1 if it has no OperationIdentifier).
+func operationIndex(op *Operation) int64 {
+	if op.OperationIdentifier == nil {
+		return -1
+	}
+
+	return op.OperationIdentifier.Index
+}
+
+// CanonicalizeOperations returns a copy of ops sorted by index,
+// then account, then amount (any remaining ties are broken by the
+// hash of the entire operation). The input slice is not modified
+// (the returned slice contains the same *Operation).
+//
+// This ordering is ONLY meant for comparing operations. Operations
+// stored or delivered by the syncer must keep the order returned
+// by the node (the asserter requires operations to be sorted by
+// index).
+func CanonicalizeOperations(ops []*Operation) []*Operation {
+	sorted := make([]*Operation, len(ops))
+	copy(sorted, ops)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if aIndex, bIndex := operationIndex(a), operationIndex(b); aIndex != bIndex {
+			return aIndex < bIndex
+		}
+
+		if aAccount, bAccount := Hash(a.Account), Hash(b.Account); aAccount != bAccount {
+			return aAccount < bAccount
+		}
+
+		if aAmount, bAmount := Hash(a.Amount), Hash(b.Amount); aAmount != bAmount {
+			return aAmount < bAmount
+		}
+
+		return Hash(a) < Hash(b)
+	})
+
+	return sorted
+}
+
+// TransactionsEqual returns a boolean indicating if two
+// transactions are equal, ignoring the order of their
+// operations (see CanonicalizeOperations).
+func TransactionsEqual(a *Transaction, b *Transaction) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	aCopy, bCopy := *a, *b
+	aCopy.Operations = CanonicalizeOperations(a.Operations)
+	bCopy.Operations = CanonicalizeOperations(b.Operations)
+
+	return Hash(&aCopy) == Hash(&bCopy)
+}
+
+// SignaturesCoverPayloads returns an error if any *SigningPayload
+// in payloads does not have at least one *Signature from the same
+// address (with non-empty bytes). This is useful for detecting
+// under-signing before invoking /construction/combine. Additional
+// signatures (for addresses not in payloads) are ignored.
+func SignaturesCoverPayloads(payloads []*SigningPayload, signatures []*Signature) error {
+	signers := map[string]struct{}{}
+	for _, signature := range signatures {
+		if signature == nil || len(signature.Bytes) == 0 ||
+			signature.SigningPayload == nil ||
+			signature.SigningPayload.AccountIdentifier == nil {
+			continue
+		}
+
+		signers[signature.SigningPayload.AccountIdentifier.Address] = struct{}{}
+	}
+
+	for i, payload := range payloads {
+		if payload.AccountIdentifier == nil {
+			return fmt.Errorf("%w: payload %d", ErrSigningPayloadAccountNil, i)
+		}
+
+		if _, ok := signers[payload.AccountIdentifier.Address]; !ok {
+			return fmt.Errorf(
+				"%w: payload %d for %s",
+				ErrSigningPayloadNotCovered,
+				i,
+				payload.AccountIdentifier.Address,
+			)
+		}
+	}
+
+	return nil
+}
+
+// NewConstructionDeriveRequest returns a
+// *ConstructionDeriveRequest for a *PublicKey
+// on a *NetworkIdentifier (without metadata).
+func NewConstructionDeriveRequest(
+	network *NetworkIdentifier,
+	publicKey *PublicKey,
+) *ConstructionDeriveRequest {
+	return &ConstructionDeriveRequest{
+		NetworkIdentifier: network,
+		PublicKey:         publicKey,
+	}
+}
+
 // String returns a pointer to the
 // string passed as an argument.
 func String(s string) *string {
@@ -15,6 +15,7 @@
 package types
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"math/big"
@@ -41,6 +42,77 @@ func TestConstructPartialBlockIdentifier(t *testing.T) {
 	)
 }
 
+func TestValidatePartialBlockIdentifier(t *testing.T) {
+	var tests = map[string]struct {
+		blockIdentifier *PartialBlockIdentifier
+
+		mode BlockLookupMode
+		err  error
+	}{
+		"nil": {
+			mode: LookupHead,
+		},
+		"empty": {
+			blockIdentifier: &PartialBlockIdentifier{},
+			mode:            LookupHead,
+		},
+		"index": {
+			blockIdentifier: &PartialBlockIdentifier{Index: Int64(0)},
+			mode:            LookupByIndex,
+		},
+		"hash": {
+			blockIdentifier: &PartialBlockIdentifier{Hash: String("block 1")},
+			mode:            LookupByHash,
+		},
+		"index and hash": {
+			blockIdentifier: &PartialBlockIdentifier{
+				Index: Int64(1),
+				Hash:  String("block 1"),
+			},
+			mode: LookupByIndexAndHash,
+		},
+		"negative index": {
+			blockIdentifier: &PartialBlockIdentifier{Index: Int64(-1)},
+			mode:            LookupByIndex,
+			err:             ErrPartialBlockIdentifierIndexInvalid,
+		},
+		"empty hash": {
+			blockIdentifier: &PartialBlockIdentifier{Hash: String("")},
+			mode:            LookupByHash,
+			err:             ErrPartialBlockIdentifierHashEmpty,
+		},
+		"index and empty hash": {
+			blockIdentifier: &PartialBlockIdentifier{
+				Index: Int64(1),
+				Hash:  String(""),
+			},
+			mode: LookupByIndexAndHash,
+			err:  ErrPartialBlockIdentifierHashEmpty,
+		},
+		"negative index and hash": {
+			blockIdentifier: &PartialBlockIdentifier{
+				Index: Int64(-1),
+				Hash:  String("block 1"),
+			},
+			mode: LookupByIndexAndHash,
+			err:  ErrPartialBlockIdentifierIndexInvalid,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidatePartialBlockIdentifier(test.blockIdentifier)
+			if test.err != nil {
+				assert.True(t, errors.Is(err, test.err))
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, test.mode, PartialBlockIdentifierLookupMode(test.blockIdentifier))
+		})
+	}
+}
+
 func TestHash(t *testing.T) {
 	var tests = map[string][]interface{}{
 		"simple": {
@@ -314,6 +386,267 @@ func TestGetAccountString(t *testing.T) {
 	}
 }
 
+func TestAccountIdentifierHash(t *testing.T) {
+	var tests = map[string]struct {
+		a *AccountIdentifier
+		b *AccountIdentifier
+
+		equal bool
+	}{
+		"same address": {
+			a:     &AccountIdentifier{Address: "hello"},
+			b:     &AccountIdentifier{Address: "hello"},
+			equal: true,
+		},
+		"different subaccount metadata ordering": {
+			a: &AccountIdentifier{
+				Address: "hello",
+				SubAccount: &SubAccountIdentifier{
+					Address: "stake",
+					Metadata: map[string]interface{}{
+						"validator": "v1",
+						"extra":     json.RawMessage(`{"epoch":2,"pool":"a"}`),
+					},
+				},
+			},
+			b: &AccountIdentifier{
+				Address: "hello",
+				SubAccount: &SubAccountIdentifier{
+					Address: "stake",
+					Metadata: map[string]interface{}{
+						"extra":     json.RawMessage(`{"pool":"a","epoch":2}`),
+						"validator": "v1",
+					},
+				},
+			},
+			equal: true,
+		},
+		"different subaccount address": {
+			a: &AccountIdentifier{
+				Address: "hello",
+				SubAccount: &SubAccountIdentifier{
+					Address:  "stake",
+					Metadata: map[string]interface{}{"validator": "v1"},
+				},
+			},
+			b: &AccountIdentifier{
+				Address: "hello",
+				SubAccount: &SubAccountIdentifier{
+					Address:  "unstake",
+					Metadata: map[string]interface{}{"validator": "v1"},
+				},
+			},
+		},
+		"missing subaccount": {
+			a: &AccountIdentifier{Address: "hello"},
+			b: &AccountIdentifier{
+				Address:    "hello",
+				SubAccount: &SubAccountIdentifier{Address: "stake"},
+			},
+		},
+		"different subaccount metadata": {
+			a: &AccountIdentifier{
+				Address: "hello",
+				SubAccount: &SubAccountIdentifier{
+					Address:  "stake",
+					Metadata: map[string]interface{}{"validator": "v1"},
+				},
+			},
+			b: &AccountIdentifier{
+				Address: "hello",
+				SubAccount: &SubAccountIdentifier{
+					Address:  "stake",
+					Metadata: map[string]interface{}{"validator": "v2"},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			hashA := AccountIdentifierHash(test.a)
+			hashB := AccountIdentifierHash(test.b)
+			assert.Equal(t, test.equal, hashA == hashB)
+			assert.Equal(t, Hash(test.a), hashA)
+		})
+	}
+}
+
+func TestCurrenciesEqual(t *testing.T) {
+	var tests = map[string]struct {
+		a        *Currency
+		b        *Currency
+		expected bool
+	}{
+		"both nil": {
+			expected: true,
+		},
+		"one nil": {
+			a:        &Currency{Symbol: "BTC", Decimals: 8},
+			expected: false,
+		},
+		"simple currencies": {
+			a:        &Currency{Symbol: "BTC", Decimals: 8},
+			b:        &Currency{Symbol: "BTC", Decimals: 8},
+			expected: true,
+		},
+		"metadata in different order": {
+			a: &Currency{
+				Symbol:   "BTC",
+				Decimals: 8,
+				Metadata: map[string]interface{}{
+					"issuer": "satoshi",
+					"count":  10,
+					"nested": map[string]interface{}{"a": 1, "b": 2},
+				},
+			},
+			b: &Currency{
+				Symbol:   "BTC",
+				Decimals: 8,
+				Metadata: map[string]interface{}{
+					"nested": map[string]interface{}{"b": 2, "a": 1},
+					"count":  10,
+					"issuer": "satoshi",
+				},
+			},
+			expected: true,
+		},
+		"different metadata": {
+			a: &Currency{
+				Symbol:   "BTC",
+				Decimals: 8,
+				Metadata: map[string]interface{}{"issuer": "satoshi"},
+			},
+			b: &Currency{
+				Symbol:   "BTC",
+				Decimals: 8,
+				Metadata: map[string]interface{}{"issuer": "hal"},
+			},
+			expected: false,
+		},
+		"nil and empty metadata": {
+			a: &Currency{Symbol: "BTC", Decimals: 8},
+			b: &Currency{
+				Symbol:   "BTC",
+				Decimals: 8,
+				Metadata: map[string]interface{}{},
+			},
+			expected: true,
+		},
+		"missing metadata": {
+			a: &Currency{
+				Symbol:   "BTC",
+				Decimals: 8,
+				Metadata: map[string]interface{}{"issuer": "satoshi"},
+			},
+			b:        &Currency{Symbol: "BTC", Decimals: 8},
+			expected: false,
+		},
+		"different decimals": {
+			a: &Currency{
+				Symbol:   "BTC",
+				Decimals: 8,
+				Metadata: map[string]interface{}{"issuer": "satoshi"},
+			},
+			b: &Currency{
+				Symbol:   "BTC",
+				Decimals: 9,
+				Metadata: map[string]interface{}{"issuer": "satoshi"},
+			},
+			expected: false,
+		},
+		"different symbols": {
+			a:        &Currency{Symbol: "BTC", Decimals: 8},
+			b:        &Currency{Symbol: "ETH", Decimals: 8},
+			expected: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, CurrenciesEqual(test.a, test.b))
+			assert.Equal(t, test.expected, CurrenciesEqual(test.b, test.a))
+		})
+	}
+}
+
+func TestAccountsInBlock(t *testing.T) {
+	staking := &AccountIdentifier{
+		Address: "addr1",
+		SubAccount: &SubAccountIdentifier{
+			Address: "staking",
+			Metadata: map[string]interface{}{
+				"other_complex_stuff": []interface{}{
+					map[string]interface{}{"neat": "test"},
+					map[string]interface{}{"i love": "ice cream"},
+				},
+			},
+		},
+	}
+	statuses := []*OperationStatus{
+		{Status: "Success", Successful: true},
+		{Status: "Failure", Successful: false},
+	}
+	op := func(index int64, account *AccountIdentifier) *Operation {
+		return &Operation{
+			OperationIdentifier: &OperationIdentifier{Index: index},
+			Type:                "Transfer",
+			Status:              String("Success"),
+			Account:             account,
+		}
+	}
+	failed := op(3, &AccountIdentifier{Address: "addr3"})
+	failed.Status = String("Failure")
+	unknown := op(4, &AccountIdentifier{Address: "addr4"})
+	unknown.Status = String("Unknown")
+	missing := op(5, &AccountIdentifier{Address: "addr5"})
+	missing.Status = nil
+
+	block := &Block{
+		BlockIdentifier: &BlockIdentifier{Hash: "blah 3", Index: 3},
+		Transactions: []*Transaction{
+			{
+				TransactionIdentifier: &TransactionIdentifier{Hash: "tx 1"},
+				Operations: []*Operation{
+					op(0, staking),
+					op(1, &AccountIdentifier{Address: "addr1"}),
+					op(2, nil),
+				},
+			},
+			{
+				TransactionIdentifier: &TransactionIdentifier{Hash: "tx 2"},
+				Operations: []*Operation{
+					op(0, &AccountIdentifier{Address: "addr2"}),
+					op(1, &AccountIdentifier{
+						Address: "addr1",
+						SubAccount: &SubAccountIdentifier{
+							Address: "staking",
+							Metadata: map[string]interface{}{
+								"other_complex_stuff": []interface{}{
+									map[string]interface{}{"neat": "test"},
+									map[string]interface{}{"i love": "ice cream"},
+								},
+							},
+						},
+					}),
+					op(2, &AccountIdentifier{Address: "addr1"}),
+					failed,
+					unknown,
+					missing,
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []*AccountIdentifier{
+		staking,
+		{Address: "addr1"},
+		{Address: "addr2"},
+	}, AccountsInBlock(block, statuses))
+	assert.Equal(t, []*AccountIdentifier{}, AccountsInBlock(&Block{}, statuses))
+	assert.Equal(t, []*AccountIdentifier{}, AccountsInBlock(block, nil))
+}
+
 func TestCurrencyString(t *testing.T) {
 	var tests = map[string]struct {
 		currency *Currency
@@ -586,6 +919,87 @@ func TestAmountValue(t *testing.T) {
 	}
 }
 
+func TestAmountMagnitude(t *testing.T) {
+	var tests = map[string]struct {
+		amount *Amount
+		result string
+
+		err error
+	}{
+		"zero decimals": {
+			amount: &Amount{
+				Value:    "100",
+				Currency: &Currency{Symbol: "BLAH", Decimals: 0},
+			},
+			result: "100",
+		},
+		"negative value": {
+			amount: &Amount{
+				Value:    "-12345",
+				Currency: &Currency{Symbol: "BTC", Decimals: 8},
+			},
+			result: "-0.00012345",
+		},
+		"zero value": {
+			amount: &Amount{
+				Value:    "0",
+				Currency: &Currency{Symbol: "ETH", Decimals: 18},
+			},
+			result: "0",
+		},
+		"large decimals": {
+			amount: &Amount{
+				Value:    "123456789012345678901234567890",
+				Currency: &Currency{Symbol: "ETH", Decimals: 18},
+			},
+			result: "123456789012.345678901234567890",
+		},
+		"very large decimals": {
+			amount: &Amount{
+				Value:    "1",
+				Currency: &Currency{Symbol: "TINY", Decimals: 100},
+			},
+			result: "0.0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000001",
+		},
+		"negative decimals": {
+			amount: &Amount{
+				Value:    "100",
+				Currency: &Currency{Symbol: "BLAH", Decimals: -1},
+			},
+			err: ErrCurrencyDecimalsInvalid,
+		},
+		"nil currency": {
+			amount: &Amount{Value: "100"},
+			err:    ErrAmountCurrencyNil,
+		},
+		"invalid value": {
+			amount: &Amount{
+				Value:    "100.1",
+				Currency: &Currency{Symbol: "BLAH", Decimals: 2},
+			},
+			err: errors.New("100.1 is not an integer"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			result, err := AmountMagnitude(test.amount)
+			if test.err != nil {
+				assert.Nil(result)
+				assert.Contains(err.Error(), test.err.Error())
+				return
+			}
+
+			assert.NoError(err)
+			expected, ok := new(big.Float).SetPrec(result.Prec()).SetString(test.result)
+			assert.True(ok)
+			assert.Equal(0, expected.Cmp(result), result.Text('f', -1))
+		})
+	}
+}
+
 func TestExtractAmount(t *testing.T) {
 	var (
 		currency1 = &Currency{
@@ -638,3 +1052,453 @@ func TestExtractAmount(t *testing.T) {
 		assert.Equal(t, amount2, result)
 	})
 }
+
+func TestWriteStruct(t *testing.T) {
+	var tests = map[string]struct {
+		val      interface{}
+		expected string
+		err      bool
+	}{
+		"block identifier": {
+			val: &BlockIdentifier{
+				Index: 1,
+				Hash:  "block 1",
+			},
+			expected: `{"index":1,"hash":"block 1"}`,
+		},
+		"raw message": {
+			val: map[string]interface{}{
+				"b": json.RawMessage(`{"z": 1, "a": "<html>"}`),
+				"a": nil,
+			},
+			expected: `{"a":null,"b":{"z":1,"a":"\u003chtml\u003e"}}`,
+		},
+		"unsupported type": {
+			val: make(chan int),
+			err: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := WriteStruct(&buf, test.val)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected+"\n", buf.String())
+
+			// PrintStruct must match json.Marshal exactly.
+			marshaled, err := json.Marshal(test.val)
+			assert.NoError(t, err)
+			assert.Equal(t, string(marshaled), PrintStruct(test.val))
+			assert.Equal(t, test.expected, PrintStruct(test.val))
+		})
+	}
+}
+
+func relatedOperation(index int64, related ...int64) *Operation {
+	op := &Operation{
+		OperationIdentifier: &OperationIdentifier{Index: index},
+		Type:                "transfer",
+	}
+	for _, r := range related {
+		op.RelatedOperations = append(op.RelatedOperations, &OperationIdentifier{Index: r})
+	}
+
+	return op
+}
+
+func TestGroupRelatedOperations(t *testing.T) {
+	var tests = map[string]struct {
+		ops      []*Operation
+		expected [][]int64
+		err      bool
+	}{
+		"no operations": {
+			ops:      []*Operation{},
+			expected: [][]int64{},
+		},
+		"unrelated operations": {
+			ops: []*Operation{
+				relatedOperation(0),
+				relatedOperation(1),
+			},
+			expected: [][]int64{{0}, {1}},
+		},
+		"transfers": {
+			ops: []*Operation{
+				relatedOperation(0),
+				relatedOperation(1),
+				relatedOperation(2, 0),
+				relatedOperation(3, 1),
+				relatedOperation(4),
+			},
+			expected: [][]int64{{0, 2}, {1, 3}, {4}},
+		},
+		"transitive relations": {
+			ops: []*Operation{
+				relatedOperation(0),
+				relatedOperation(1),
+				relatedOperation(2),
+				relatedOperation(3, 2),
+				relatedOperation(4, 0, 3),
+				relatedOperation(5, 1),
+			},
+			expected: [][]int64{{0, 2, 3, 4}, {1, 5}},
+		},
+		"related to later operation": {
+			ops: []*Operation{
+				relatedOperation(0, 1),
+				relatedOperation(1),
+			},
+			err: true,
+		},
+		"related to self": {
+			ops: []*Operation{
+				relatedOperation(0, 0),
+			},
+			err: true,
+		},
+		"duplicate related operation": {
+			ops: []*Operation{
+				relatedOperation(0),
+				relatedOperation(1, 0, 0),
+			},
+			err: true,
+		},
+		"unsorted operations": {
+			ops: []*Operation{
+				relatedOperation(1),
+				relatedOperation(0),
+			},
+			err: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			groups, err := GroupRelatedOperations(&Transaction{Operations: test.ops})
+			if test.err {
+				assert.Error(t, err)
+				assert.Nil(t, groups)
+				return
+			}
+
+			assert.NoError(t, err)
+			indexes := [][]int64{}
+			for _, group := range groups {
+				groupIndexes := []int64{}
+				for _, op := range group {
+					groupIndexes = append(groupIndexes, op.OperationIdentifier.Index)
+				}
+				indexes = append(indexes, groupIndexes)
+			}
+			assert.Equal(t, test.expected, indexes)
+		})
+	}
+}
+
+func TestSimpleTransferOperations(t *testing.T) {
+	var (
+		from     = &AccountIdentifier{Address: "from"}
+		to       = &AccountIdentifier{Address: "to"}
+		currency = &Currency{Symbol: "BTC", Decimals: 8}
+	)
+
+	var tests = map[string]struct {
+		amount string
+		debit  string
+		credit string
+	}{
+		"positive amount": {
+			amount: "100",
+			debit:  "-100",
+			credit: "100",
+		},
+		"negative amount": {
+			amount: "-100",
+			debit:  "-100",
+			credit: "100",
+		},
+		"invalid amount": {
+			amount: "hello",
+			debit:  "hello",
+			credit: "hello",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ops := SimpleTransferOperations(from, to, test.amount, currency, "PAYMENT")
+			assert.Equal(t, []*Operation{
+				{
+					OperationIdentifier: &OperationIdentifier{Index: 0},
+					Type:                "PAYMENT",
+					Account:             from,
+					Amount:              &Amount{Value: test.debit, Currency: currency},
+				},
+				{
+					OperationIdentifier: &OperationIdentifier{Index: 1},
+					RelatedOperations:   []*OperationIdentifier{{Index: 0}},
+					Type:                "PAYMENT",
+					Account:             to,
+					Amount:              &Amount{Value: test.credit, Currency: currency},
+				},
+			}, ops)
+
+			groups, err := GroupRelatedOperations(&Transaction{Operations: ops})
+			assert.NoError(t, err)
+			assert.Len(t, groups, 1)
+		})
+	}
+}
+
+func TestSumOperationAmounts(t *testing.T) {
+	var (
+		currency = &Currency{Symbol: "BTC", Decimals: 8}
+		other    = &Currency{Symbol: "ETH", Decimals: 18}
+
+		operation = func(opType string, value string, currency *Currency) *Operation {
+			return &Operation{
+				Type:   opType,
+				Amount: &Amount{Value: value, Currency: currency},
+			}
+		}
+
+		isFee = func(op *Operation) bool {
+			return op.Type == "FEE"
+		}
+	)
+
+	var tests = map[string]struct {
+		ops    []*Operation
+		filter func(*Operation) bool
+		sum    *Amount
+		err    bool
+	}{
+		"fees": {
+			ops: []*Operation{
+				operation("FEE", "-10", currency),
+				operation("PAYMENT", "-100", currency),
+				operation("PAYMENT", "100", currency),
+				operation("FEE", "-5", currency),
+				{Type: "FEE"},
+			},
+			filter: isFee,
+			sum:    &Amount{Value: "-15", Currency: currency},
+		},
+		"no filter": {
+			ops: []*Operation{
+				operation("FEE", "-10", currency),
+				operation("PAYMENT", "100", currency),
+			},
+			sum: &Amount{Value: "90", Currency: currency},
+		},
+		"no matching operations": {
+			ops: []*Operation{
+				operation("PAYMENT", "100", currency),
+			},
+			filter: isFee,
+		},
+		"ignore filtered currencies": {
+			ops: []*Operation{
+				operation("FEE", "-10", currency),
+				operation("PAYMENT", "100", other),
+			},
+			filter: isFee,
+			sum:    &Amount{Value: "-10", Currency: currency},
+		},
+		"mixed currencies": {
+			ops: []*Operation{
+				operation("FEE", "-10", currency),
+				operation("FEE", "-10", other),
+			},
+			filter: isFee,
+			err:    true,
+		},
+		"invalid value": {
+			ops: []*Operation{
+				operation("FEE", "-10", currency),
+				operation("FEE", "1.5", currency),
+			},
+			filter: isFee,
+			err:    true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			sum, err := SumOperationAmounts(test.ops, test.filter)
+			if test.err {
+				assert.Error(t, err)
+				assert.Nil(t, sum)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.sum, sum)
+		})
+	}
+}
+
+func TestCanonicalizeOperations(t *testing.T) {
+	operation := func(index int64, address string, value string) *Operation {
+		return &Operation{
+			OperationIdentifier: &OperationIdentifier{Index: index},
+			Type:                "PAYMENT",
+			Account:             &AccountIdentifier{Address: address},
+			Amount: &Amount{
+				Value:    value,
+				Currency: &Currency{Symbol: "BTC", Decimals: 8},
+			},
+		}
+	}
+
+	ops := []*Operation{
+		operation(2, "addr1", "10"),
+		operation(0, "addr2", "-10"),
+		operation(1, "addr1", "5"),
+		operation(1, "addr1", "-5"),
+		{Type: "FEE"},
+	}
+	original := make([]*Operation, len(ops))
+	copy(original, ops)
+
+	sorted := CanonicalizeOperations(ops)
+	assert.Equal(t, original, ops)
+	assert.Len(t, sorted, len(ops))
+	assert.Equal(t, ops[4], sorted[0])
+	assert.Equal(t, ops[1], sorted[1])
+	assert.ElementsMatch(t, []*Operation{ops[2], ops[3]}, sorted[2:4])
+	assert.Equal(t, ops[0], sorted[4])
+
+	// The order is independent of the input order
+	reversed := make([]*Operation, len(ops))
+	for i, op := range ops {
+		reversed[len(ops)-1-i] = op
+	}
+	assert.Equal(t, sorted, CanonicalizeOperations(reversed))
+	assert.Equal(t, []*Operation{}, CanonicalizeOperations([]*Operation{}))
+
+	// Transactions with reordered operations are equal
+	txA := &Transaction{
+		TransactionIdentifier: &TransactionIdentifier{Hash: "tx"},
+		Operations:            ops,
+	}
+	txB := &Transaction{
+		TransactionIdentifier: &TransactionIdentifier{Hash: "tx"},
+		Operations:            reversed,
+	}
+	assert.True(t, TransactionsEqual(txA, txB))
+	assert.Equal(t, ops, txA.Operations)
+
+	txB.Operations = reversed[1:]
+	assert.False(t, TransactionsEqual(txA, txB))
+	assert.False(t, TransactionsEqual(txA, nil))
+	assert.True(t, TransactionsEqual(nil, nil))
+}
+
+func TestSignaturesCoverPayloads(t *testing.T) {
+	payload := func(address string) *SigningPayload {
+		return &SigningPayload{
+			AccountIdentifier: &AccountIdentifier{Address: address},
+			Bytes:             []byte("payload " + address),
+			SignatureType:     Ecdsa,
+		}
+	}
+	signature := func(address string, bytes []byte) *Signature {
+		return &Signature{
+			SigningPayload: payload(address),
+			PublicKey:      &PublicKey{Bytes: []byte("key"), CurveType: Secp256k1},
+			SignatureType:  Ecdsa,
+			Bytes:          bytes,
+		}
+	}
+
+	var tests = map[string]struct {
+		payloads   []*SigningPayload
+		signatures []*Signature
+
+		err error
+	}{
+		"full coverage": {
+			payloads: []*SigningPayload{payload("addr1"), payload("addr2")},
+			signatures: []*Signature{
+				signature("addr2", []byte("sig2")),
+				signature("addr1", []byte("sig1")),
+			},
+		},
+		"multiple payloads for one address": {
+			payloads:   []*SigningPayload{payload("addr1"), payload("addr1")},
+			signatures: []*Signature{signature("addr1", []byte("sig1"))},
+		},
+		"no payloads": {
+			signatures: []*Signature{signature("addr1", []byte("sig1"))},
+		},
+		"over coverage": {
+			payloads: []*SigningPayload{payload("addr1")},
+			signatures: []*Signature{
+				signature("addr1", []byte("sig1")),
+				signature("addr1", []byte("sig1 again")),
+				signature("addr3", []byte("sig3")),
+			},
+		},
+		"partial coverage": {
+			payloads:   []*SigningPayload{payload("addr1"), payload("addr2")},
+			signatures: []*Signature{signature("addr1", []byte("sig1"))},
+			err:        ErrSigningPayloadNotCovered,
+		},
+		"no signatures": {
+			payloads: []*SigningPayload{payload("addr1")},
+			err:      ErrSigningPayloadNotCovered,
+		},
+		"empty signature bytes": {
+			payloads:   []*SigningPayload{payload("addr1")},
+			signatures: []*Signature{signature("addr1", []byte{})},
+			err:        ErrSigningPayloadNotCovered,
+		},
+		"nil payload account": {
+			payloads:   []*SigningPayload{{Bytes: []byte("payload")}},
+			signatures: []*Signature{signature("addr1", []byte("sig1"))},
+			err:        ErrSigningPayloadAccountNil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := SignaturesCoverPayloads(test.payloads, test.signatures)
+			if test.err != nil {
+				assert.True(t, errors.Is(err, test.err))
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewConstructionDeriveRequest(t *testing.T) {
+	network := &NetworkIdentifier{
+		Blockchain: "hello",
+		Network:    "world",
+	}
+	publicKey := &PublicKey{
+		Bytes:     []byte("hello"),
+		CurveType: Secp256k1,
+	}
+
+	request := NewConstructionDeriveRequest(network, publicKey)
+	assert.Equal(t, &ConstructionDeriveRequest{
+		NetworkIdentifier: network,
+		PublicKey:         publicKey,
+	}, request)
+
+	// The request marshals to the same JSON as a hand-assembled request
+	raw := `{"network_identifier":{"blockchain":"hello","network":"world"},` +
+		`"public_key":{"hex_bytes":"68656c6c6f","curve_type":"secp256k1"}}`
+	assert.JSONEq(t, raw, PrintStruct(request))
+}
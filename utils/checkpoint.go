@@ -0,0 +1,125 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+// ErrCheckpointInvalid is returned by ReadCheckpoint when
+// a checkpoint file cannot be parsed.
+var ErrCheckpointInvalid = errors.New("checkpoint is invalid")
+
+// checkpoint is the JSON representation of the
+// state needed to resume syncing.
+type checkpoint struct {
+	BlockIdentifier *types.BlockIdentifier   `json:"block_identifier"`
+	PastBlocks      []*types.BlockIdentifier `json:"past_blocks"`
+}
+
+// WriteCheckpoint atomically writes the last processed
+// blockID and pastBlocks (the blocks the syncer tracks
+// to handle reorgs) as JSON to filePath. The checkpoint
+// is written to a temporary file in the same directory
+// and then renamed to filePath, so a crash while writing
+// never leaves a partially written checkpoint behind.
+func WriteCheckpoint(
+	filePath string,
+	blockID *types.BlockIdentifier,
+	pastBlocks []*types.BlockIdentifier,
+) error {
+	b, err := json.Marshal(&checkpoint{
+		BlockIdentifier: blockID,
+		PastBlocks:      pastBlocks,
+	})
+	if err != nil {
+		return fmt.Errorf("%w: unable to marshal checkpoint", err)
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(filePath), filepath.Base(filePath)+".tmp")
+	if err != nil {
+		return fmt.Errorf("%w: unable to create temporary file for %s", err, filePath)
+	}
+
+	tmpPath := tmp.Name()
+	if err := writeAndSync(tmp, b); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("%w: unable to write to file path %s", err, tmpPath)
+	}
+
+	if err := os.Rename(tmpPath, filePath); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("%w: unable to rename %s to %s", err, tmpPath, filePath)
+	}
+
+	return nil
+}
+
+// writeAndSync writes b to f and flushes it
+// to disk before closing f.
+func writeAndSync(f *os.File, b []byte) error {
+	if _, err := f.Write(b); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// ReadCheckpoint reads a checkpoint written by WriteCheckpoint
+// and returns the last processed block and past blocks. These
+// can be used to resume syncing after a restart (by providing
+// the past blocks with syncer.WithPastBlocks and starting
+// at the index after the last processed block).
+//
+// If the checkpoint does not exist, the returned error wraps
+// os.ErrNotExist. If it cannot be parsed, the returned error
+// wraps ErrCheckpointInvalid.
+func ReadCheckpoint(
+	filePath string,
+) (*types.BlockIdentifier, []*types.BlockIdentifier, error) {
+	b, err := ioutil.ReadFile(path.Clean(filePath))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: unable to load file %s", err, filePath)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+
+	var c checkpoint
+	if err := dec.Decode(&c); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrCheckpointInvalid, err)
+	}
+
+	if c.BlockIdentifier == nil {
+		return nil, nil, fmt.Errorf("%w: block identifier is missing", ErrCheckpointInvalid)
+	}
+
+	return c.BlockIdentifier, c.PastBlocks, nil
+}
@@ -0,0 +1,136 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+func dirEntries(t *testing.T, dir string) []string {
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+
+	names := []string{}
+	for _, f := range files {
+		names = append(names, f.Name())
+	}
+
+	return names
+}
+
+func TestCheckpoint(t *testing.T) {
+	dir, err := CreateTempDir()
+	assert.NoError(t, err)
+	defer RemoveTempDir(dir)
+
+	checkpointPath := path.Join(dir, "checkpoint.json")
+	pastBlocks := []*types.BlockIdentifier{
+		{Hash: "block 9", Index: 9},
+		{Hash: "block 10", Index: 10},
+	}
+
+	t.Run("missing checkpoint", func(t *testing.T) {
+		blockID, past, err := ReadCheckpoint(checkpointPath)
+		assert.Nil(t, blockID)
+		assert.Nil(t, past)
+		assert.True(t, errors.Is(err, os.ErrNotExist))
+	})
+
+	t.Run("write and read", func(t *testing.T) {
+		assert.NoError(t, WriteCheckpoint(checkpointPath, pastBlocks[1], pastBlocks))
+
+		blockID, past, err := ReadCheckpoint(checkpointPath)
+		assert.NoError(t, err)
+		assert.Equal(t, pastBlocks[1], blockID)
+		assert.Equal(t, pastBlocks, past)
+		assert.Equal(t, []string{"checkpoint.json"}, dirEntries(t, dir))
+	})
+
+	t.Run("overwrite", func(t *testing.T) {
+		newBlock := &types.BlockIdentifier{Hash: "block 11", Index: 11}
+		assert.NoError(t, WriteCheckpoint(checkpointPath, newBlock, nil))
+
+		blockID, past, err := ReadCheckpoint(checkpointPath)
+		assert.NoError(t, err)
+		assert.Equal(t, newBlock, blockID)
+		assert.Nil(t, past)
+		assert.Equal(t, []string{"checkpoint.json"}, dirEntries(t, dir))
+	})
+
+	t.Run("failed write", func(t *testing.T) {
+		// Renaming onto a non-empty directory fails, which
+		// must not leave a temporary file behind.
+		dirPath := path.Join(dir, "occupied")
+		assert.NoError(t, EnsurePathExists(path.Join(dirPath, "child")))
+
+		assert.Error(t, WriteCheckpoint(dirPath, pastBlocks[1], pastBlocks))
+		assert.ElementsMatch(t, []string{"checkpoint.json", "occupied"}, dirEntries(t, dir))
+
+		// The existing checkpoint is untouched
+		blockID, _, err := ReadCheckpoint(checkpointPath)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(11), blockID.Index)
+	})
+}
+
+func TestReadCheckpointCorrupt(t *testing.T) {
+	var tests = map[string]struct {
+		contents string
+	}{
+		"empty": {
+			contents: "",
+		},
+		"truncated": {
+			contents: `{"block_identifier":{"index":10,"ha`,
+		},
+		"not json": {
+			contents: "hello",
+		},
+		"unknown field": {
+			contents: `{"block_identifier":{"index":10,"hash":"block 10"},"tip":1}`,
+		},
+		"missing block identifier": {
+			contents: `{"past_blocks":[]}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir, err := CreateTempDir()
+			assert.NoError(t, err)
+			defer RemoveTempDir(dir)
+
+			checkpointPath := path.Join(dir, "checkpoint.json")
+			assert.NoError(t, ioutil.WriteFile(
+				checkpointPath,
+				[]byte(test.contents),
+				os.FileMode(DefaultFilePermissions),
+			))
+
+			blockID, past, err := ReadCheckpoint(checkpointPath)
+			assert.Nil(t, blockID)
+			assert.Nil(t, past)
+			assert.True(t, errors.Is(err, ErrCheckpointInvalid))
+		})
+	}
+}
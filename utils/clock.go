@@ -0,0 +1,39 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import "time"
+
+// Clock provides the current time and a way to sleep. Injecting
+// a Clock makes it possible to test time-dependent behavior (like
+// tip detection and stall timeouts) deterministically.
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+}
+
+// RealClock is a Clock backed by the time package.
+type RealClock struct{}
+
+// Now returns the current local time.
+func (RealClock) Now() time.Time {
+	return time.Now()
+}
+
+// Sleep pauses the current goroutine for at least the
+// duration d.
+func (RealClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
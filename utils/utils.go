@@ -70,6 +70,10 @@ var (
 	// you are attempting to connect to is not supported.
 	ErrNetworkNotSupported = errors.New("network not supported")
 
+	// ErrDuplicateCurrency is returned by DiffBalances when
+	// a set of balances contains a currency more than once.
+	ErrDuplicateCurrency = errors.New("duplicate currency")
+
 	// OneHundredInt is a big.Int of value 100.
 	OneHundredInt = big.NewInt(OneHundred)
 
@@ -197,6 +201,38 @@ type BlockStorageHelper interface {
 	// to this interface.
 }
 
+// NetworkStatusChanged compares the current block of two
+// *types.NetworkStatusResponse (usually from consecutive polls of
+// /network/status). advanced is true if the current block index
+// increased. reorged is true if the current block index decreased or
+// the current block hash changed at the same index. If neither is
+// true, the node has not made progress since prev.
+//
+// If prev is nil, curr is considered to have advanced.
+func NetworkStatusChanged(
+	prev *types.NetworkStatusResponse,
+	curr *types.NetworkStatusResponse,
+) (bool, bool) {
+	if curr == nil || curr.CurrentBlockIdentifier == nil {
+		return false, false
+	}
+
+	if prev == nil || prev.CurrentBlockIdentifier == nil {
+		return true, false
+	}
+
+	prevBlock := prev.CurrentBlockIdentifier
+	currBlock := curr.CurrentBlockIdentifier
+	switch {
+	case currBlock.Index > prevBlock.Index:
+		return true, false
+	case currBlock.Index < prevBlock.Index:
+		return false, true
+	default:
+		return false, currBlock.Hash != prevBlock.Hash
+	}
+}
+
 // CheckNetworkTip returns a boolean indicating if the block returned by
 // network/status is at tip. It also returns the block identifier
 // returned by network/status.
@@ -376,6 +412,77 @@ func ContainsAccountIdentifier(arr []*types.AccountIdentifier, s *types.AccountI
 	return false
 }
 
+// balanceValues returns a map (keyed by types.Hash(currency))
+// of the value of each balance in a set of balances.
+func balanceValues(balances []*types.Amount) (map[string]*big.Int, error) {
+	values := make(map[string]*big.Int, len(balances))
+	for _, balance := range balances {
+		if balance == nil || balance.Currency == nil {
+			return nil, fmt.Errorf("balance %s is missing a currency", types.PrintStruct(balance))
+		}
+
+		key := types.Hash(balance.Currency)
+		if _, ok := values[key]; ok {
+			return nil, fmt.Errorf(
+				"%w: %s",
+				ErrDuplicateCurrency,
+				types.PrintStruct(balance.Currency),
+			)
+		}
+
+		value, err := types.AmountValue(balance)
+		if err != nil {
+			return nil, err
+		}
+
+		values[key] = value
+	}
+
+	return values, nil
+}
+
+// DiffBalances returns a map (keyed by types.Hash(currency))
+// of the signed difference (computed - reported) of each
+// currency with a different balance in computed and reported.
+// A currency missing from either set is considered to have
+// a balance of 0. Currencies with the same balance in both
+// sets are not included in the result.
+func DiffBalances(computed, reported []*types.Amount) (map[string]string, error) {
+	computedValues, err := balanceValues(computed)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to parse computed balances", err)
+	}
+
+	reportedValues, err := balanceValues(reported)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to parse reported balances", err)
+	}
+
+	diff := map[string]string{}
+	for key, value := range computedValues {
+		reportedValue, ok := reportedValues[key]
+		if !ok {
+			reportedValue = big.NewInt(0)
+		}
+
+		if difference := new(big.Int).Sub(value, reportedValue); difference.Sign() != 0 {
+			diff[key] = difference.String()
+		}
+	}
+
+	for key, value := range reportedValues {
+		if _, ok := computedValues[key]; ok {
+			continue
+		}
+
+		if value.Sign() != 0 {
+			diff[key] = new(big.Int).Neg(value).String()
+		}
+	}
+
+	return diff, nil
+}
+
 // PrettyAmount returns a currency amount in native format with
 // its symbol.
 func PrettyAmount(amount *big.Int, currency *types.Currency) string {
@@ -491,7 +598,17 @@ func AtTip(
 	tipDelay int64,
 	blockTimestamp int64,
 ) bool {
-	currentTime := Milliseconds()
+	return AtTipWithClock(RealClock{}, tipDelay, blockTimestamp)
+}
+
+// AtTipWithClock is identical to AtTip except that the
+// current time is determined by the provided Clock.
+func AtTipWithClock(
+	clock Clock,
+	tipDelay int64,
+	blockTimestamp int64,
+) bool {
+	currentTime := clock.Now().UnixNano() / NanosecondsInMillisecond
 	tipCutoff := currentTime - (tipDelay * MillisecondsInSecond)
 
 	return blockTimestamp >= tipCutoff
@@ -514,6 +631,33 @@ func ContextSleep(ctx context.Context, duration time.Duration) error {
 	}
 }
 
+// ContextSleepWithClock is identical to ContextSleep except
+// that the sleep is performed by the provided Clock.
+func ContextSleepWithClock(ctx context.Context, clock Clock, duration time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if _, ok := clock.(RealClock); ok {
+		return ContextSleep(ctx, duration)
+	}
+
+	// An arbitrary Clock cannot be interrupted, so we stop
+	// waiting for it (rather than the sleep itself) on cancel.
+	done := make(chan struct{})
+	go func() {
+		clock.Sleep(duration)
+		close(done)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-done:
+		return nil
+	}
+}
+
 // MemoryUsage contains memory usage stats converted
 // to MBs.
 type MemoryUsage struct {
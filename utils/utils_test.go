@@ -228,6 +228,91 @@ func TestPrettyAmount(t *testing.T) {
 	}
 }
 
+func TestDiffBalances(t *testing.T) {
+	btc := &types.Currency{Symbol: "BTC", Decimals: 8}
+	eth := &types.Currency{Symbol: "ETH", Decimals: 18}
+	usdc := &types.Currency{Symbol: "USDC", Decimals: 6}
+
+	var tests = map[string]struct {
+		computed []*types.Amount
+		reported []*types.Amount
+
+		diff          map[string]string
+		err           bool
+		expectedError error
+	}{
+		"matching": {
+			computed: []*types.Amount{{Value: "100", Currency: btc}},
+			reported: []*types.Amount{{Value: "100", Currency: btc}},
+			diff:     map[string]string{},
+		},
+		"mismatched": {
+			computed: []*types.Amount{
+				{Value: "100", Currency: btc},
+				{Value: "5", Currency: eth},
+			},
+			reported: []*types.Amount{
+				{Value: "10", Currency: eth},
+				{Value: "150", Currency: btc},
+			},
+			diff: map[string]string{
+				types.Hash(btc): "-50",
+				types.Hash(eth): "-5",
+			},
+		},
+		"extra computed currency": {
+			computed: []*types.Amount{
+				{Value: "100", Currency: btc},
+				{Value: "20", Currency: usdc},
+			},
+			reported: []*types.Amount{{Value: "100", Currency: btc}},
+			diff: map[string]string{
+				types.Hash(usdc): "20",
+			},
+		},
+		"missing computed currency": {
+			computed: []*types.Amount{{Value: "100", Currency: btc}},
+			reported: []*types.Amount{
+				{Value: "100", Currency: btc},
+				{Value: "20", Currency: usdc},
+				{Value: "0", Currency: eth},
+			},
+			diff: map[string]string{
+				types.Hash(usdc): "-20",
+			},
+		},
+		"duplicate currency": {
+			computed: []*types.Amount{
+				{Value: "100", Currency: btc},
+				{Value: "100", Currency: btc},
+			},
+			err:           true,
+			expectedError: ErrDuplicateCurrency,
+		},
+		"invalid value": {
+			reported: []*types.Amount{{Value: "1.5", Currency: btc}},
+			err:      true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			diff, err := DiffBalances(test.computed, test.reported)
+			if test.err {
+				assert.Error(t, err)
+				assert.Nil(t, diff)
+				if test.expectedError != nil {
+					assert.True(t, errors.Is(err, test.expectedError))
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.diff, diff)
+		})
+	}
+}
+
 func TestMilliseconds(t *testing.T) {
 	assert.True(t, Milliseconds() > asserter.MinUnixEpoch)
 	assert.True(t, Milliseconds() < asserter.MaxUnixEpoch)
@@ -383,6 +468,60 @@ func TestGetAccountBalances(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestNetworkStatusChanged(t *testing.T) {
+	status := func(hash string, index int64) *types.NetworkStatusResponse {
+		return &types.NetworkStatusResponse{
+			CurrentBlockIdentifier: &types.BlockIdentifier{
+				Hash:  hash,
+				Index: index,
+			},
+		}
+	}
+
+	var tests = map[string]struct {
+		prev *types.NetworkStatusResponse
+		curr *types.NetworkStatusResponse
+
+		advanced bool
+		reorged  bool
+	}{
+		"advance": {
+			prev:     status("block 10", 10),
+			curr:     status("block 12", 12),
+			advanced: true,
+		},
+		"stall": {
+			prev: status("block 10", 10),
+			curr: status("block 10", 10),
+		},
+		"reorg at same index": {
+			prev:    status("block 10", 10),
+			curr:    status("block 10a", 10),
+			reorged: true,
+		},
+		"reorg to lower index": {
+			prev:    status("block 10", 10),
+			curr:    status("block 9a", 9),
+			reorged: true,
+		},
+		"no previous status": {
+			curr:     status("block 10", 10),
+			advanced: true,
+		},
+		"no current status": {
+			prev: status("block 10", 10),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			advanced, reorged := NetworkStatusChanged(test.prev, test.curr)
+			assert.Equal(t, test.advanced, advanced)
+			assert.Equal(t, test.reorged, reorged)
+		})
+	}
+}
+
 func TestCheckNetworkTip(t *testing.T) {
 	ctx := context.Background()
 
@@ -0,0 +1,127 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	versionParts = 3
+)
+
+var (
+	// ErrVersionInvalid is returned when a version
+	// is not of the form major.minor.patch.
+	ErrVersionInvalid = errors.New("version is invalid")
+
+	// ErrVersionConstraintInvalid is returned when a version
+	// constraint cannot be parsed.
+	ErrVersionConstraintInvalid = errors.New("version constraint is invalid")
+)
+
+// versionOperators are the supported constraint operators. Two character
+// operators are listed first so that they are matched before
+// their single character prefixes.
+var versionOperators = []string{">=", "<=", "==", ">", "<", "="}
+
+// ParseVersion parses a version of the form major.minor.patch
+// (i.e. a RosettaVersion). A leading "v" is permitted and any
+// pre-release or build suffix (starting with "-" or "+") is ignored.
+func ParseVersion(version string) (int, int, int, error) {
+	trimmed := strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexAny(trimmed, "-+"); i >= 0 {
+		trimmed = trimmed[:i]
+	}
+
+	parts := strings.Split(trimmed, ".")
+	if len(parts) != versionParts {
+		return 0, 0, 0, fmt.Errorf("%w: %s", ErrVersionInvalid, version)
+	}
+
+	numbers := make([]int, versionParts)
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 {
+			return 0, 0, 0, fmt.Errorf("%w: %s", ErrVersionInvalid, version)
+		}
+
+		numbers[i] = number
+	}
+
+	return numbers[0], numbers[1], numbers[2], nil
+}
+
+// compareVersions returns -1 if a < b, 0 if a == b,
+// and 1 if a > b.
+func compareVersions(a []int, b []int) int {
+	for i := range a {
+		switch {
+		case a[i] < b[i]:
+			return -1
+		case a[i] > b[i]:
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// VersionSatisfies returns a boolean indicating if version
+// satisfies constraint. A constraint is an optional operator
+// (">=", ">", "<=", "<", "=", or "==") followed by a version
+// (i.e. ">=1.4.0"). A constraint without an operator
+// requires an exact match.
+func VersionSatisfies(version string, constraint string) (bool, error) {
+	major, minor, patch, err := ParseVersion(version)
+	if err != nil {
+		return false, err
+	}
+
+	trimmed := strings.TrimSpace(constraint)
+	operator := "="
+	for _, op := range versionOperators {
+		if strings.HasPrefix(trimmed, op) {
+			operator = op
+			trimmed = strings.TrimPrefix(trimmed, op)
+			break
+		}
+	}
+
+	cMajor, cMinor, cPatch, err := ParseVersion(trimmed)
+	if err != nil {
+		return false, fmt.Errorf("%w: %s", ErrVersionConstraintInvalid, constraint)
+	}
+
+	comparison := compareVersions(
+		[]int{major, minor, patch},
+		[]int{cMajor, cMinor, cPatch},
+	)
+	switch operator {
+	case ">=":
+		return comparison >= 0, nil
+	case ">":
+		return comparison > 0, nil
+	case "<=":
+		return comparison <= 0, nil
+	case "<":
+		return comparison < 0, nil
+	default:
+		return comparison == 0, nil
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVersion(t *testing.T) {
+	var tests = map[string]struct {
+		version string
+		major   int
+		minor   int
+		patch   int
+		err     error
+	}{
+		"simple": {
+			version: "1.4.0",
+			major:   1,
+			minor:   4,
+		},
+		"prefix and suffix": {
+			version: "v0.21.10-rc1+build5",
+			minor:   21,
+			patch:   10,
+		},
+		"empty": {
+			version: "",
+			err:     ErrVersionInvalid,
+		},
+		"missing patch": {
+			version: "1.4",
+			err:     ErrVersionInvalid,
+		},
+		"too many parts": {
+			version: "1.4.0.1",
+			err:     ErrVersionInvalid,
+		},
+		"non-numeric": {
+			version: "1.a.0",
+			err:     ErrVersionInvalid,
+		},
+		"negative": {
+			version: "1.-4.0",
+			err:     ErrVersionInvalid,
+		},
+		"empty part": {
+			version: "1..0",
+			err:     ErrVersionInvalid,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			major, minor, patch, err := ParseVersion(test.version)
+			if test.err != nil {
+				assert.True(t, errors.Is(err, test.err))
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.major, major)
+			assert.Equal(t, test.minor, minor)
+			assert.Equal(t, test.patch, patch)
+		})
+	}
+}
+
+func TestVersionSatisfies(t *testing.T) {
+	var tests = map[string]struct {
+		version    string
+		constraint string
+		satisfied  bool
+		err        error
+	}{
+		"greater or equal (equal)": {
+			version:    "1.4.0",
+			constraint: ">=1.4.0",
+			satisfied:  true,
+		},
+		"greater or equal (greater)": {
+			version:    "1.10.0",
+			constraint: ">=1.4.0",
+			satisfied:  true,
+		},
+		"greater or equal (less)": {
+			version:    "1.3.9",
+			constraint: ">=1.4.0",
+		},
+		"greater": {
+			version:    "2.0.0",
+			constraint: "> 1.9.9",
+			satisfied:  true,
+		},
+		"less or equal": {
+			version:    "1.4.1",
+			constraint: "<=1.4.0",
+		},
+		"less": {
+			version:    "1.3.0",
+			constraint: "<1.4.0",
+			satisfied:  true,
+		},
+		"exact": {
+			version:    "1.4.0",
+			constraint: "1.4.0",
+			satisfied:  true,
+		},
+		"equal mismatch": {
+			version:    "1.4.0",
+			constraint: "==1.4.1",
+		},
+		"invalid version": {
+			version:    "1.4",
+			constraint: ">=1.4.0",
+			err:        ErrVersionInvalid,
+		},
+		"invalid constraint": {
+			version:    "1.4.0",
+			constraint: "~>1.4.0",
+			err:        ErrVersionConstraintInvalid,
+		},
+		"empty constraint": {
+			version:    "1.4.0",
+			constraint: ">=",
+			err:        ErrVersionConstraintInvalid,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			satisfied, err := VersionSatisfies(test.version, test.constraint)
+			if test.err != nil {
+				assert.True(t, errors.Is(err, test.err))
+				assert.False(t, satisfied)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.satisfied, satisfied)
+		})
+	}
+}